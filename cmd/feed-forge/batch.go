@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lepinkainen/feed-forge/pkg/feed"
+	"github.com/lepinkainen/feed-forge/pkg/providers"
+)
+
+// batchFile is the YAML file read by the batch command.
+type batchFile struct {
+	Jobs []batchJob `yaml:"jobs"`
+}
+
+// batchJob is one feed to generate. Config is decoded over the provider's
+// section of the main config file, so a job only lists what it changes.
+type batchJob struct {
+	Provider string    `yaml:"provider"`
+	Config   yaml.Node `yaml:"config"`
+	Outfile  string    `yaml:"outfile"`
+	Format   string    `yaml:"format"` // atom (default), rss, json or html
+}
+
+// loadBatchFile reads and validates a batch file.
+func loadBatchFile(path string) (batchFile, error) {
+	var batch batchFile
+	// #nosec G304 -- batch file is an explicit CLI input, intentionally read from disk.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return batch, err
+	}
+	if err := yaml.Unmarshal(data, &batch); err != nil {
+		return batch, fmt.Errorf("parse batch file %s: %w", path, err)
+	}
+	if len(batch.Jobs) == 0 {
+		return batch, fmt.Errorf("batch file %s has no jobs", path)
+	}
+	for i, job := range batch.Jobs {
+		if job.Provider == "" {
+			return batch, fmt.Errorf("batch job %d: provider is required", i+1)
+		}
+		if !feed.ValidFormat(job.Format) {
+			return batch, fmt.Errorf("batch job %d: unknown format %q (atom, rss, json, html)", i+1, job.Format)
+		}
+	}
+	return batch, nil
+}
+
+// runBatch runs every job in the batch file, at most parallel at once (one per
+// CPU when <= 0), prints one status line per job and returns an error when any failed.
+// Jobs ignore --interval: the batch is run explicitly, so every feed is built.
+func runBatch(path, configPath string, parallel int) error {
+	batch, err := loadBatchFile(path)
+	if err != nil {
+		return err
+	}
+
+	results := runBatchJobs(batch.Jobs, configPath, parallel)
+
+	failed := 0
+	for _, r := range results {
+		if r.Status == "failed" {
+			failed++
+			fmt.Printf("FAIL %s -> %s: %v\n", r.Provider, r.Filename, r.Err)
+			continue
+		}
+		fmt.Printf("ok   %s -> %s (%s)\n", r.Provider, r.Filename, r.Duration.Truncate(time.Millisecond))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d batch job(s) failed", failed, len(results))
+	}
+	return nil
+}
+
+// runBatchJobs runs jobs with at most parallel in flight and returns their
+// results in job order.
+func runBatchJobs(jobs []batchJob, configPath string, parallel int) []feedResult {
+	results := make([]feedResult, len(jobs))
+	providers.RunBounded(len(jobs), parallel, func(i int) {
+		results[i] = runBatchJob(configPath, jobs[i])
+	})
+	return results
+}
+
+// runBatchJob builds one job's provider config and writes its feed.
+func runBatchJob(configPath string, job batchJob) feedResult {
+	result := feedResult{Provider: job.Provider, Status: "failed"}
+	fail := func(err error) feedResult {
+		slog.Error("Batch job failed", "provider", job.Provider, "outfile", result.Filename, "error", err)
+		result.Err = err
+		return result
+	}
+
+	info, err := providers.DefaultRegistry.Get(job.Provider)
+	if err != nil {
+		return fail(err)
+	}
+	result.FeedName = feedName(info, job.Provider)
+
+	var providerConfig any
+	if info.ConfigFactory != nil {
+		providerConfig = info.ConfigFactory()
+		if err := loadProviderConfigFromYAML(configPath, job.Provider, providerConfig); err != nil && !os.IsNotExist(err) {
+			return fail(fmt.Errorf("load provider config: %w", err))
+		}
+		if !job.Config.IsZero() {
+			if err := job.Config.Decode(providerConfig); err != nil {
+				return fail(fmt.Errorf("decode job config: %w", err))
+			}
+		}
+	}
+
+	outfile := job.Outfile
+	if outfile == "" {
+		outfile = providers.GetGenerateConfig(providerConfig).Outfile
+	}
+	if outfile == "" {
+		outfile = job.Provider + ".xml"
+	}
+	outfile = feed.OutfileForFormat(outfile, job.Format)
+	result.Filename = outfile
+	outfile = resolveOutfile(outfile)
+
+	provider, err := providers.DefaultRegistry.CreateProvider(job.Provider, providerConfig)
+	if err != nil {
+		return fail(fmt.Errorf("create provider: %w", err))
+	}
+	defer closeProvider(job.Provider, provider)
+
+	start := time.Now()
+	err = writeFeedAs(provider, job.Provider, outfile, job.Format)
+	result.Duration = time.Since(start)
+	if err != nil {
+		return fail(err)
+	}
+
+	result.Status = "generated"
+	return result
+}
+
+// writeFeedAs writes the provider's feed to outfile in format through the
+// provider's shared generation pipeline. Providers that only implement
+// GenerateFeed support Atom alone.
+func writeFeedAs(provider providers.FeedProvider, name, outfile, format string) error {
+	if generator, ok := provider.(providers.FormatGenerator); ok {
+		return generator.GenerateFeedAs(outfile, format)
+	}
+	if format != "" && format != feed.FormatAtom {
+		return fmt.Errorf("provider %q does not support %s output", name, format)
+	}
+	return provider.GenerateFeed(outfile)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lepinkainen/feed-forge/pkg/providers"
+)
+
+type failingProvider struct{ stubProvider }
+
+func (f *failingProvider) GenerateFeed(string) error { return errors.New("upstream down") }
+
+func TestRunBatch_ReportsEachJobAndFailsWhenAnyFails(t *testing.T) {
+	oldCLI := CLI
+	t.Cleanup(func() { CLI = oldCLI })
+	CLI.OutputDir = t.TempDir()
+
+	withTestRegistry(t, func(r *providers.ProviderRegistry) {
+		if err := r.Register("good", &providers.ProviderInfo{
+			Name: "good",
+			Factory: func(config any) (providers.FeedProvider, error) {
+				return &stubProvider{cfg: config.(*stubConfig)}, nil
+			},
+			ConfigFactory: func() any { return &stubConfig{} },
+		}); err != nil {
+			t.Fatalf("Register(good) error = %v", err)
+		}
+		if err := r.Register("bad", &providers.ProviderInfo{
+			Name:          "bad",
+			Factory:       func(config any) (providers.FeedProvider, error) { return &failingProvider{}, nil },
+			ConfigFactory: func() any { return &stubConfig{} },
+		}); err != nil {
+			t.Fatalf("Register(bad) error = %v", err)
+		}
+
+		dir := t.TempDir()
+		configPath := filepath.Join(dir, "config.yaml")
+		if err := os.WriteFile(configPath, []byte("good:\n  message: from-config\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		batchPath := filepath.Join(dir, "batch.yaml")
+		batch := "jobs:\n" +
+			"  - provider: good\n    outfile: good.xml\n    config:\n      message: from-job\n" +
+			"  - provider: bad\n    outfile: bad.xml\n"
+		if err := os.WriteFile(batchPath, []byte(batch), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		var err error
+		out := captureStdout(t, func() { err = runBatch(batchPath, configPath, 2) })
+		if err == nil || !strings.Contains(err.Error(), "1 of 2") {
+			t.Fatalf("runBatch() error = %v, want 1 of 2 jobs failed", err)
+		}
+		if !strings.Contains(out, "ok   good -> good.xml") || !strings.Contains(out, "FAIL bad -> bad.xml: upstream down") {
+			t.Errorf("runBatch() output = %q", out)
+		}
+
+		content, readErr := os.ReadFile(filepath.Join(CLI.OutputDir, "good.xml"))
+		if readErr != nil {
+			t.Fatalf("ReadFile(good.xml) error = %v", readErr)
+		}
+		if string(content) != "generated:from-job" {
+			t.Errorf("good.xml = %q, want the job config to override the config file", content)
+		}
+	})
+}
+
+func TestLoadBatchFile_RejectsUnknownFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch.yaml")
+	if err := os.WriteFile(path, []byte("jobs:\n  - provider: good\n    format: pdf\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadBatchFile(path); err == nil {
+		t.Fatal("loadBatchFile() error = nil, want unknown format error")
+	}
+}
@@ -286,3 +286,43 @@ func TestGenerateFeedIndex_WritesSortedNonFailedFeeds(t *testing.T) {
 		t.Fatalf("feeds.opml should not include failed feed:\n%s", opml)
 	}
 }
+
+func TestApplyRedditPreset(t *testing.T) {
+	oldReddit := CLI.Reddit
+	t.Cleanup(func() { CLI.Reddit = oldReddit })
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	config := `
+reddit:
+  feed-id: "default-feed"
+  username: "default-user"
+  presets:
+    tech:
+      feed-id: "tech-feed"
+      username: "tech-user"
+`
+	if err := os.WriteFile(configPath, []byte(config), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	CLI.Reddit.FeedID = "default-feed"
+	CLI.Reddit.Username = "default-user"
+	if err := applyRedditPreset(configPath, "tech", []string{"reddit", "--preset", "tech"}); err != nil {
+		t.Fatalf("applyRedditPreset() error = %v", err)
+	}
+	if CLI.Reddit.FeedID != "tech-feed" || CLI.Reddit.Username != "tech-user" {
+		t.Fatalf("preset not applied: feed=%q user=%q", CLI.Reddit.FeedID, CLI.Reddit.Username)
+	}
+
+	CLI.Reddit.Username = "explicit-user"
+	if err := applyRedditPreset(configPath, "tech", []string{"reddit", "--preset=tech", "--username=explicit-user"}); err != nil {
+		t.Fatalf("applyRedditPreset(explicit) error = %v", err)
+	}
+	if CLI.Reddit.Username != "explicit-user" {
+		t.Fatalf("explicit --username overridden by preset: %q", CLI.Reddit.Username)
+	}
+
+	if err := applyRedditPreset(configPath, "missing", nil); err == nil {
+		t.Fatal("applyRedditPreset(missing) error = nil, want error")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -21,9 +22,12 @@ import (
 
 	apipkg "github.com/lepinkainen/feed-forge/pkg/api"
 	"github.com/lepinkainen/feed-forge/pkg/feed"
+	"github.com/lepinkainen/feed-forge/pkg/feedmeta"
 	"github.com/lepinkainen/feed-forge/pkg/filesystem"
+	"github.com/lepinkainen/feed-forge/pkg/httpcache"
 	"github.com/lepinkainen/feed-forge/pkg/llm"
 	"github.com/lepinkainen/feed-forge/pkg/notifications"
+	"github.com/lepinkainen/feed-forge/pkg/opengraph"
 	"github.com/lepinkainen/feed-forge/pkg/preview"
 	"github.com/lepinkainen/feed-forge/pkg/providers"
 
@@ -34,6 +38,7 @@ import (
 	"github.com/lepinkainen/feed-forge/internal/fingerpori"
 	"github.com/lepinkainen/feed-forge/internal/hackernews"
 	"github.com/lepinkainen/feed-forge/internal/oglaf"
+	"github.com/lepinkainen/feed-forge/internal/readlater"
 	redditjson "github.com/lepinkainen/feed-forge/internal/reddit-json"
 	"github.com/lepinkainen/feed-forge/internal/tildes"
 	"github.com/lepinkainen/feed-forge/internal/youtube"
@@ -41,35 +46,106 @@ import (
 
 // CLI structure
 var CLI struct {
-	Config            string `help:"Configuration file path" default:"config.yaml"`
-	Debug             bool   `help:"Enable debug logging" default:"false"`
-	OutputDir         string `help:"Base output directory for all generated feeds" default:"" yaml:"output-dir"`
-	FeedBaseURL       string `help:"Public base URL for generated feeds and OPML" default:"https://endymion.xyz/rss/" yaml:"feed-base-url"`
-	CacheDir          string `help:"Directory for cache databases" default:"" yaml:"cache-dir"`
-	DiscordWebhookURL string `help:"Discord webhook URL for failure notifications" default:"" yaml:"discord-webhook-url"`
+	Config             string   `help:"Configuration file path" default:"config.yaml"`
+	Debug              bool     `help:"Enable debug logging" default:"false"`
+	OutputDir          string   `help:"Base output directory for all generated feeds" default:"" yaml:"output-dir"`
+	OutputFormat       string   `help:"Format written for each feed (atom, rss, json, html)" enum:"atom,rss,json,html" default:"atom" yaml:"output-format"`
+	FeedBaseURL        string   `help:"Public base URL for generated feeds and OPML" default:"https://endymion.xyz/rss/" yaml:"feed-base-url"`
+	CacheDir           string   `help:"Directory for cache databases" default:"" yaml:"cache-dir"`
+	Hub                string   `help:"WebSub hub URL to advertise in feeds and notify after writing them (needs --feed-base-url)" default:"" yaml:"hub"`
+	SelfURL            string   `name:"self-url" help:"Public URL of the generated feed, emitted as rel=\"self\"" default:""`
+	HTMLURL            string   `name:"html-url" help:"Human-readable source page, emitted as rel=\"alternate\" type=\"text/html\"" default:""`
+	DiscordWebhookURL  string   `help:"Discord webhook URL for failure notifications" default:"" yaml:"discord-webhook-url"`
+	RefreshOG          bool     `name:"refresh-og" help:"Ignore cached OpenGraph data and refetch every link" default:"false"`
+	Regenerate         bool     `help:"Rebuild every feed from source items, ignoring --interval and upstream Not Modified responses" default:"false"`
+	SkipEmpty          bool     `help:"Keep an existing feed instead of overwriting it with an empty one" default:"false" yaml:"skip-empty"`
+	FetchLimit         int      `help:"Items to fetch and process per provider (0 = the provider's --limit)" default:"0" yaml:"fetch-limit"`
+	FeedLimit          int      `help:"Items to emit per feed after filtering and sorting (0 = all fetched)" default:"0" yaml:"feed-limit"`
+	DedupeBy           string   `help:"Drop items repeating an earlier item's key (none, url, title, id)" enum:"none,url,title,id" default:"none" yaml:"dedupe-by"`
+	GlobalDedupe       bool     `help:"Drop items whose link another feed already emitted in an earlier run" default:"false" yaml:"global-dedupe"`
+	GlobalDedupeTTL    string   `name:"global-dedupe-ttl" help:"How long a link stays claimed by the feed that last emitted it" default:"168h" yaml:"global-dedupe-ttl"`
+	OnDuplicateID      string   `name:"on-duplicate-id" help:"Action for entries sharing an ID (warn, drop, suffix)" enum:"warn,drop,suffix" default:"warn" yaml:"on-duplicate-id"`
+	Rank               string   `help:"Sort items before --feed-limit (none, hotness)" enum:"none,hotness" default:"none" yaml:"rank"`
+	HotnessGravity     float64  `help:"Age penalty exponent for --rank hotness" default:"1.8" yaml:"hotness-gravity"`
+	MaxFeedSize        int      `help:"Maximum feed size in bytes (0 = unlimited)" default:"0" yaml:"max-feed-size"`
+	MaxFeedSizeAction  string   `help:"Action when a feed exceeds --max-feed-size (error, trim)" enum:"error,trim" default:"error" yaml:"max-feed-size-action"`
+	PaywallDomains     []string `help:"Domains whose links are flagged as paywalled (replaces the built-in list)" yaml:"paywall-domains"`
+	RespectRobots      bool     `help:"Honor robots.txt Disallow rules and Crawl-delay for OpenGraph fetches" default:"false" yaml:"respect-robots"`
+	SiteNames          []string `name:"site-name" help:"Publication name for a host without og:site_name, as host=Name (added to the built-in list)" yaml:"site-names"`
+	Soft404Phrases     []string `name:"soft404-phrases" help:"Title/description phrases marking a page as not found (replaces the built-in list)" yaml:"soft404-phrases"`
+	OGDumpDir          string   `name:"og-dump-dir" help:"Write fetched OpenGraph HTML and extracted data to this directory for debugging" type:"path"`
+	OGMaxRedirects     int      `name:"og-max-redirects" help:"Maximum redirects followed per OpenGraph fetch" default:"10" yaml:"og-max-redirects"`
+	OGMinBodyBytes     int      `name:"og-min-body-bytes" help:"Treat pages smaller than this without og:* tags as failed OpenGraph fetches (0 = off)" default:"0" yaml:"og-min-body-bytes"`
+	OGMaxFetches       int      `name:"og-max-fetches" help:"Fetch OpenGraph data for at most this many linked pages per feed, in feed order (0 = all)" default:"0" yaml:"og-max-fetches"`
+	OGMemoryCache      int      `name:"og-memory-cache" help:"Resolved OpenGraph lookups kept in memory per fetcher, least recently used evicted first" default:"1000" yaml:"og-memory-cache"`
+	InsecureTLS        bool     `name:"insecure-tls" help:"Skip TLS certificate verification for OpenGraph fetches (limited to --insecure-tls-domains when set)" default:"false" yaml:"insecure-tls"`
+	InsecureTLSDomains []string `name:"insecure-tls-domains" help:"Only these domains (and subdomains) skip TLS verification for OpenGraph fetches" yaml:"insecure-tls-domains"`
+	OGTimeout          string   `name:"og-timeout" help:"Time limit for each OpenGraph page fetch, including redirects and reading the body" default:"10s" yaml:"og-timeout"`
+	OGLang             string   `name:"og-lang" help:"Accept-Language for OpenGraph fetches (default: per-feed locale, then English)" yaml:"og-lang"`
+	RequestBudget      int      `help:"Maximum outbound requests per run across providers and OpenGraph fetches (0 = unlimited)" default:"0" yaml:"request-budget"`
+	MaxInFlight        int      `help:"Maximum outbound requests in flight at once across stats refreshes and OpenGraph fetches (0 = no shared cap)" default:"0" yaml:"max-in-flight"`
+	DynamicSubtitle    bool     `help:"Set the feed subtitle to \"Latest: \" and the newest item's title instead of the feed description" default:"false" yaml:"dynamic-subtitle"`
+	AuthorEmail        string   `help:"Email address emitted in the feed-level <author>" yaml:"author-email"`
+	StripEmoji         bool     `help:"Remove emoji from item titles" default:"false" yaml:"strip-emoji"`
+	Trends             bool     `help:"Record scores in the feed and show score/comment changes since the previous run" default:"false" yaml:"trends"`
+	FreshnessWindow    string   `help:"Tag items first seen within this duration as \"fresh\" and older items with changed stats as \"updated\" (0 = off)" default:"0" yaml:"freshness-window"`
+	MaxTitleLength     int      `help:"Truncate item titles longer than this many characters at a word boundary with … (0 = no limit)" default:"0" yaml:"max-title-length"`
+	AutoDir            bool     `help:"Mark titles and content written mostly in Arabic, Hebrew or another right-to-left script as dir=\"rtl\" text" default:"false" yaml:"auto-dir"`
+	StripQuery         bool     `help:"Remove the query string (?utm_source=... and the rest) from item links" default:"false" yaml:"strip-query"`
+	TagURIIDs          bool     `name:"tag-uri-ids" help:"Use tag: URIs (tag:news.ycombinator.com,2024:item/12345) as entry IDs for items with a stable source ID; changing IDs makes readers show entries again" default:"false" yaml:"tag-uri-ids"`
+	CollapseWhitespace bool     `help:"Squeeze runs of spaces, tabs and newlines in item titles and summaries to single spaces" default:"false" yaml:"collapse-whitespace"`
+	ContentMaxChars    int      `help:"Truncate item content longer than this many characters with a read-more link (0 = no limit)" default:"0" yaml:"content-max-chars"`
+	ContentMaxBytes    int      `help:"Hard cap on each entry's content in bytes, cut at a tag boundary with a truncation notice (-1 = no cap)" default:"65536" yaml:"content-max-bytes"`
+	DateFormat         string   `help:"Timestamp format for <updated>/<published> (rfc3339, rfc3339-utc, rfc3339-nofrac)" enum:"rfc3339,rfc3339-utc,rfc3339-nofrac" default:"rfc3339-nofrac" yaml:"date-format"`
+	Timezone           string   `help:"IANA time zone for <updated>/<published>, e.g. Europe/Helsinki (default: each source's own offset)" yaml:"timezone"`
+	DefaultImage       string   `help:"Thumbnail URL for items with neither their own image nor an OpenGraph image" yaml:"default-image"`
+	Podcast            bool     `help:"Make og:audio the only enclosure of items that link audio, for podcast clients" default:"false" yaml:"podcast"`
+	NoEnclosures       bool     `help:"Omit rel=\"enclosure\" image links from entries (inline images and thumbnails are kept)" default:"false" yaml:"no-enclosures"`
+	NoCategories       bool     `help:"Omit all <category> elements from entries (items are still filtered and sorted by them)" default:"false" yaml:"no-categories"`
+	AbbreviateCounts   bool     `help:"Show scores and comment counts in entry content and the preview list abbreviated (12.3k, 1.5M)" default:"false" yaml:"abbreviate-counts"`
+	ScoreLabel         string   `help:"Label for the score in entry content and the preview list" yaml:"score-label"`
+	CommentsLabel      string   `help:"Label for the comment count in entry content and the preview list" yaml:"comments-label"`
+	ShowProvenance     bool     `help:"Note in link previews where and how long ago the preview was fetched" default:"false" yaml:"show-provenance"`
+	RawCategories      bool     `help:"Emit the source's category strings verbatim, without provider prefixes or the added paywall category" default:"false" yaml:"raw-categories"`
+	CategoryAllow      []string `help:"Keep only these entry categories (case-insensitive, repeat or comma-separate)" yaml:"category-allow"`
+	CategoryBlock      []string `help:"Drop these entry categories, e.g. Rising (case-insensitive, wins over --category-allow)" yaml:"category-block"`
+	StableUpdated      bool     `help:"Hold each entry's <updated> at the time it was first seen (Hacker News)" default:"false" yaml:"stable-updated"`
+	XMLStandalone      bool     `name:"xml-standalone" help:"Declare saved feeds standalone=\"yes\"" default:"false" yaml:"xml-standalone"`
+	XMLBOM             bool     `name:"xml-bom" help:"Prefix saved feeds with a UTF-8 byte order mark" default:"false" yaml:"xml-bom"`
+	Archive            bool     `help:"Also write monthly archive feeds with RFC 5005 paging links" default:"false" yaml:"archive"`
+	Paginate           bool     `help:"Also write the feed as numbered pages (feed-1.xml, ...) with RFC 5005 first/last/previous/next links" default:"false" yaml:"paginate"`
+	PageSize           int      `help:"Items per page with --paginate" default:"50" yaml:"page-size"`
 
 	Reddit struct {
 		Outfile     string `help:"Output file path" short:"o" default:"reddit.xml"`
 		MinScore    int    `help:"Minimum post score" default:"50"`
 		MinComments int    `help:"Minimum comment count" default:"10"`
+		Threshold   string `name:"threshold-mode" help:"Combine --min-score and --min-comments with and (both) or or (either)" enum:"and,or" default:"and" yaml:"threshold-mode"`
 		FeedID      string `help:"Reddit feed ID"`
 		Username    string `help:"Reddit username"`
 		ProxyURL    string `help:"Proxy URL for Reddit API requests" yaml:"proxy-url"`
 		ProxySecret string `help:"Shared secret for proxy authentication" yaml:"proxy-secret"`
 		OGProxyURL  string `help:"Proxy URL for Reddit OpenGraph fetching" yaml:"og-proxy-url"`
 		Interval    string `help:"Minimum time between regenerations" yaml:"interval"`
+		Preset      string `help:"Named preset from reddit.presets supplying feed ID and username"`
 	} `cmd:"reddit" help:"Generate RSS feed from Reddit."`
 
 	HackerNews struct {
-		Outfile   string `help:"Output file path" short:"o" default:"hackernews.xml"`
-		MinPoints int    `help:"Minimum points threshold" default:"50"`
-		Limit     int    `help:"Maximum number of items" default:"30"`
-		Interval  string `help:"Minimum time between regenerations" yaml:"interval"`
+		Outfile     string `help:"Output file path" short:"o" default:"hackernews.xml"`
+		MinPoints   int    `help:"Minimum points threshold" default:"50"`
+		Limit       int    `help:"Maximum number of items" default:"${hackernews_limit}"`
+		StoryType   string `help:"Story type to fetch (front_page, ask_hn, show_hn, story)" enum:"front_page,ask_hn,show_hn,story" default:"front_page" yaml:"story-type"`
+		API         string `name:"hn-api" help:"Hacker News API to read stories from (algolia, firebase)" enum:"algolia,firebase" default:"algolia" yaml:"hn-api"`
+		Comments    bool   `help:"Emit the best comments on top stories instead of the stories" default:"false" yaml:"comments"`
+		ArchiveMode bool   `help:"Keep items deleted on HN in the database, flagged dead, instead of removing them" default:"false" yaml:"archive-mode"`
+		SchemeURL   string `name:"category-scheme-url" help:"URL template for the points/comments/domain category schemes ({term} = value), e.g. https://hn.algolia.com/?query={term}" yaml:"category-scheme-url"`
+		Domains     string `name:"domains-config" help:"JSON file with category_domains and point_buckets, replacing the embedded domains.json" type:"path" yaml:"domains-config"`
+		Interval    string `help:"Minimum time between regenerations" yaml:"interval"`
 	} `cmd:"hackernews" help:"Generate RSS feed from Hacker News."`
 
 	Fingerpori struct {
 		Outfile  string `help:"Output file path" short:"o" default:"fingerpori.xml"`
-		Limit    int    `help:"Maximum number of items" default:"100"`
+		Limit    int    `help:"Maximum number of items" default:"${fingerpori_limit}"`
 		Interval string `help:"Minimum time between regenerations" yaml:"interval"`
 	} `cmd:"fingerpori" help:"Generate RSS feed from Fingerpori comics."`
 
@@ -79,9 +155,12 @@ var CLI struct {
 	} `cmd:"feissarimokat" help:"Generate RSS feed from Feissarimokat comics."`
 
 	Preview struct {
-		Provider string `arg:"" name:"provider" help:"Provider name (e.g. reddit, hacker-news, fingerpori, oglaf, feissarimokat, tildes, youtube)."`
-		Limit    int    `help:"Maximum number of items to fetch (0 = provider default)." default:"0"`
-		Index    int    `help:"Output XML for specific item index (0-based) to stdout" default:"-1"`
+		Provider    string   `arg:"" name:"provider" help:"Provider name (e.g. reddit, hacker-news, fingerpori, oglaf, feissarimokat, tildes, youtube, readlater)."`
+		Limit       int      `help:"Maximum number of items to fetch (0 = provider default)." default:"0"`
+		Index       int      `help:"Output XML for specific item index (0-based) to stdout" default:"-1"`
+		Range       string   `help:"Output a feed with items in a 0-based range (a:b, a:, :b) to stdout"`
+		ListColumns []string `help:"Fields shown in the list view, in order (score, comments, date, title, author, domain, categories)" default:"score,comments,date,title"`
+		NoTUI       bool     `name:"no-tui" help:"Print the item list instead of starting the interactive view (automatic when stdout is not a terminal)" default:"false"`
 	} `cmd:"preview" help:"Preview feed items interactively for any registered provider."`
 	Oglaf struct {
 		Outfile  string `help:"Output file path" short:"o" default:"oglaf.xml"`
@@ -101,17 +180,42 @@ var CLI struct {
 		FeedURL       string   `help:"YouTube Atom feed URL" yaml:"feed-url"`
 		FeedURLs      []string `name:"feed-urls" help:"YouTube Atom feed URLs, repeat for multiple channels" yaml:"feed-urls"`
 		ChannelIDs    []string `name:"channel-ids" help:"YouTube channel IDs, repeat for multiple channels" yaml:"channel-ids"`
-		Limit         int      `help:"Maximum number of items" default:"30" yaml:"limit"`
+		Limit         int      `help:"Maximum number of items" default:"${youtube_limit}" yaml:"limit"`
 		IncludeShorts bool     `help:"Include YouTube Shorts" default:"false" yaml:"include-shorts"`
 		Interval      string   `help:"Minimum time between regenerations" yaml:"interval"`
 	} `cmd:"youtube" name:"youtube" help:"Generate RSS feed from YouTube channel Atom feeds."`
 
+	ReadLater struct {
+		Outfile  string `help:"Output file path" short:"o" default:"readlater.xml"`
+		File     string `help:"File of URLs to read later, one per line (appended lines are newest)" type:"path" yaml:"file"`
+		Limit    int    `help:"Maximum number of items" default:"${readlater_limit}" yaml:"limit"`
+		Interval string `help:"Minimum time between regenerations" yaml:"interval"`
+	} `cmd:"readlater" name:"readlater" help:"Generate RSS feed from a local file of saved URLs."`
+
 	YouTubeRSS struct {
 		URL string `arg:"" name:"url" help:"YouTube channel URL, e.g. https://www.youtube.com/@Taskmaster"`
 	} `cmd:"youtube-rss" name:"youtube-rss" help:"Print the RSS feed URL advertised by a YouTube channel page."`
 
 	Generate struct{} `cmd:"generate" help:"Generate feeds for all configured providers."`
 
+	Batch struct {
+		File     string `arg:"" name:"file" help:"YAML file listing feed jobs (provider, config, outfile, format)" type:"path"`
+		Parallel int    `name:"parallel-providers" help:"Maximum jobs run at once (0 = CPU count)" default:"0"`
+	} `cmd:"batch" help:"Generate the feeds listed in a batch file, reporting each job and failing if any fails."`
+
+	ItemsFromFile struct {
+		File     string `arg:"" name:"file" help:"JSON file containing an array of feed items"`
+		Provider string `help:"Provider whose template and feed metadata to use" default:"hackernews"`
+		Outfile  string `help:"Output file path" short:"o" default:"items.xml"`
+	} `cmd:"items-from-file" name:"items-from-file" help:"Generate a feed from a JSON file of items, for template and OpenGraph debugging."`
+
+	Template struct {
+		Check struct {
+			Name string `arg:"" name:"name" help:"Template name without .tmpl, e.g. hackernews-atom"`
+			Dir  string `name:"template-dir" help:"Directory of override templates to check (default: ./templates, then embedded)" type:"path"`
+		} `cmd:"check" help:"Render a template against sample items and OpenGraph data without network access, printing the output or the error."`
+	} `cmd:"template" help:"Work with feed templates."`
+
 	BulletinFetch struct{} `cmd:"bulletin-fetch" name:"bulletin-fetch" help:"Poll bulletin source feeds, extract full text, and store new items."`
 
 	BulletinGenerate struct {
@@ -123,6 +227,8 @@ var CLI struct {
 	} `cmd:"bulletin-publish" name:"bulletin-publish" help:"Render stored bulletins into HTML pages and the Atom feed (no model)."`
 
 	BulletinSummarize struct{} `cmd:"bulletin-summarize" name:"bulletin-summarize" help:"Debug: print the digest for current unpublished items to stdout without writing or marking anything."`
+
+	Version struct{} `cmd:"version" help:"Print the feed-forge version."`
 }
 
 func resolveConfigPath(args []string) string {
@@ -187,6 +293,7 @@ func buildProviderConfig(name string) any {
 			},
 			MinScore:    CLI.Reddit.MinScore,
 			MinComments: CLI.Reddit.MinComments,
+			Threshold:   CLI.Reddit.Threshold,
 			FeedID:      CLI.Reddit.FeedID,
 			Username:    CLI.Reddit.Username,
 			ProxyURL:    CLI.Reddit.ProxyURL,
@@ -199,8 +306,14 @@ func buildProviderConfig(name string) any {
 				Outfile:  CLI.HackerNews.Outfile,
 				Interval: CLI.HackerNews.Interval,
 			},
-			MinPoints: CLI.HackerNews.MinPoints,
-			Limit:     CLI.HackerNews.Limit,
+			MinPoints:         CLI.HackerNews.MinPoints,
+			Limit:             CLI.HackerNews.Limit,
+			StoryType:         CLI.HackerNews.StoryType,
+			API:               CLI.HackerNews.API,
+			Comments:          CLI.HackerNews.Comments,
+			ArchiveMode:       CLI.HackerNews.ArchiveMode,
+			CategorySchemeURL: CLI.HackerNews.SchemeURL,
+			DomainsConfig:     CLI.HackerNews.Domains,
 		}
 	case "fingerpori":
 		return &fingerpori.Config{
@@ -246,12 +359,21 @@ func buildProviderConfig(name string) any {
 			Limit:         CLI.YouTube.Limit,
 			IncludeShorts: CLI.YouTube.IncludeShorts,
 		}
+	case "readlater":
+		return &readlater.Config{
+			GenerateConfig: providers.GenerateConfig{
+				Outfile:  CLI.ReadLater.Outfile,
+				Interval: CLI.ReadLater.Interval,
+			},
+			File:  CLI.ReadLater.File,
+			Limit: CLI.ReadLater.Limit,
+		}
 	default:
 		return nil
 	}
 }
 
-func previewFeed(providerName string, limit, index int, configPath string) error {
+func previewFeed(providerName string, limit, index int, itemRange, configPath string) error {
 	info, err := providers.DefaultRegistry.Get(providerName)
 	if err != nil {
 		return err
@@ -289,6 +411,15 @@ func previewFeed(providerName string, limit, index int, configPath string) error
 		return nil
 	}
 
+	if itemRange != "" {
+		start, end, err := preview.ParseRange(itemRange, len(items))
+		if err != nil {
+			return err
+		}
+		fmt.Println(preview.FormatXMLFeed(items[start:end], info.Preview.TemplateName, feedConfig))
+		return nil
+	}
+
 	providerDisplay := info.Preview.ProviderName
 	if providerDisplay == "" {
 		providerDisplay = info.Name
@@ -297,6 +428,49 @@ func previewFeed(providerName string, limit, index int, configPath string) error
 	return preview.Run(items, providerDisplay, info.Preview.TemplateName, feedConfig)
 }
 
+// generateFromItemsFile replays a JSON item export through the normal
+// template and OpenGraph pipeline using providerName's feed metadata.
+func generateFromItemsFile(path, providerName, outfile string) error {
+	info, err := providers.DefaultRegistry.Get(providerName)
+	if err != nil {
+		return err
+	}
+	if info.Preview == nil {
+		return fmt.Errorf("provider %q does not expose preview metadata", providerName)
+	}
+
+	// #nosec G304 -- items file is an explicit CLI input, intentionally read from disk.
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	items, err := feed.ReadItemsJSON(file)
+	if err != nil {
+		return err
+	}
+
+	ogDBPath, err := filesystem.GetDefaultPath("opengraph.db")
+	if err != nil {
+		return err
+	}
+	ogDB, err := opengraph.NewDatabase(ogDBPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = ogDB.Close() }()
+
+	if err := filesystem.EnsureDirectoryExists(outfile); err != nil {
+		return err
+	}
+	if err := feed.SaveAtomFeedToFileWithEmbeddedTemplate(items, info.Preview.TemplateName, outfile, info.Preview.Config, ogDB); err != nil {
+		return err
+	}
+	feed.LogFeedGeneration(len(items), outfile)
+	return nil
+}
+
 // loadProviderConfigFromYAML unmarshals a provider's YAML section directly into
 // its Config struct. Used by the generate command where Kong doesn't populate
 // command-level sub-structs.
@@ -340,6 +514,36 @@ func configuredProviders(configPath string) ([]string, error) {
 	return names, nil
 }
 
+// parseSiteNames parses host=Name pairs into a map keyed by lowercase host.
+func parseSiteNames(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	names := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		host, name, ok := strings.Cut(pair, "=")
+		host, name = strings.ToLower(strings.TrimSpace(host)), strings.TrimSpace(name)
+		if !ok || host == "" || name == "" {
+			return nil, fmt.Errorf("%q is not host=Name", pair)
+		}
+		names[host] = name
+	}
+	return names, nil
+}
+
+// parseTimezone loads the --timezone zone, nil when it is empty. The
+// rfc3339-utc date format always writes UTC, so combining it with a zone is
+// rejected rather than silently ignoring the zone.
+func parseTimezone(name, dateFormat string) (*time.Location, error) {
+	if name == "" {
+		return nil, nil
+	}
+	if dateFormat == feed.DateFormatRFC3339UTC {
+		return nil, fmt.Errorf("--timezone %s conflicts with --date-format %s, which always writes UTC", name, dateFormat)
+	}
+	return time.LoadLocation(name)
+}
+
 const defaultInterval = 15 * time.Minute
 
 func parseInterval(s string) time.Duration {
@@ -512,11 +716,12 @@ func generateProvider(configPath, name string) feedResult {
 	if outfile == "" {
 		outfile = name + ".xml"
 	}
+	outfile = feed.OutfileForFormat(outfile, CLI.OutputFormat)
 	result.Filename = outfile
 	outfile = resolveOutfile(outfile)
 
 	interval := parseInterval(gc.Interval)
-	if skip, age := shouldSkipProvider(outfile, interval); skip {
+	if skip, age := shouldSkipProvider(outfile, interval); skip && !CLI.Regenerate {
 		slog.Info("Skipping provider", "provider", name, "age", age.Truncate(time.Second), "interval", interval)
 		result.Status = "skipped"
 		return result
@@ -529,17 +734,11 @@ func generateProvider(configPath, name string) feedResult {
 		return result
 	}
 
-	if closer, ok := provider.(interface{ Close() error }); ok {
-		defer func() {
-			if err := closer.Close(); err != nil {
-				slog.Error("Failed to close provider", "provider", name, "error", err)
-			}
-		}()
-	}
+	defer closeProvider(name, provider)
 
 	slog.Info("Generating feed", "provider", name, "outfile", outfile)
 	start := time.Now()
-	if err := provider.GenerateFeed(outfile); err != nil {
+	if err := writeFeedAs(provider, name, outfile, CLI.OutputFormat); err != nil {
 		result.Err = err
 		result.Duration = time.Since(start)
 		if !apipkg.IsTransientUpstreamError(err) {
@@ -553,6 +752,15 @@ func generateProvider(configPath, name string) feedResult {
 	return result
 }
 
+// closeProvider closes providers that hold resources such as databases.
+func closeProvider(name string, provider providers.FeedProvider) {
+	if closer, ok := provider.(interface{ Close() error }); ok {
+		if err := closer.Close(); err != nil {
+			slog.Error("Failed to close provider", "provider", name, "error", err)
+		}
+	}
+}
+
 func generateFeedIndex(results []feedResult) error {
 	if CLI.OutputDir == "" {
 		slog.Info("Skipping feed index generation: output-dir not configured")
@@ -679,7 +887,7 @@ func generateOPML(feeds []feedResult) error {
 	payload = append(payload, '\n')
 
 	opmlPath := filepath.Join(CLI.OutputDir, opmlFilename)
-	if err := os.WriteFile(opmlPath, payload, 0o600); err != nil {
+	if err := filesystem.WriteFileAtomic(opmlPath, payload, 0o600); err != nil {
 		return fmt.Errorf("write OPML file: %w", err)
 	}
 
@@ -687,6 +895,18 @@ func generateOPML(feeds []feedResult) error {
 	return nil
 }
 
+// providerLimitVars exposes each registered provider's DefaultLimit to the
+// CLI's default tags as ${<provider>_limit}.
+func providerLimitVars() kong.Vars {
+	vars := kong.Vars{}
+	for _, name := range providers.DefaultRegistry.List() {
+		if info, err := providers.DefaultRegistry.Get(name); err == nil && info.DefaultLimit > 0 {
+			vars[name+"_limit"] = strconv.Itoa(info.DefaultLimit)
+		}
+	}
+	return vars
+}
+
 func main() {
 	configPath := resolveConfigPath(os.Args[1:])
 
@@ -696,6 +916,7 @@ func main() {
 		kong.Description("A unified RSS feed generator with multiple provider support."),
 		kong.UsageOnError(),
 		kong.Configuration(kongyaml.Loader, configPath),
+		providerLimitVars(),
 	)
 
 	// Configure logging level based on debug flag
@@ -711,9 +932,131 @@ func main() {
 		filesystem.SetCacheDir(CLI.CacheDir)
 	}
 
+	httpcache.SetIgnoreValidators(CLI.Regenerate)
+	apipkg.SetRequestBudget(CLI.RequestBudget)
+	apipkg.SetMaxInFlight(CLI.MaxInFlight)
+
+	if CLI.AuthorEmail != "" {
+		if err := feed.ValidateAuthorEmail(CLI.AuthorEmail); err != nil {
+			slog.Error("Invalid --author-email", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	globalDedupeTTL, err := time.ParseDuration(CLI.GlobalDedupeTTL)
+	if err != nil {
+		slog.Error("Invalid --global-dedupe-ttl", "error", err)
+		os.Exit(1)
+	}
+
+	siteNames, err := parseSiteNames(CLI.SiteNames)
+	if err != nil {
+		slog.Error("Invalid --site-name", "error", err)
+		os.Exit(1)
+	}
+
+	ogTimeout, err := time.ParseDuration(CLI.OGTimeout)
+	if err != nil || ogTimeout <= 0 {
+		slog.Error("Invalid --og-timeout, want a positive duration", "value", CLI.OGTimeout, "error", err)
+		os.Exit(1)
+	}
+
+	freshnessWindow, err := time.ParseDuration(CLI.FreshnessWindow)
+	if err != nil {
+		slog.Error("Invalid --freshness-window", "error", err)
+		os.Exit(1)
+	}
+
+	timezone, err := parseTimezone(CLI.Timezone, CLI.DateFormat)
+	if err != nil {
+		slog.Error("Invalid --timezone", "error", err)
+		os.Exit(1)
+	}
+
+	feed.SetOptions(feed.Options{
+		RefreshOG:          CLI.RefreshOG,
+		SkipEmpty:          CLI.SkipEmpty,
+		FetchLimit:         CLI.FetchLimit,
+		FeedLimit:          CLI.FeedLimit,
+		DedupeBy:           CLI.DedupeBy,
+		GlobalDedupe:       CLI.GlobalDedupe,
+		GlobalDedupeTTL:    globalDedupeTTL,
+		OnDuplicateID:      CLI.OnDuplicateID,
+		Rank:               CLI.Rank,
+		HotnessGravity:     CLI.HotnessGravity,
+		MaxFeedSize:        CLI.MaxFeedSize,
+		MaxFeedSizeAction:  CLI.MaxFeedSizeAction,
+		PaywallDomains:     CLI.PaywallDomains,
+		SiteNames:          siteNames,
+		RespectRobots:      CLI.RespectRobots,
+		OGMaxRedirects:     CLI.OGMaxRedirects,
+		OGMinBodyBytes:     CLI.OGMinBodyBytes,
+		OGMaxFetches:       CLI.OGMaxFetches,
+		OGMemoryCache:      CLI.OGMemoryCache,
+		InsecureTLS:        CLI.InsecureTLS,
+		InsecureTLSDomains: CLI.InsecureTLSDomains,
+		OGDumpDir:          CLI.OGDumpDir,
+		Soft404Phrases:     CLI.Soft404Phrases,
+		OGLanguage:         CLI.OGLang,
+		Archive:            CLI.Archive,
+		Paginate:           CLI.Paginate,
+		PageSize:           CLI.PageSize,
+		Hub:                CLI.Hub,
+		FeedBaseURL:        CLI.FeedBaseURL,
+		SelfURL:            CLI.SelfURL,
+		HTMLURL:            CLI.HTMLURL,
+		XMLStandalone:      CLI.XMLStandalone,
+		XMLBOM:             CLI.XMLBOM,
+		StripEmoji:         CLI.StripEmoji,
+		MaxTitleLength:     CLI.MaxTitleLength,
+		FreshnessWindow:    freshnessWindow,
+		Trends:             CLI.Trends,
+		OGTimeout:          ogTimeout,
+		CollapseWhitespace: CLI.CollapseWhitespace,
+		AutoDir:            CLI.AutoDir,
+		StripQuery:         CLI.StripQuery,
+		TagURIIDs:          CLI.TagURIIDs,
+		ContentMaxChars:    CLI.ContentMaxChars,
+		ContentMaxBytes:    CLI.ContentMaxBytes,
+		NoEnclosures:       CLI.NoEnclosures,
+		NoCategories:       CLI.NoCategories,
+		Podcast:            CLI.Podcast,
+		DefaultImage:       CLI.DefaultImage,
+		StableUpdated:      CLI.StableUpdated,
+		RawCategories:      CLI.RawCategories,
+		CategoryAllow:      CLI.CategoryAllow,
+		CategoryBlock:      CLI.CategoryBlock,
+		ShowProvenance:     CLI.ShowProvenance,
+		AbbreviateCounts:   CLI.AbbreviateCounts,
+		StatLabels:         feed.StatLabels{Score: CLI.ScoreLabel, Comments: CLI.CommentsLabel},
+		DateFormat:         CLI.DateFormat,
+		Timezone:           timezone,
+		DynamicSubtitle:    CLI.DynamicSubtitle,
+		AuthorEmail:        CLI.AuthorEmail,
+	})
+
+	if err := loadProviderHeaders(configPath); err != nil {
+		slog.Error("Invalid headers config", "error", err)
+		os.Exit(1)
+	}
+
 	dispatchCommand(ctx.Command(), configPath)
 }
 
+// loadProviderHeaders reads the headers: section (provider name -> header
+// name -> value) and hands it to the API clients. A missing config file is fine.
+func loadProviderHeaders(configPath string) error {
+	var headers map[string]map[string]string
+	if err := loadProviderConfigFromYAML(configPath, "headers", &headers); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	apipkg.SetProviderHeaders(headers)
+	return nil
+}
+
 func dispatchCommand(command, configPath string) {
 	type providerSpec struct {
 		key, name, outfile string
@@ -726,6 +1069,7 @@ func dispatchCommand(command, configPath string) {
 		"oglaf":         {"oglaf", "Oglaf", CLI.Oglaf.Outfile, nil},
 		"tildes":        {"tildes", "Tildes", CLI.Tildes.Outfile, nil},
 		"youtube":       {"youtube", "YouTube", CLI.YouTube.Outfile, nil},
+		"readlater":     {"readlater", "Read Later", CLI.ReadLater.Outfile, nil},
 	}
 	if spec, ok := providerCmds[command]; ok {
 		runProvider(spec.key, spec.name, spec.outfile, spec.extra...)
@@ -738,14 +1082,15 @@ func dispatchCommand(command, configPath string) {
 
 	switch command {
 	case "reddit":
-		if CLI.Reddit.FeedID == "" || CLI.Reddit.Username == "" {
-			slog.Error("Reddit feed requires both feed_id and username to be set via CLI flags or config file")
-			os.Exit(1)
-		}
-		runProvider("reddit", "Reddit", CLI.Reddit.Outfile, "feed_id", CLI.Reddit.FeedID, "username", CLI.Reddit.Username)
+		runReddit(configPath)
 	case "preview <provider>":
 		slog.Debug("Previewing provider feed...", "provider", CLI.Preview.Provider)
-		if err := previewFeed(CLI.Preview.Provider, CLI.Preview.Limit, CLI.Preview.Index, configPath); err != nil {
+		if err := preview.SetListColumns(CLI.Preview.ListColumns); err != nil {
+			slog.Error("Invalid --list-columns", "error", err)
+			os.Exit(1)
+		}
+		preview.SetNoTUI(CLI.Preview.NoTUI)
+		if err := previewFeed(CLI.Preview.Provider, CLI.Preview.Limit, CLI.Preview.Index, CLI.Preview.Range, configPath); err != nil {
 			slog.Error("Preview failed", "provider", CLI.Preview.Provider, "error", err)
 			os.Exit(1)
 		}
@@ -756,6 +1101,26 @@ func dispatchCommand(command, configPath string) {
 			os.Exit(1)
 		}
 		fmt.Println(feedURL)
+	case "items-from-file <file>":
+		if err := generateFromItemsFile(CLI.ItemsFromFile.File, CLI.ItemsFromFile.Provider, resolveOutfile(CLI.ItemsFromFile.Outfile)); err != nil {
+			slog.Error("Failed to generate feed from items file", "file", CLI.ItemsFromFile.File, "error", err)
+			os.Exit(1)
+		}
+	case "template check <name>":
+		if CLI.Template.Check.Dir != "" {
+			feed.SetTemplateOverrideFS(os.DirFS(CLI.Template.Check.Dir))
+		}
+		if err := feed.CheckTemplate(CLI.Template.Check.Name, os.Stdout); err != nil {
+			slog.Error("Template check failed", "template", CLI.Template.Check.Name, "error", err)
+			os.Exit(1)
+		}
+	case "version":
+		fmt.Println(feedmeta.VersionString())
+	case "batch <file>":
+		if err := runBatch(CLI.Batch.File, configPath, CLI.Batch.Parallel); err != nil {
+			slog.Error("Batch failed", "file", CLI.Batch.File, "error", err)
+			os.Exit(1)
+		}
 	case "generate":
 		slog.Debug("Generating feeds for all configured providers...")
 		if err := generateAll(configPath); err != nil {
@@ -893,6 +1258,60 @@ func runBulletinSummarize(configPath string) error {
 	return nil
 }
 
+func runReddit(configPath string) {
+	if err := applyRedditPreset(configPath, CLI.Reddit.Preset, os.Args[1:]); err != nil {
+		slog.Error("Failed to apply Reddit preset", "preset", CLI.Reddit.Preset, "error", err)
+		os.Exit(1)
+	}
+	if CLI.Reddit.FeedID == "" {
+		CLI.Reddit.FeedID = os.Getenv(redditjson.FeedIDEnv)
+	}
+	if CLI.Reddit.Username == "" {
+		CLI.Reddit.Username = os.Getenv(redditjson.UsernameEnv)
+	}
+	if CLI.Reddit.FeedID == "" || CLI.Reddit.Username == "" {
+		slog.Error("Reddit feed requires both feed_id and username to be set via CLI flags, config file or REDDIT_FEED_ID/REDDIT_USERNAME")
+		os.Exit(1)
+	}
+	runProvider("reddit", "Reddit", CLI.Reddit.Outfile, "feed_id", CLI.Reddit.FeedID, "username", CLI.Reddit.Username)
+}
+
+// applyRedditPreset fills the Reddit feed ID and username from the named entry
+// in reddit.presets. Values passed explicitly as flags in args take precedence.
+func applyRedditPreset(configPath, preset string, args []string) error {
+	if preset == "" {
+		return nil
+	}
+
+	var cfg redditjson.Config
+	if err := loadProviderConfigFromYAML(configPath, "reddit", &cfg); err != nil {
+		return fmt.Errorf("load reddit presets: %w", err)
+	}
+	values, ok := cfg.Presets[preset]
+	if !ok {
+		return fmt.Errorf("reddit preset %q not found", preset)
+	}
+
+	if values.FeedID != "" && !flagPassed(args, "feed-id") {
+		CLI.Reddit.FeedID = values.FeedID
+	}
+	if values.Username != "" && !flagPassed(args, "username") {
+		CLI.Reddit.Username = values.Username
+	}
+	return nil
+}
+
+// flagPassed reports whether --name was given explicitly on the command line.
+func flagPassed(args []string, name string) bool {
+	flag := "--" + name
+	for _, arg := range args {
+		if arg == flag || strings.HasPrefix(arg, flag+"=") {
+			return true
+		}
+	}
+	return false
+}
+
 func runProvider(key, displayName, outfileFlag string, extraKV ...any) {
 	slog.Debug("Generating " + displayName + " feed...")
 
@@ -903,8 +1322,8 @@ func runProvider(key, displayName, outfileFlag string, extraKV ...any) {
 		os.Exit(1)
 	}
 
-	outfile := resolveOutfile(outfileFlag)
-	if err := provider.GenerateFeed(outfile); err != nil {
+	outfile := resolveOutfile(feed.OutfileForFormat(outfileFlag, CLI.OutputFormat))
+	if err := writeFeedAs(provider, key, outfile, CLI.OutputFormat); err != nil {
 		args := append([]any{"output_file", outfile}, extraKV...)
 		args = append(args, "error", err)
 		slog.Error("Failed to generate "+displayName+" feed", args...)
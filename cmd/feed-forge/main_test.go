@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/lepinkainen/feed-forge/internal/feissarimokat"
@@ -12,6 +13,7 @@ import (
 	redditjson "github.com/lepinkainen/feed-forge/internal/reddit-json"
 	"github.com/lepinkainen/feed-forge/internal/tildes"
 	"github.com/lepinkainen/feed-forge/internal/youtube"
+	"github.com/lepinkainen/feed-forge/pkg/feed"
 	"github.com/lepinkainen/feed-forge/pkg/providers"
 )
 
@@ -68,6 +70,11 @@ youtube:
   include-shorts: false
   outfile: youtube.xml
   interval: 45m
+readlater:
+  file: /tmp/readlater.txt
+  limit: 20
+  outfile: readlater.xml
+  interval: 1h
 `
 	path := filepath.Join(t.TempDir(), "config.yaml")
 	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
@@ -289,3 +296,36 @@ func TestAllRegisteredProviders_HaveYAMLTags(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSiteNames(t *testing.T) {
+	got, err := parseSiteNames([]string{"Example.COM = Example News", "blog.test=Test Blog"})
+	if err != nil {
+		t.Fatalf("parseSiteNames() error = %v", err)
+	}
+	want := map[string]string{"example.com": "Example News", "blog.test": "Test Blog"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSiteNames() = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"example.com", "=Name", "example.com="} {
+		if _, err := parseSiteNames([]string{bad}); err == nil {
+			t.Errorf("parseSiteNames(%q) error = nil, want error", bad)
+		}
+	}
+}
+
+func TestParseTimezone(t *testing.T) {
+	loc, err := parseTimezone("Europe/Helsinki", feed.DateFormatRFC3339NoFrac)
+	if err != nil || loc == nil || loc.String() != "Europe/Helsinki" {
+		t.Fatalf("parseTimezone(Europe/Helsinki) = %v, %v", loc, err)
+	}
+	if loc, err := parseTimezone("", feed.DateFormatRFC3339UTC); err != nil || loc != nil {
+		t.Errorf("parseTimezone(\"\") = %v, %v, want nil, nil", loc, err)
+	}
+	if _, err := parseTimezone("Europe/Helsinki", feed.DateFormatRFC3339UTC); err == nil {
+		t.Error("parseTimezone() with rfc3339-utc error = nil, want a conflict error")
+	}
+	if _, err := parseTimezone("Not/AZone", feed.DateFormatRFC3339); err == nil {
+		t.Error("parseTimezone(Not/AZone) error = nil, want error")
+	}
+}
@@ -116,7 +116,7 @@ func TestPreviewFeed_PrintsXMLForIndexedItem(t *testing.T) {
 		}
 
 		out := captureStdout(t, func() {
-			if err := previewFeed("stubpreview", 1, 0, ""); err != nil {
+			if err := previewFeed("stubpreview", 1, 0, "", ""); err != nil {
 				t.Fatalf("previewFeed() error = %v", err)
 			}
 		})
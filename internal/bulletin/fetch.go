@@ -46,7 +46,7 @@ func Fetch(cfg Config, dbPath string) error {
 	}
 	defer func() { _ = cacheStore.Close() }()
 
-	client := api.NewGenericClient()
+	client := api.NewGenericClient().ForProvider("bulletin")
 	client.SetUserAgent(userAgent)
 	parser := gofeed.NewParser()
 	ctx := context.Background()
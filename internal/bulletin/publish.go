@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/lepinkainen/feed-forge/pkg/feed"
+	"github.com/lepinkainen/feed-forge/pkg/feedmeta"
 	"github.com/lepinkainen/feed-forge/pkg/filesystem"
 	"github.com/lepinkainen/feed-forge/templates"
 )
@@ -25,13 +26,15 @@ const (
 
 // atomData is the view model for the bulletin Atom template.
 type atomData struct {
-	FeedTitle string
-	Subtitle  string
-	FeedID    string
-	SelfLink  string
-	Updated   string
-	Generator string
-	Entries   []atomEntry
+	FeedTitle        string
+	Subtitle         string
+	FeedID           string
+	SelfLink         string
+	Updated          string
+	Generator        string
+	GeneratorURI     string
+	GeneratorVersion string
+	Entries          []atomEntry
 }
 
 type atomEntry struct {
@@ -256,28 +259,27 @@ func writeAtom(outfile, feedBaseURL string, bulletins []Row) error {
 	}
 
 	data := atomData{
-		FeedTitle: "Feed Forge Bulletin",
-		Subtitle:  "Aggregated, de-duplicated news digests",
-		FeedID:    "urn:feed-forge:bulletin",
-		SelfLink:  feedBaseURL,
-		Updated:   entries[0].Updated,
-		Generator: "feed-forge",
-		Entries:   entries,
+		FeedTitle:        "Feed Forge Bulletin",
+		Subtitle:         "Aggregated, de-duplicated news digests",
+		FeedID:           "urn:feed-forge:bulletin",
+		SelfLink:         feedBaseURL,
+		Updated:          entries[0].Updated,
+		Generator:        feedmeta.GeneratorName,
+		GeneratorURI:     feedmeta.GeneratorURI,
+		GeneratorVersion: feedmeta.Version,
+		Entries:          entries,
 	}
 
 	if derr := filesystem.EnsureDirectoryExists(outfile); derr != nil {
 		return derr
 	}
-	// #nosec G304 -- output path is an explicit CLI/config input, intentionally written to disk.
-	f, err := os.Create(outfile)
-	if err != nil {
-		return fmt.Errorf("create outfile: %w", err)
-	}
-	defer func() { _ = f.Close() }()
-
-	if terr := tmpl.Execute(f, data); terr != nil {
+	var buf bytes.Buffer
+	if terr := tmpl.Execute(&buf, data); terr != nil {
 		return fmt.Errorf("execute bulletin template: %w", terr)
 	}
+	if werr := filesystem.WriteFileAtomic(outfile, buf.Bytes(), 0o600); werr != nil {
+		return fmt.Errorf("write outfile: %w", werr)
+	}
 	return nil
 }
 
@@ -325,7 +327,7 @@ func writeHTMLFile(path string, b Row) error {
 	if err := filesystem.EnsureDirectoryExists(path); err != nil {
 		return err
 	}
-	if err := os.WriteFile(path, page, 0o600); err != nil {
+	if err := filesystem.WriteFileAtomic(path, page, 0o600); err != nil {
 		return fmt.Errorf("write bulletin page %s: %w", path, err)
 	}
 	return nil
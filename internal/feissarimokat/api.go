@@ -38,7 +38,7 @@ func fetchRSSFeed() ([]RSSItem, error) {
 func fetchRSSFeedWithCache(store *httpcache.Store) ([]RSSItem, error) {
 	slog.Debug("Fetching Feissarimokat RSS feed", "url", FeedURL)
 
-	client := api.NewGenericClient()
+	client := api.NewGenericClient().ForProvider("feissarimokat")
 	body, err := httpcache.CachedGet(context.Background(), client, store, FeedURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching RSS feed: %w", err)
@@ -55,7 +55,7 @@ func fetchRSSFeedWithCache(store *httpcache.Store) ([]RSSItem, error) {
 
 // scrapeImages fetches a post page and extracts images from div.postbody
 func scrapeImages(pageURL string) ([]string, error) {
-	client := api.NewGenericClient()
+	client := api.NewGenericClient().ForProvider("feissarimokat")
 	resp, err := client.Get(pageURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching page: %w", err)
@@ -17,6 +17,7 @@ var previewInfo = &providers.PreviewInfo{
 		Description: "Feissarimokat comics with embedded images",
 		Author:      "Feissarimokat",
 		ID:          "https://www.feissarimokat.com/",
+		Language:    "fi",
 	},
 	ProviderName: "Feissarimokat",
 	TemplateName: "feissarimokat-atom",
@@ -25,7 +25,7 @@ const (
 func fetchItems() ([]Item, error) {
 	slog.Debug("Fetching Fingerpori items from API", "url", FingerporiAPIURL)
 
-	client := api.NewGenericClient()
+	client := api.NewGenericClient().ForProvider("fingerpori")
 	var items []Item
 	if err := client.GetAndDecode(FingerporiAPIURL, &items, nil); err != nil {
 		return nil, fmt.Errorf("error fetching Fingerpori items: %w", err)
@@ -16,11 +16,15 @@ var previewInfo = &providers.PreviewInfo{
 		Description: "Daily Fingerpori comics from Helsingin Sanomat",
 		Author:      "Pertti Jarla",
 		ID:          "https://www.hs.fi/fingerpori/",
+		Language:    "fi",
 	},
 	ProviderName: "Fingerpori",
 	TemplateName: "fingerpori-atom",
 }
 
+// defaultLimit is the number of comics in the feed when no limit is set.
+const defaultLimit = 100
+
 // Provider implements the FeedProvider interface for Fingerpori comics
 type Provider struct {
 	*providers.BaseProvider
@@ -77,10 +81,11 @@ func init() {
 		Factory:     factory,
 		ConfigFactory: func() any {
 			return &Config{
-				Limit: 100,
+				Limit: defaultLimit,
 			}
 		},
-		Preview: previewInfo,
+		Preview:      previewInfo,
+		DefaultLimit: defaultLimit,
 	})
 }
 
@@ -97,11 +102,7 @@ func (p *Provider) FetchItems(limit int) ([]providers.FeedItem, error) {
 	// Process items to add computed fields
 	items = processItems(items)
 
-	// Use provided limit or fall back to provider's default
-	itemLimit := limit
-	if itemLimit == 0 {
-		itemLimit = p.Limit
-	}
+	itemLimit := providers.ResolveLimit(limit, p.Limit, defaultLimit)
 
 	// Apply limit if specified
 	if itemLimit > 0 && len(items) > itemLimit {
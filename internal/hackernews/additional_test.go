@@ -4,11 +4,15 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/lepinkainen/feed-forge/pkg/database"
+	"github.com/lepinkainen/feed-forge/pkg/feed"
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
 	_ "modernc.org/sqlite"
 )
 
@@ -99,6 +103,20 @@ func TestItemGetters(t *testing.T) {
 	}
 }
 
+func TestItemTagURI(t *testing.T) {
+	item := &Item{
+		ItemID:           "12345",
+		ItemLink:         "https://example.com/story",
+		ItemCommentsLink: "https://news.ycombinator.com/item?id=12345",
+		ItemCreatedAt:    time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC),
+	}
+
+	got, ok := feedtypes.ItemTagURI(item)
+	if !ok || got != "tag:news.ycombinator.com,2024:item/12345" {
+		t.Fatalf("ItemTagURI() = %q, %v", got, ok)
+	}
+}
+
 func TestDatabaseHelpers(t *testing.T) {
 	db := newTestDB(t)
 
@@ -190,3 +208,137 @@ func contains(values []string, want string) bool {
 	}
 	return false
 }
+
+func TestScoreDeltasAcrossRuns(t *testing.T) {
+	db := newTestDB(t)
+	if err := initializeSchema(db); err != nil {
+		t.Fatalf("initializeSchema() error = %v", err)
+	}
+	// Running the schema twice exercises the column migrations.
+	if err := initializeSchema(db); err != nil {
+		t.Fatalf("initializeSchema(again) error = %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	item := Item{
+		ItemID:           "42",
+		ItemTitle:        "Rising story",
+		ItemLink:         "https://example.com/rising",
+		ItemCommentsLink: "https://news.ycombinator.com/item?id=42",
+		Points:           100,
+		ItemCommentCount: 10,
+		ItemAuthor:       "alice",
+		ItemCreatedAt:    now,
+		UpdatedAt:        now,
+	}
+
+	// First run: nothing reported yet, so no delta.
+	updateStoredItems(db, []Item{item})
+	first, err := getAllItems(db, 10, 0)
+	if err != nil {
+		t.Fatalf("getAllItems(first) error = %v", err)
+	}
+	if len(first) != 1 || first[0].ScoreDelta() != 0 || first[0].CommentDelta() != 0 {
+		t.Fatalf("first run deltas = %#v, want zero", first)
+	}
+	recordReportedStats(db, first)
+
+	// Second run: the score and comments grew.
+	item.Points = 112
+	item.ItemCommentCount = 15
+	updateStoredItems(db, []Item{item})
+	second, err := getAllItems(db, 10, 0)
+	if err != nil {
+		t.Fatalf("getAllItems(second) error = %v", err)
+	}
+	if len(second) != 1 {
+		t.Fatalf("getAllItems(second) len = %d, want 1", len(second))
+	}
+	if got := second[0].ScoreDelta(); got != 12 {
+		t.Errorf("ScoreDelta() = %d, want 12", got)
+	}
+	if got := second[0].CommentDelta(); got != 5 {
+		t.Errorf("CommentDelta() = %d, want 5", got)
+	}
+}
+
+var entryUpdatedRE = regexp.MustCompile(`<entry>[\s\S]*?<updated>([^<]+)</updated>`)
+
+func TestStableUpdatedAcrossRuns(t *testing.T) {
+	previous := feed.GetOptions()
+	t.Cleanup(func() { feed.SetOptions(previous) })
+	feed.SetOptions(feed.Options{StableUpdated: true})
+
+	db := newTestDB(t)
+	if err := initializeSchema(db); err != nil {
+		t.Fatalf("initializeSchema() error = %v", err)
+	}
+
+	created := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	firstSeen := created.Add(30 * time.Minute)
+	item := Item{
+		ItemID:           "42",
+		ItemTitle:        "Rising story",
+		ItemLink:         "https://example.com/rising",
+		ItemCommentsLink: "https://news.ycombinator.com/item?id=42",
+		Points:           100,
+		ItemCommentCount: 10,
+		ItemAuthor:       "alice",
+		ItemCreatedAt:    created,
+		UpdatedAt:        firstSeen,
+	}
+
+	render := func() string {
+		t.Helper()
+		items, err := getAllItems(db, 10, 0)
+		if err != nil {
+			t.Fatalf("getAllItems() error = %v", err)
+		}
+		content, err := feed.GenerateAtomFeedWithEmbeddedTemplate(convertToFeedItems(items), "hackernews-atom", feed.Config{Title: "HN", ID: "hn"}, nil)
+		if err != nil {
+			t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+		}
+		return content
+	}
+
+	entryUpdated := func(content string) string {
+		t.Helper()
+		match := entryUpdatedRE.FindStringSubmatch(content)
+		if match == nil {
+			t.Fatalf("entry <updated> missing:\n%s", content)
+		}
+		return match[1]
+	}
+
+	// First run, scraped at firstSeen.
+	updateStoredItems(db, []Item{item})
+	first := render()
+
+	var storedFirstSeen time.Time
+	if err := db.DB().QueryRow(`SELECT first_seen_at FROM items WHERE item_hn_id = ?`, item.ItemID).Scan(&storedFirstSeen); err != nil {
+		t.Fatalf("read first_seen_at: %v", err)
+	}
+	want := storedFirstSeen.UTC().Format(time.RFC3339)
+	if got := entryUpdated(first); got != want {
+		t.Errorf("first run <updated> = %s, want stored first_seen_at %s", got, want)
+	}
+
+	// Second run three hours later: the refresh bumps the score and updated_at.
+	item.Points = 250
+	item.UpdatedAt = firstSeen.Add(3 * time.Hour)
+	updateStoredItems(db, []Item{item})
+	second := render()
+	if got := entryUpdated(second); got != want {
+		t.Errorf("second run <updated> = %s, want unchanged first_seen_at %s", got, want)
+	}
+	if !strings.Contains(second, "<strong>Score:</strong> 250") {
+		t.Error("second run content does not show the refreshed score")
+	}
+
+	// Without the option the same item renders its submission time, so the
+	// assertions above depend on StableUpdated.
+	feed.SetOptions(feed.Options{})
+	if got := entryUpdated(render()); got == want {
+		t.Errorf("<updated> = first_seen_at %s without StableUpdated, want the created time", got)
+	}
+}
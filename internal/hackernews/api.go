@@ -2,10 +2,13 @@
 package hackernews
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/url"
+	"strconv"
 	"sync"
 	"time"
 
@@ -16,17 +19,54 @@ import (
 // an httptest server. algoliaItemURLFmt must contain exactly one %s for the
 // item ID.
 var (
-	algoliaSearchURL  = "https://hn.algolia.com/api/v1/search_by_date?tags=front_page&hitsPerPage=100"
+	algoliaSearchURL  = "https://hn.algolia.com/api/v1/search_by_date"
 	algoliaItemURLFmt = "https://hn.algolia.com/api/v1/items/%s"
 )
 
-// fetchItems retrieves current front page items from Algolia API
-func fetchItems() []Item {
-	slog.Debug("Fetching Hacker News items from Algolia API")
+// Story types selectable with Config.StoryType. Each maps directly to an
+// Algolia tags filter.
+const (
+	StoryTypeFrontPage = "front_page"
+	StoryTypeAskHN     = "ask_hn"
+	StoryTypeShowHN    = "show_hn"
+	StoryTypeNewest    = "story"
+)
+
+const algoliaHitsPerPage = 100
+
+// validStoryType reports whether storyType is one of the StoryType constants.
+func validStoryType(storyType string) bool {
+	switch storyType {
+	case StoryTypeFrontPage, StoryTypeAskHN, StoryTypeShowHN, StoryTypeNewest:
+		return true
+	}
+	return false
+}
+
+// searchURL builds the Algolia search URL for storyType, defaulting to the
+// front page.
+func searchURL(storyType string) string {
+	if storyType == "" {
+		storyType = StoryTypeFrontPage
+	}
+	u, err := url.Parse(algoliaSearchURL)
+	if err != nil {
+		return algoliaSearchURL
+	}
+	query := u.Query()
+	query.Set("tags", storyType)
+	query.Set("hitsPerPage", strconv.Itoa(algoliaHitsPerPage))
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// fetchItems retrieves current items of the given story type from Algolia API
+func fetchItems(storyType string) []Item {
+	slog.Debug("Fetching Hacker News items from Algolia API", "storyType", storyType)
 
 	var algoliaResp AlgoliaResponse
-	client := api.NewHackerNewsClient() // Use enhanced client with rate limiting
-	err := client.GetAndDecode(algoliaSearchURL, &algoliaResp, nil)
+	client := api.NewHackerNewsClient().ForProvider("hackernews") // Use enhanced client with rate limiting
+	err := client.GetAndDecode(searchURL(storyType), &algoliaResp, nil)
 	if err != nil {
 		slog.Error("Failed to fetch or decode Hacker News items", "error", err)
 		return nil
@@ -77,8 +117,11 @@ func fetchItems() []Item {
 	return items
 }
 
-// updateItemStats updates item statistics using concurrent API calls to Algolia
-func updateItemStats(db *sql.DB, items []Item, recentlyUpdated map[string]bool) {
+// updateItemStats updates item statistics using concurrent API calls to Algolia.
+// Every worker looks items up with fetchStats through client, so its rate
+// limiter throttles the whole refresh and its connections are reused. Items the
+// API no longer knows are deleted, or only flagged dead in archive mode.
+func updateItemStats(db *sql.DB, client *api.EnhancedClient, fetchStats func(*api.EnhancedClient, string) statsUpdate, outbound api.Semaphore, items []Item, recentlyUpdated map[string]bool, archiveMode bool) {
 	slog.Debug("Updating item stats", "itemCount", len(items))
 
 	itemsToUpdate, skippedCount := filterItemsForUpdate(items, recentlyUpdated)
@@ -96,14 +139,14 @@ func updateItemStats(db *sql.DB, items []Item, recentlyUpdated map[string]bool)
 	resultChan := make(chan statsUpdate, len(itemsToUpdate))
 	var wg sync.WaitGroup
 
-	// Shared client for all workers — single rate limiter across goroutines
-	client := api.NewHackerNewsClient()
-
 	// Start workers
 	for range numWorkers {
 		wg.Go(func() {
 			for item := range workChan {
-				update := fetchItemStats(client, item.ItemID)
+				// outbound is shared with the OpenGraph fetcher, capping both phases together
+				_ = outbound.Acquire(context.Background())
+				update := fetchStats(client, item.ItemID)
+				outbound.Release()
 				resultChan <- update
 			}
 		})
@@ -124,25 +167,20 @@ func updateItemStats(db *sql.DB, items []Item, recentlyUpdated map[string]bool)
 	updatedCount := 0
 	deletedCount := 0
 	for update := range resultChan {
-		upd, del := applyStatUpdate(db, update)
+		upd, del := applyStatUpdate(db, update, archiveMode)
 		updatedCount += upd
 		deletedCount += del
 	}
 
-	slog.Debug("Completed stats update", "updated", updatedCount, "deleted", deletedCount, "skipped", skippedCount)
+	slog.Debug("Completed stats update", "updated", updatedCount, "dead", deletedCount, "skipped", skippedCount)
 }
 
 // applyStatUpdate writes one Algolia result to the DB and reports the row delta.
-func applyStatUpdate(db *sql.DB, update statsUpdate) (updated, deleted int) {
+func applyStatUpdate(db *sql.DB, update statsUpdate, archiveMode bool) (updated, dead int) {
+	if update.isDeadItem {
+		return 0, removeDeadItem(db, update.itemID, archiveMode)
+	}
 	if update.err != nil {
-		if update.isDeadItem {
-			if _, err := db.Exec(`DELETE FROM items WHERE item_hn_id = ?`, update.itemID); err != nil {
-				slog.Warn("Failed to delete dead item from database", "error", err, "hn_id", update.itemID)
-				return 0, 0
-			}
-			slog.Debug("Deleted dead item from database", "hn_id", update.itemID)
-			return 0, 1
-		}
 		slog.Warn("Failed to fetch item stats from Algolia", "error", update.err, "hn_id", update.itemID)
 		return 0, 0
 	}
@@ -162,6 +200,26 @@ func applyStatUpdate(db *sql.DB, update statsUpdate) (updated, deleted int) {
 	return 1, 0
 }
 
+// removeDeadItem deletes a dead item, or flags it dead in archive mode so the
+// row is kept but excluded from feeds. It returns 1 when the row was changed.
+func removeDeadItem(db *sql.DB, itemID string, archiveMode bool) int {
+	if archiveMode {
+		if _, err := db.Exec(`UPDATE items SET dead = 1, updated_at = ? WHERE item_hn_id = ?`, time.Now(), itemID); err != nil {
+			slog.Warn("Failed to flag dead item in database", "error", err, "hn_id", itemID)
+			return 0
+		}
+		slog.Debug("Flagged dead item in database", "hn_id", itemID)
+		return 1
+	}
+
+	if _, err := db.Exec(`DELETE FROM items WHERE item_hn_id = ?`, itemID); err != nil {
+		slog.Warn("Failed to delete dead item from database", "error", err, "hn_id", itemID)
+		return 0
+	}
+	slog.Debug("Deleted dead item from database", "hn_id", itemID)
+	return 1
+}
+
 // filterItemsForUpdate drops items missing an ID or already refreshed this run.
 func filterItemsForUpdate(items []Item, recentlyUpdated map[string]bool) (toUpdate []Item, skipped int) {
 	for _, item := range items {
@@ -1,10 +1,15 @@
 package hackernews
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -51,7 +56,7 @@ func TestFetchItemsParsesAlgoliaResponse(t *testing.T) {
 	algoliaSearchURL = srv.URL
 	t.Cleanup(func() { algoliaSearchURL = original })
 
-	items := fetchItems()
+	items := fetchItems(StoryTypeFrontPage)
 	if len(items) != 2 {
 		t.Fatalf("got %d items, want 2", len(items))
 	}
@@ -70,6 +75,31 @@ func TestFetchItemsParsesAlgoliaResponse(t *testing.T) {
 	}
 }
 
+func TestSearchURLForStoryTypes(t *testing.T) {
+	tests := []struct {
+		storyType string
+		want      string
+	}{
+		{storyType: "", want: "https://hn.algolia.com/api/v1/search_by_date?hitsPerPage=100&tags=front_page"},
+		{storyType: StoryTypeFrontPage, want: "https://hn.algolia.com/api/v1/search_by_date?hitsPerPage=100&tags=front_page"},
+		{storyType: StoryTypeAskHN, want: "https://hn.algolia.com/api/v1/search_by_date?hitsPerPage=100&tags=ask_hn"},
+		{storyType: StoryTypeShowHN, want: "https://hn.algolia.com/api/v1/search_by_date?hitsPerPage=100&tags=show_hn"},
+		{storyType: StoryTypeNewest, want: "https://hn.algolia.com/api/v1/search_by_date?hitsPerPage=100&tags=story"},
+	}
+
+	for _, tt := range tests {
+		if got := searchURL(tt.storyType); got != tt.want {
+			t.Errorf("searchURL(%q) = %q, want %q", tt.storyType, got, tt.want)
+		}
+	}
+}
+
+func TestFactoryRejectsUnknownStoryType(t *testing.T) {
+	if _, err := factory(&Config{StoryType: "jobs"}); err == nil {
+		t.Fatal("factory() error = nil, want error for unknown story type")
+	}
+}
+
 func TestFetchItemsReturnsNilOnHTTPError(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "boom", http.StatusInternalServerError)
@@ -80,9 +110,9 @@ func TestFetchItemsReturnsNilOnHTTPError(t *testing.T) {
 	algoliaSearchURL = srv.URL
 	t.Cleanup(func() { algoliaSearchURL = original })
 
-	items := fetchItems()
+	items := fetchItems(StoryTypeFrontPage)
 	if items != nil {
-		t.Errorf("fetchItems() = %v, want nil on error", items)
+		t.Errorf("fetchItems(StoryTypeFrontPage) = %v, want nil on error", items)
 	}
 }
 
@@ -186,7 +216,7 @@ func TestUpdateItemStatsWritesToDatabase(t *testing.T) {
 	}
 	_ = updateStoredItems(db, items)
 
-	updateItemStats(db.DB(), items, map[string]bool{"300": true})
+	updateItemStats(db.DB(), api.NewHackerNewsClient(), fetchItemStats, nil, items, map[string]bool{"300": true}, false)
 
 	// 100 got its stats bumped.
 	var points, comments int
@@ -197,16 +227,13 @@ func TestUpdateItemStatsWritesToDatabase(t *testing.T) {
 		t.Errorf("item 100 stats = (%d, %d), want (999, 77)", points, comments)
 	}
 
-	// 200 returned 410 Gone. fetchItemStats flags it isDeadItem with err=nil,
-	// which updateItemStats currently treats as a successful stats update,
-	// writing points=0/comments=0 rather than deleting. This test pins that
-	// observed behavior — the apparent mismatch with the "dead item" branch
-	// (which only runs when err != nil) is a latent bug worth a separate fix.
-	if err := db.DB().QueryRow(`SELECT points, comment_count FROM items WHERE item_hn_id = ?`, "200").Scan(&points, &comments); err != nil {
-		t.Fatalf("scan 200: %v", err)
+	// 200 returned 410 Gone, so it is removed from the database.
+	var count int
+	if err := db.DB().QueryRow(`SELECT COUNT(*) FROM items WHERE item_hn_id = ?`, "200").Scan(&count); err != nil {
+		t.Fatalf("count 200: %v", err)
 	}
-	if points != 0 || comments != 0 {
-		t.Errorf("dead item stats = (%d, %d), want (0, 0)", points, comments)
+	if count != 0 {
+		t.Errorf("dead item rows = %d, want 0 (deleted)", count)
 	}
 
 	// 300 was left alone (skipped via recentlyUpdated).
@@ -218,6 +245,108 @@ func TestUpdateItemStatsWritesToDatabase(t *testing.T) {
 	}
 }
 
+func TestUpdateItemStatsArchiveModeFlagsDeadItems(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/100") {
+			_, _ = w.Write([]byte(`{"objectID":"100","points":999,"num_comments":77}`))
+			return
+		}
+		http.Error(w, "gone", http.StatusGone)
+	}))
+	t.Cleanup(srv.Close)
+
+	original := algoliaItemURLFmt
+	algoliaItemURLFmt = srv.URL + "/%s"
+	t.Cleanup(func() { algoliaItemURLFmt = original })
+
+	db := newTestDB(t)
+	if err := initializeSchema(db); err != nil {
+		t.Fatalf("initializeSchema: %v", err)
+	}
+
+	now := time.Now()
+	items := []Item{
+		{ItemID: "100", ItemTitle: "live", Points: 10, ItemCreatedAt: now, UpdatedAt: now},
+		{ItemID: "200", ItemTitle: "dead", Points: 500, ItemCreatedAt: now, UpdatedAt: now},
+	}
+	_ = updateStoredItems(db, items)
+
+	updateItemStats(db.DB(), api.NewHackerNewsClient(), fetchItemStats, nil, items, nil, true)
+
+	var dead bool
+	if err := db.DB().QueryRow(`SELECT dead FROM items WHERE item_hn_id = ?`, "200").Scan(&dead); err != nil {
+		t.Fatalf("dead item should be kept in archive mode: %v", err)
+	}
+	if !dead {
+		t.Error("dead item not flagged dead")
+	}
+
+	got, err := getAllItems(db, 10, 0)
+	if err != nil {
+		t.Fatalf("getAllItems: %v", err)
+	}
+	if len(got) != 1 || got[0].ItemID != "100" {
+		t.Errorf("getAllItems = %+v, want only item 100", got)
+	}
+}
+
+// countingLimiter records how many requests went through the limiter it wraps.
+type countingLimiter struct {
+	*api.SimpleRateLimiter
+	calls atomic.Int32
+}
+
+func (l *countingLimiter) WaitContext(ctx context.Context) error {
+	l.calls.Add(1)
+	return l.SimpleRateLimiter.WaitContext(ctx)
+}
+
+func TestUpdateItemStatsSharesRateLimiterAcrossWorkers(t *testing.T) {
+	const delay = 40 * time.Millisecond
+	var mu sync.Mutex
+	var arrivals []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		arrivals = append(arrivals, time.Now())
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"points":1,"num_comments":1}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	original := algoliaItemURLFmt
+	algoliaItemURLFmt = srv.URL + "/%s"
+	t.Cleanup(func() { algoliaItemURLFmt = original })
+
+	db := newTestDB(t)
+	if err := initializeSchema(db); err != nil {
+		t.Fatalf("initializeSchema: %v", err)
+	}
+	now := time.Now()
+	var items []Item
+	for i := range 6 {
+		items = append(items, Item{ItemID: strconv.Itoa(i + 1), ItemCreatedAt: now, UpdatedAt: now})
+	}
+	_ = updateStoredItems(db, items)
+
+	limiter := &countingLimiter{SimpleRateLimiter: api.NewSimpleRateLimiter(delay)}
+	client := api.NewEnhancedClient(&api.EnhancedClientConfig{RateLimiter: limiter})
+	updateItemStats(db.DB(), client, fetchItemStats, nil, items, nil, false)
+
+	if got := limiter.calls.Load(); got != int32(len(items)) {
+		t.Fatalf("limiter calls = %d, want %d (one per item through the shared client)", got, len(items))
+	}
+	if len(arrivals) != len(items) {
+		t.Fatalf("server requests = %d, want %d", len(arrivals), len(items))
+	}
+	slices.SortFunc(arrivals, func(a, b time.Time) int { return a.Compare(b) })
+	for i := 1; i < len(arrivals); i++ {
+		// Allow some scheduling slack below the limiter's delay.
+		if gap := arrivals[i].Sub(arrivals[i-1]); gap < delay*3/4 {
+			t.Fatalf("requests %d and %d arrived %v apart, want about %v: workers are not sharing one limiter", i-1, i, gap, delay)
+		}
+	}
+}
+
 func TestUpdateItemStatsNoOpWhenAllSkipped(t *testing.T) {
 	db := newTestDB(t)
 	if err := initializeSchema(db); err != nil {
@@ -234,7 +363,7 @@ func TestUpdateItemStatsNoOpWhenAllSkipped(t *testing.T) {
 
 	done := make(chan struct{})
 	go func() {
-		updateItemStats(db.DB(), items, map[string]bool{"1": true})
+		updateItemStats(db.DB(), api.NewHackerNewsClient(), fetchItemStats, nil, items, map[string]bool{"1": true}, false)
 		close(done)
 	}()
 	select {
@@ -358,3 +487,85 @@ func TestFetchItemsUsesProvidedContentDB(t *testing.T) {
 		t.Errorf("got %d items, want 0 from empty response", len(items))
 	}
 }
+
+func TestDefaultLimit(t *testing.T) {
+	info, err := providers.DefaultRegistry.Get("hackernews")
+	if err != nil {
+		t.Fatalf("registry Get: %v", err)
+	}
+	if info.DefaultLimit != 30 {
+		t.Fatalf("DefaultLimit = %d, want 30", info.DefaultLimit)
+	}
+	if cfg := info.ConfigFactory().(*Config); cfg.Limit != info.DefaultLimit {
+		t.Errorf("ConfigFactory Limit = %d, want DefaultLimit %d", cfg.Limit, info.DefaultLimit)
+	}
+
+	// Every hit is new this run, so no stats lookups are made.
+	resp := AlgoliaResponse{}
+	created := time.Now().UTC().Format(time.RFC3339)
+	for i := range 2 * defaultLimit {
+		id := strconv.Itoa(1000 + i)
+		resp.Hits = append(resp.Hits, AlgoliaHit{ObjectID: id, Title: "Story " + id, URL: "https://example.com/" + id, Points: 100, CreatedAt: created})
+	}
+	payload, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(payload)
+	}))
+	t.Cleanup(srv.Close)
+
+	origSearch := algoliaSearchURL
+	algoliaSearchURL = srv.URL
+	t.Cleanup(func() { algoliaSearchURL = origSearch })
+
+	p := &Provider{
+		BaseProvider:   &providers.BaseProvider{ContentDB: newTestDB(t)},
+		CategoryMapper: LoadConfig(""),
+	}
+	items, err := p.FetchItems(0)
+	if err != nil {
+		t.Fatalf("FetchItems: %v", err)
+	}
+	if len(items) != info.DefaultLimit {
+		t.Fatalf("FetchItems(0) with no configured limit returned %d items, want DefaultLimit %d", len(items), info.DefaultLimit)
+	}
+}
+
+func TestUpdateItemStatsHonorsOutboundSemaphore(t *testing.T) {
+	const limit = 3
+	db := newTestDB(t)
+	if err := initializeSchema(db); err != nil {
+		t.Fatalf("initializeSchema: %v", err)
+	}
+	now := time.Now()
+	var items []Item
+	for i := range 12 {
+		items = append(items, Item{ItemID: strconv.Itoa(i + 1), ItemCreatedAt: now, UpdatedAt: now})
+	}
+	_ = updateStoredItems(db, items)
+
+	var current, peak, calls atomic.Int32
+	fetch := func(_ *api.EnhancedClient, itemID string) statsUpdate {
+		n := current.Add(1)
+		defer current.Add(-1)
+		calls.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return statsUpdate{itemID: itemID, points: 1, commentCount: 1}
+	}
+	updateItemStats(db.DB(), api.NewHackerNewsClient(), fetch, api.NewSemaphore(limit), items, nil, false)
+
+	if got := calls.Load(); got != int32(len(items)) {
+		t.Fatalf("stats fetches = %d, want %d", got, len(items))
+	}
+	if got := peak.Load(); got > limit {
+		t.Fatalf("peak concurrent stats fetches = %d, want at most %d", got, limit)
+	}
+}
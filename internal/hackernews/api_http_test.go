@@ -71,11 +71,11 @@ func TestFetchItemsWithHTTPServer(t *testing.T) {
 	algoliaItemURLFmt = server.URL + "/api/v1/items/%s"
 
 	before := time.Now()
-	items := fetchItems()
+	items := fetchItems(StoryTypeFrontPage)
 	after := time.Now()
 
 	if len(items) != 2 {
-		t.Fatalf("len(fetchItems()) = %d, want 2", len(items))
+		t.Fatalf("len(fetchItems(StoryTypeFrontPage)) = %d, want 2", len(items))
 	}
 	if items[0].ItemID != "123" || items[0].ItemTitle != "Good story" || items[0].ItemLink != "https://example.com/good" {
 		t.Fatalf("first item = %#v", items[0])
@@ -107,9 +107,9 @@ func TestFetchItemsWithLiveSnapshotFixture(t *testing.T) {
 	defer server.Close()
 
 	algoliaSearchURL = server.URL
-	items := fetchItems()
+	items := fetchItems(StoryTypeFrontPage)
 	if len(items) < 20 {
-		t.Fatalf("len(fetchItems()) = %d, want at least 20 items from live snapshot", len(items))
+		t.Fatalf("len(fetchItems(StoryTypeFrontPage)) = %d, want at least 20 items from live snapshot", len(items))
 	}
 	if items[0].ItemID == "" || items[0].ItemTitle == "" || items[0].ItemCommentsLink == "" {
 		t.Fatalf("first parsed item missing fields: %#v", items[0])
@@ -133,8 +133,8 @@ func TestFetchItemsReturnsNilOnDecodeError(t *testing.T) {
 	defer server.Close()
 
 	algoliaSearchURL = server.URL
-	if items := fetchItems(); items != nil {
-		t.Fatalf("fetchItems() = %#v, want nil on malformed JSON", items)
+	if items := fetchItems(StoryTypeFrontPage); items != nil {
+		t.Fatalf("fetchItems(StoryTypeFrontPage) = %#v, want nil on malformed JSON", items)
 	}
 }
 
@@ -39,20 +39,32 @@ func categorizeContent(title, domain, url string, categoryMapper *CategoryMapper
 	return categories
 }
 
-// categorizeByPoints returns a category label based on point count and threshold
-func categorizeByPoints(points int, minPoints int) string {
-	switch {
-	case points >= 500:
-		return "Viral 500+"
-	case points >= 200:
-		return "Hot 200+"
-	case points >= 100:
-		return "High Score 100+"
-	case points >= minPoints*2:
-		return fmt.Sprintf("High Score %d+", minPoints*2)
-	case points >= minPoints:
-		return fmt.Sprintf("Popular %d+", minPoints)
-	default:
-		return "Rising"
+// risingCategory labels items below every point bucket.
+const risingCategory = "Rising"
+
+// defaultPointBuckets returns the built-in point buckets for a minimum points
+// threshold, highest first.
+func defaultPointBuckets(minPoints int) []PointBucket {
+	return []PointBucket{
+		{Min: 500, Label: "Viral 500+"},
+		{Min: 200, Label: "Hot 200+"},
+		{Min: 100, Label: "High Score 100+"},
+		{Min: minPoints * 2, Label: fmt.Sprintf("High Score %d+", minPoints*2)},
+		{Min: minPoints, Label: fmt.Sprintf("Popular %d+", minPoints)},
+	}
+}
+
+// categorizeByPoints returns the label of the first bucket whose minimum the
+// points reach, or "Rising" below all of them. Empty buckets use
+// defaultPointBuckets for minPoints.
+func categorizeByPoints(points int, minPoints int, buckets []PointBucket) string {
+	if len(buckets) == 0 {
+		buckets = defaultPointBuckets(minPoints)
+	}
+	for _, bucket := range buckets {
+		if points >= bucket.Min {
+			return bucket.Label
+		}
 	}
+	return risingCategory
 }
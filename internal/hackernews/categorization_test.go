@@ -1,6 +1,7 @@
 package hackernews
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -211,7 +212,7 @@ func TestCategorizeByPoints(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := categorizeByPoints(tt.points, tt.minPoints)
+			result := categorizeByPoints(tt.points, tt.minPoints, nil)
 			if result != tt.expected {
 				t.Errorf("categorizeByPoints(%d, %d) = %q, expected %q",
 					tt.points, tt.minPoints, result, tt.expected)
@@ -219,3 +220,59 @@ func TestCategorizeByPoints(t *testing.T) {
 		})
 	}
 }
+
+func TestCategorizeByPoints_CustomBuckets(t *testing.T) {
+	// Listed out of order on purpose: the mapper sorts them highest first.
+	buckets := []PointBucket{
+		{Min: 10, Label: "Noticed 10+"},
+		{Min: 50, Label: "Hot 50+"},
+		{Min: 25, Label: "Warm 25+"},
+	}
+	config := &DomainConfig{PointBuckets: buckets}
+	mapper := NewCategoryMapper(config)
+	if config.PointBuckets[0].Min != 10 || config.PointBuckets[1].Min != 50 || config.PointBuckets[2].Min != 25 {
+		t.Fatalf("NewCategoryMapper() reordered the caller's buckets: %v", config.PointBuckets)
+	}
+
+	tests := []struct {
+		points int
+		want   string
+	}{
+		{9, "Rising"},
+		{10, "Noticed 10+"},
+		{24, "Noticed 10+"},
+		{25, "Warm 25+"},
+		{49, "Warm 25+"},
+		{50, "Hot 50+"},
+		{600, "Hot 50+"},
+	}
+	for _, tt := range tests {
+		if got := categorizeByPoints(tt.points, 50, mapper.PointBuckets()); got != tt.want {
+			t.Errorf("categorizeByPoints(%d) = %q, want %q", tt.points, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfig_PointBuckets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "domains.json")
+	if err := os.WriteFile(path, []byte(`{
+		"category_domains": {"Code": ["github.com"]},
+		"point_buckets": [{"min": 5, "label": "Seen 5+"}, {"min": 20, "label": "Big 20+"}]
+	}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	mapper := LoadConfig(path)
+	if mapper == nil {
+		t.Fatal("LoadConfig() = nil")
+	}
+	if got := categorizeByPoints(21, 50, mapper.PointBuckets()); got != "Big 20+" {
+		t.Errorf("categorizeByPoints(21) = %q, want %q", got, "Big 20+")
+	}
+	if got := (*CategoryMapper)(nil).PointBuckets(); got != nil {
+		t.Errorf("nil mapper PointBuckets() = %v, want nil", got)
+	}
+	if got := categorizeByPoints(600, 50, nil); got != "Viral 500+" {
+		t.Errorf("default buckets: categorizeByPoints(600) = %q, want %q", got, "Viral 500+")
+	}
+}
@@ -0,0 +1,220 @@
+package hackernews
+
+import (
+	"fmt"
+	"html"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/api"
+	"github.com/lepinkainen/feed-forge/pkg/providers"
+)
+
+// algoliaCommentSearchURL is Algolia's relevance-ranked search, used to find
+// the best comments on a story. A var so tests can point it at httptest.
+var algoliaCommentSearchURL = "https://hn.algolia.com/api/v1/search"
+
+// Comments mode picks the top commentStories front-page stories by points and
+// takes the commentsPerStory best comments from each.
+const (
+	commentStories   = 10
+	commentsPerStory = 3
+)
+
+var (
+	commentParagraphRegex = regexp.MustCompile(`(?i)<p\s*/?>`)
+	commentTagRegex       = regexp.MustCompile(`<[^>]*>`)
+)
+
+// AlgoliaCommentResponse is an Algolia search response for comment hits.
+type AlgoliaCommentResponse struct {
+	Hits []AlgoliaCommentHit `json:"hits"`
+}
+
+// AlgoliaCommentHit is a single comment from Algolia search results.
+type AlgoliaCommentHit struct {
+	ObjectID    string `json:"objectID"`
+	Author      string `json:"author"`
+	CommentText string `json:"comment_text"`
+	CreatedAt   string `json:"created_at"`
+	StoryID     int    `json:"story_id"`
+	StoryTitle  string `json:"story_title"`
+}
+
+// CommentItem is a notable comment on a Hacker News story.
+type CommentItem struct {
+	CommentID  string
+	StoryID    string
+	StoryTitle string
+	Text       string // Plain text with entities decoded; paragraphs separated by blank lines
+	CommentBy  string
+	PostedAt   time.Time
+}
+
+// Title returns the comment author and the story it was posted on.
+func (c *CommentItem) Title() string {
+	return fmt.Sprintf("%s on %q", c.CommentBy, c.StoryTitle)
+}
+
+// Link returns the comment permalink.
+func (c *CommentItem) Link() string {
+	return "https://news.ycombinator.com/item?id=" + c.CommentID
+}
+
+// CommentsLink returns the discussion page of the story the comment is on.
+func (c *CommentItem) CommentsLink() string {
+	return "https://news.ycombinator.com/item?id=" + c.StoryID
+}
+
+// GUID returns the comment permalink, which is unique per comment.
+func (c *CommentItem) GUID() string {
+	return c.Link()
+}
+
+// Author returns the comment author.
+func (c *CommentItem) Author() string {
+	return c.CommentBy
+}
+
+// AuthorURI returns the Hacker News user profile URL.
+func (c *CommentItem) AuthorURI() string {
+	return fmt.Sprintf("https://news.ycombinator.com/user?id=%s", c.CommentBy)
+}
+
+// Score returns 0; Hacker News does not expose comment points.
+func (c *CommentItem) Score() int {
+	return 0
+}
+
+// CommentCount returns 0; replies are not counted.
+func (c *CommentItem) CommentCount() int {
+	return 0
+}
+
+// CreatedAt returns when the comment was posted.
+func (c *CommentItem) CreatedAt() time.Time {
+	return c.PostedAt
+}
+
+// Categories returns a single "Comment" category.
+func (c *CommentItem) Categories() []string {
+	return []string{"Comment"}
+}
+
+// ImageURL returns an empty string; comments have no images.
+func (c *CommentItem) ImageURL() string {
+	return ""
+}
+
+// Content returns the comment text as escaped HTML paragraphs.
+func (c *CommentItem) Content() string {
+	var b strings.Builder
+	for _, paragraph := range strings.Split(c.Text, "\n\n") {
+		b.WriteString("<p>")
+		b.WriteString(html.EscapeString(paragraph))
+		b.WriteString("</p>")
+	}
+	return b.String()
+}
+
+// commentText converts Algolia's comment HTML to plain text: paragraphs become
+// blank-line breaks, tags are dropped and HTML entities are decoded.
+func commentText(commentHTML string) string {
+	var paragraphs []string
+	for _, paragraph := range commentParagraphRegex.Split(commentHTML, -1) {
+		text := html.UnescapeString(commentTagRegex.ReplaceAllString(paragraph, ""))
+		if text = strings.Join(strings.Fields(text), " "); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// commentSearchURL builds the Algolia query for the best comments on storyID.
+func commentSearchURL(storyID string) string {
+	u, err := url.Parse(algoliaCommentSearchURL)
+	if err != nil {
+		return algoliaCommentSearchURL
+	}
+	query := u.Query()
+	query.Set("tags", "comment,story_"+storyID)
+	query.Set("hitsPerPage", strconv.Itoa(commentsPerStory))
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// fetchStoryComments retrieves the best comments on a single story.
+func fetchStoryComments(client *api.EnhancedClient, story Item) ([]CommentItem, error) {
+	var resp AlgoliaCommentResponse
+	if err := client.GetAndDecode(commentSearchURL(story.ItemID), &resp, nil); err != nil {
+		return nil, fmt.Errorf("fetch comments for story %s: %w", story.ItemID, err)
+	}
+
+	comments := make([]CommentItem, 0, len(resp.Hits))
+	for _, hit := range resp.Hits {
+		text := commentText(hit.CommentText)
+		if text == "" {
+			continue
+		}
+		postedAt, err := time.Parse(time.RFC3339, hit.CreatedAt)
+		if err != nil {
+			postedAt = story.ItemCreatedAt
+		}
+		storyTitle := hit.StoryTitle
+		if storyTitle == "" {
+			storyTitle = story.ItemTitle
+		}
+		comments = append(comments, CommentItem{
+			CommentID:  hit.ObjectID,
+			StoryID:    story.ItemID,
+			StoryTitle: storyTitle,
+			Text:       text,
+			CommentBy:  hit.Author,
+			PostedAt:   postedAt,
+		})
+	}
+	return comments, nil
+}
+
+// fetchComments returns the best comments on the highest-scoring stories of
+// storyType with at least minPoints, newest comments first, capped at limit.
+func fetchComments(storyType string, minPoints, limit int) []providers.FeedItem {
+	var stories []Item
+	for _, story := range fetchItems(storyType) {
+		if story.Points >= minPoints {
+			stories = append(stories, story)
+		}
+	}
+	sort.SliceStable(stories, func(i, j int) bool { return stories[i].Points > stories[j].Points })
+	if len(stories) > commentStories {
+		stories = stories[:commentStories]
+	}
+
+	client := api.NewHackerNewsClient().ForProvider("hackernews")
+	var comments []CommentItem
+	for _, story := range stories {
+		storyComments, err := fetchStoryComments(client, story)
+		if err != nil {
+			slog.Warn("Failed to fetch Hacker News comments", "hn_id", story.ItemID, "error", err)
+			continue
+		}
+		comments = append(comments, storyComments...)
+	}
+
+	sort.SliceStable(comments, func(i, j int) bool { return comments[i].PostedAt.After(comments[j].PostedAt) })
+	if limit > 0 && len(comments) > limit {
+		comments = comments[:limit]
+	}
+
+	slog.Debug("Fetched Hacker News comments", "stories", len(stories), "comments", len(comments))
+	feedItems := make([]providers.FeedItem, len(comments))
+	for i := range comments {
+		feedItems[i] = &comments[i]
+	}
+	return feedItems
+}
@@ -0,0 +1,81 @@
+package hackernews
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommentText(t *testing.T) {
+	got := commentText(`It&#x27;s &quot;fine&quot; &gt; 3<p>See <a href="https:&#x2F;&#x2F;example.com">https:&#x2F;&#x2F;example.com</a><p><i>Tom &amp; Jerry</i>`)
+	want := "It's \"fine\" > 3\n\nSee https://example.com\n\nTom & Jerry"
+	if got != want {
+		t.Fatalf("commentText() = %q, want %q", got, want)
+	}
+}
+
+func TestFetchCommentsFromMockedAlgolia(t *testing.T) {
+	var commentQueries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/stories" {
+			_, _ = w.Write(algoliaSearchPayload())
+			return
+		}
+		commentQueries = append(commentQueries, r.URL.Query().Get("tags"))
+		if r.URL.Query().Get("tags") != "comment,story_100" {
+			_, _ = w.Write([]byte(`{"hits":[]}`))
+			return
+		}
+		resp := AlgoliaCommentResponse{Hits: []AlgoliaCommentHit{{
+			ObjectID:    "555",
+			Author:      "carol",
+			CommentText: "I&#x27;ve shipped this &amp; it works.<p>Second &lt;para&gt;",
+			CreatedAt:   time.Date(2026, 4, 10, 13, 0, 0, 0, time.UTC).Format(time.RFC3339),
+			StoryID:     100,
+			StoryTitle:  "Story One",
+		}}}
+		b, _ := json.Marshal(resp)
+		_, _ = w.Write(b)
+	}))
+	t.Cleanup(srv.Close)
+
+	originalSearch, originalComments := algoliaSearchURL, algoliaCommentSearchURL
+	algoliaSearchURL = srv.URL + "/stories"
+	algoliaCommentSearchURL = srv.URL + "/comments"
+	t.Cleanup(func() { algoliaSearchURL, algoliaCommentSearchURL = originalSearch, originalComments })
+
+	items := fetchComments(StoryTypeFrontPage, 100, 10)
+
+	// Only Story One (150 points) clears minPoints=100.
+	if len(commentQueries) != 1 || commentQueries[0] != "comment,story_100" {
+		t.Fatalf("comment queries = %v, want only story_100", commentQueries)
+	}
+	if len(items) != 1 {
+		t.Fatalf("got %d items, want 1", len(items))
+	}
+
+	comment := items[0]
+	if comment.Link() != "https://news.ycombinator.com/item?id=555" {
+		t.Errorf("Link() = %q, want comment permalink", comment.Link())
+	}
+	if comment.CommentsLink() != "https://news.ycombinator.com/item?id=100" {
+		t.Errorf("CommentsLink() = %q, want story discussion", comment.CommentsLink())
+	}
+	if comment.Author() != "carol" {
+		t.Errorf("Author() = %q", comment.Author())
+	}
+	if comment.Title() != `carol on "Story One"` {
+		t.Errorf("Title() = %q", comment.Title())
+	}
+	wantContent := "<p>I&#39;ve shipped this &amp; it works.</p><p>Second &lt;para&gt;</p>"
+	if comment.Content() != wantContent {
+		t.Errorf("Content() = %q, want %q", comment.Content(), wantContent)
+	}
+	if !strings.Contains(comment.(*CommentItem).Text, "I've shipped this & it works.") {
+		t.Errorf("Text = %q, want decoded entities", comment.(*CommentItem).Text)
+	}
+}
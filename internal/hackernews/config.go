@@ -1,10 +1,12 @@
 package hackernews
 
 import (
+	"cmp"
 	"encoding/json"
 	"errors"
 	"io/fs"
 	"log/slog"
+	"slices"
 	"strings"
 
 	"github.com/lepinkainen/feed-forge/configs"
@@ -14,12 +16,21 @@ import (
 // DomainConfig represents the configuration structure for domain mappings
 type DomainConfig struct {
 	CategoryDomains map[string][]string `json:"category_domains"`
+	// PointBuckets replaces the built-in points categories when set.
+	PointBuckets []PointBucket `json:"point_buckets,omitempty"`
+}
+
+// PointBucket labels items with at least Min points.
+type PointBucket struct {
+	Min   int    `json:"min"`
+	Label string `json:"label"`
 }
 
 // CategoryMapper provides methods for domain categorization
 type CategoryMapper struct {
 	config           *DomainConfig
 	domainToCategory map[string]string // reverse lookup for efficient searching
+	pointBuckets     []PointBucket     // config.PointBuckets, highest first
 }
 
 // LoadConfig loads configuration with fallback priority:
@@ -75,6 +86,11 @@ func NewCategoryMapper(config *DomainConfig) *CategoryMapper {
 		}
 	}
 
+	// Check the highest bucket first whatever order the config lists them in.
+	// Sort a copy so the caller's config keeps its order.
+	mapper.pointBuckets = slices.Clone(config.PointBuckets)
+	slices.SortStableFunc(mapper.pointBuckets, func(a, b PointBucket) int { return cmp.Compare(b.Min, a.Min) })
+
 	slog.Debug("CategoryMapper initialized", "categories", len(config.CategoryDomains), "domain_mappings", len(mapper.domainToCategory))
 	return mapper
 }
@@ -106,3 +122,12 @@ func (cm *CategoryMapper) GetAllCategories() []string {
 	}
 	return categories
 }
+
+// PointBuckets returns the configured point buckets, or nil to use the
+// defaults. A nil mapper has none.
+func (cm *CategoryMapper) PointBuckets() []PointBucket {
+	if cm == nil {
+		return nil
+	}
+	return cm.pointBuckets
+}
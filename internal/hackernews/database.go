@@ -3,6 +3,7 @@ package hackernews
 import (
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/lepinkainen/feed-forge/pkg/database"
 	_ "modernc.org/sqlite" // Pure Go SQLite driver
@@ -22,12 +23,31 @@ func initializeSchema(db *database.Database) error {
 		comment_count INTEGER DEFAULT 0,
 		author TEXT,
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		reported_points INTEGER,                -- points when the item was last emitted in a feed
+		reported_comment_count INTEGER,         -- comment count when the item was last emitted in a feed
+		dead BOOLEAN DEFAULT 0,                 -- deleted on HN; kept instead of removed in archive mode
+		first_seen_at TIMESTAMP                 -- when the item was first stored; never updated
 	)`
 	if err := db.ExecuteSchema(createItemsTable); err != nil {
 		return fmt.Errorf("failed to create items table: %w", err)
 	}
 
+	for _, migration := range []string{
+		`ALTER TABLE items ADD COLUMN reported_points INTEGER`,
+		`ALTER TABLE items ADD COLUMN reported_comment_count INTEGER`,
+		`ALTER TABLE items ADD COLUMN dead BOOLEAN DEFAULT 0`,
+		`ALTER TABLE items ADD COLUMN first_seen_at TIMESTAMP`,
+	} {
+		if err := db.ExecuteSchema(migration); err != nil && !strings.Contains(strings.ToLower(err.Error()), "duplicate column") {
+			return fmt.Errorf("failed to migrate items table: %w", err)
+		}
+	}
+	// Rows stored before first_seen_at existed take their current updated_at once.
+	if err := db.ExecuteSchema(`UPDATE items SET first_seen_at = updated_at WHERE first_seen_at IS NULL`); err != nil {
+		return fmt.Errorf("failed to backfill first_seen_at: %w", err)
+	}
+
 	slog.Debug("Database schema initialized successfully")
 	return nil
 }
@@ -41,8 +61,8 @@ func updateStoredItems(db *database.Database, newItems []Item) map[string]bool {
 		// The 'item.CreatedAt' should be the original submission time of the HN post.
 		// The 'item.UpdatedAt' should be when it was last seen/modified by your scraper.
 		result, err := db.DB().Exec(`
-			INSERT INTO items (item_hn_id, title, link, comments_link, points, comment_count, author, created_at, updated_at)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			INSERT INTO items (item_hn_id, title, link, comments_link, points, comment_count, author, created_at, updated_at, first_seen_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 			ON CONFLICT(item_hn_id) DO UPDATE SET
 				title = excluded.title,
 				link = excluded.link, 
@@ -50,8 +70,8 @@ func updateStoredItems(db *database.Database, newItems []Item) map[string]bool {
 				points = excluded.points,
 				comment_count = excluded.comment_count,
 				author = excluded.author,
-				updated_at = excluded.updated_at`, // Note: created_at is not updated on conflict
-			item.ItemID, item.ItemTitle, item.ItemLink, item.ItemCommentsLink, item.Points, item.ItemCommentCount, item.ItemAuthor, item.ItemCreatedAt, item.UpdatedAt)
+				updated_at = excluded.updated_at`, // Note: created_at and first_seen_at are not updated on conflict
+			item.ItemID, item.ItemTitle, item.ItemLink, item.ItemCommentsLink, item.Points, item.ItemCommentCount, item.ItemAuthor, item.ItemCreatedAt, item.UpdatedAt, item.UpdatedAt)
 
 		if err != nil {
 			slog.Error("Error updating item", "error", err, "hn_id", item.ItemID)
@@ -68,10 +88,12 @@ func updateStoredItems(db *database.Database, newItems []Item) map[string]bool {
 	return updatedItems
 }
 
-// getAllItems retrieves items from database with minimum points threshold
+// getAllItems retrieves live items from database with minimum points threshold
 func getAllItems(db *database.Database, limit int, minPoints int) ([]Item, error) {
 	slog.Debug("Querying database for items", "limit", limit, "minPoints", minPoints)
-	rows, err := db.DB().Query("SELECT item_hn_id, title, link, comments_link, points, comment_count, author, created_at, updated_at FROM items WHERE points > ? ORDER BY created_at DESC LIMIT ?", minPoints, limit)
+	rows, err := db.DB().Query(`SELECT item_hn_id, title, link, comments_link, points, comment_count, author, created_at, updated_at, first_seen_at,
+		COALESCE(reported_points, points), COALESCE(reported_comment_count, comment_count)
+		FROM items WHERE points > ? AND dead = 0 ORDER BY created_at DESC LIMIT ?`, minPoints, limit)
 	if err != nil {
 		slog.Error("Failed to query database", "error", err)
 		return nil, err
@@ -81,14 +103,29 @@ func getAllItems(db *database.Database, limit int, minPoints int) ([]Item, error
 	var items []Item
 	for rows.Next() {
 		var item Item
-		err := rows.Scan(&item.ItemID, &item.ItemTitle, &item.ItemLink, &item.ItemCommentsLink, &item.Points, &item.ItemCommentCount, &item.ItemAuthor, &item.ItemCreatedAt, &item.UpdatedAt)
+		var reportedPoints, reportedComments int
+		err := rows.Scan(&item.ItemID, &item.ItemTitle, &item.ItemLink, &item.ItemCommentsLink, &item.Points, &item.ItemCommentCount, &item.ItemAuthor, &item.ItemCreatedAt, &item.UpdatedAt, &item.FirstSeen,
+			&reportedPoints, &reportedComments)
 		if err != nil {
 			slog.Error("Error scanning row", "error", err)
 			continue
 		}
+		item.PointsDelta = item.Points - reportedPoints
+		item.CommentsDelta = item.ItemCommentCount - reportedComments
 		items = append(items, item)
 	}
 
 	slog.Debug("Retrieved items from database", "count", len(items))
 	return items, nil
 }
+
+// recordReportedStats stores the current stats of emitted items so the next
+// run can compute score and comment deltas against them.
+func recordReportedStats(db *database.Database, items []Item) {
+	for _, item := range items {
+		if _, err := db.DB().Exec(`UPDATE items SET reported_points = ?, reported_comment_count = ? WHERE item_hn_id = ?`,
+			item.Points, item.ItemCommentCount, item.ItemID); err != nil {
+			slog.Warn("Failed to record reported item stats", "error", err, "hn_id", item.ItemID)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package hackernews
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/api"
+)
+
+// Hacker News APIs selectable with Config.API.
+const (
+	APIAlgolia  = "algolia"
+	APIFirebase = "firebase"
+)
+
+// firebaseBaseURL is the official Hacker News API. Kept as a var so tests can
+// point it at an httptest server.
+var firebaseBaseURL = "https://hacker-news.firebaseio.com/v0"
+
+// firebaseStoryLists maps story types to Firebase story list endpoints.
+var firebaseStoryLists = map[string]string{
+	StoryTypeFrontPage: "topstories",
+	StoryTypeAskHN:     "askstories",
+	StoryTypeShowHN:    "showstories",
+	StoryTypeNewest:    "newstories",
+}
+
+// validAPI reports whether hnAPI is empty or one of the API constants.
+func validAPI(hnAPI string) bool {
+	switch hnAPI {
+	case "", APIAlgolia, APIFirebase:
+		return true
+	}
+	return false
+}
+
+// itemStatsFetcher returns the per-item stats lookup for hnAPI.
+func itemStatsFetcher(hnAPI string) func(*api.EnhancedClient, string) statsUpdate {
+	if hnAPI == APIFirebase {
+		return fetchFirebaseItemStats
+	}
+	return fetchItemStats
+}
+
+// fetchFirebaseItems retrieves current items of the given story type from the
+// Firebase API: the story list, then each of its first algoliaHitsPerPage
+// items, so a run sees the same number of stories as with Algolia.
+func fetchFirebaseItems(storyType string) []Item {
+	list := firebaseStoryLists[storyType]
+	if list == "" {
+		list = firebaseStoryLists[StoryTypeFrontPage]
+	}
+	slog.Debug("Fetching Hacker News items from Firebase API", "storyType", storyType, "list", list)
+
+	client := api.NewHackerNewsClient().ForProvider("hackernews")
+	var ids []int
+	if err := client.GetAndDecode(firebaseBaseURL+"/"+list+".json", &ids, nil); err != nil {
+		slog.Error("Failed to fetch or decode Hacker News story list", "error", err)
+		return nil
+	}
+	if len(ids) > algoliaHitsPerPage {
+		ids = ids[:algoliaHitsPerPage]
+	}
+
+	// Fetch items concurrently through the shared client, keeping list order
+	fetched := make([]*FirebaseItem, len(ids))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Go(func() {
+			item, err := fetchFirebaseItem(client, strconv.Itoa(id))
+			if err != nil {
+				slog.Warn("Failed to fetch Hacker News item from Firebase", "error", err, "hn_id", id)
+				return
+			}
+			fetched[i] = item
+		})
+	}
+	wg.Wait()
+
+	now := time.Now()
+	var items []Item
+	for _, fbItem := range fetched {
+		if fbItem == nil || fbItem.Dead || fbItem.Deleted {
+			continue
+		}
+		items = append(items, firebaseToItem(fbItem, now))
+	}
+
+	slog.Debug("Finished processing items", "totalItems", len(items))
+	return items
+}
+
+// fetchFirebaseItem fetches one item. Firebase answers unknown IDs with a
+// JSON null, returned as a nil item.
+func fetchFirebaseItem(client *api.EnhancedClient, itemID string) (*FirebaseItem, error) {
+	var item *FirebaseItem
+	if err := client.GetAndDecode(firebaseBaseURL+"/item/"+itemID+".json", &item, nil); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+	return item, nil
+}
+
+// firebaseToItem maps a Firebase item to an Item the same way fetchItems maps
+// Algolia hits.
+func firebaseToItem(fbItem *FirebaseItem, now time.Time) Item {
+	itemID := strconv.Itoa(fbItem.ID)
+	return Item{
+		ItemID:           itemID,
+		ItemTitle:        fbItem.Title,
+		ItemLink:         fbItem.URL,
+		ItemCommentsLink: fmt.Sprintf("https://news.ycombinator.com/item?id=%s", itemID),
+		Points:           fbItem.Score,
+		ItemCommentCount: fbItem.Descendants,
+		ItemAuthor:       fbItem.By,
+		ItemCreatedAt:    time.Unix(fbItem.Time, 0).UTC(),
+		UpdatedAt:        now,
+	}
+}
+
+// fetchFirebaseItemStats retrieves current statistics for a single item from
+// the Firebase API. Missing, dead and deleted items are reported dead.
+func fetchFirebaseItemStats(client *api.EnhancedClient, itemID string) statsUpdate {
+	item, err := fetchFirebaseItem(client, itemID)
+	if err != nil {
+		return statsUpdate{itemID: itemID, err: err}
+	}
+	if item == nil || item.Dead || item.Deleted {
+		slog.Debug("Item missing, dead or deleted on Firebase, marking as dead", "hn_id", itemID)
+		return statsUpdate{itemID: itemID, isDeadItem: true}
+	}
+	return statsUpdate{itemID: itemID, points: item.Score, commentCount: item.Descendants}
+}
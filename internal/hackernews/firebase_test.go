@@ -0,0 +1,88 @@
+package hackernews
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/api"
+)
+
+func withFirebaseServer(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	original := firebaseBaseURL
+	firebaseBaseURL = srv.URL
+	t.Cleanup(func() { firebaseBaseURL = original })
+}
+
+func TestFetchFirebaseItemsMapsFields(t *testing.T) {
+	withFirebaseServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/showstories.json":
+			_, _ = w.Write([]byte(`[8863, 1, 2]`))
+		case "/item/8863.json":
+			_, _ = w.Write([]byte(`{"by":"dhouston","descendants":71,"id":8863,"kids":[8952,9224],"score":111,
+				"time":1175714200,"title":"My YC app: Dropbox - Throw away your USB drive","type":"story","url":"http://www.getdropbox.com/u/2/screencast.html"}`))
+		case "/item/1.json":
+			_, _ = w.Write([]byte(`{"id":1,"type":"story","dead":true,"title":"Flagged"}`))
+		default:
+			_, _ = w.Write([]byte(`null`))
+		}
+	})
+
+	items := fetchFirebaseItems(StoryTypeShowHN)
+	if len(items) != 1 {
+		t.Fatalf("fetchFirebaseItems() returned %d items, want 1 (dead and missing items skipped): %+v", len(items), items)
+	}
+	got := items[0]
+	want := Item{
+		ItemID:           "8863",
+		ItemTitle:        "My YC app: Dropbox - Throw away your USB drive",
+		ItemLink:         "http://www.getdropbox.com/u/2/screencast.html",
+		ItemCommentsLink: "https://news.ycombinator.com/item?id=8863",
+		Points:           111,
+		ItemCommentCount: 71,
+		ItemAuthor:       "dhouston",
+		ItemCreatedAt:    time.Date(2007, 4, 4, 19, 16, 40, 0, time.UTC),
+		UpdatedAt:        got.UpdatedAt,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("fetchFirebaseItems()[0] = %+v, want %+v", got, want)
+	}
+}
+
+func TestFetchFirebaseItemStats(t *testing.T) {
+	withFirebaseServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/item/42.json":
+			_, _ = w.Write([]byte(`{"id":42,"score":200,"descendants":30}`))
+		case "/item/43.json":
+			_, _ = w.Write([]byte(`{"id":43,"deleted":true}`))
+		case "/item/44.json":
+			_, _ = w.Write([]byte(`null`))
+		default:
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}
+	})
+	client := api.NewEnhancedClient(&api.EnhancedClientConfig{RateLimiter: api.NewNoOpRateLimiter()})
+
+	if update := fetchFirebaseItemStats(client, "42"); update.err != nil || update.isDeadItem || update.points != 200 || update.commentCount != 30 {
+		t.Errorf("fetchFirebaseItemStats(42) = %+v, want 200 points and 30 comments", update)
+	}
+	for _, id := range []string{"43", "44"} {
+		if update := fetchFirebaseItemStats(client, id); update.err != nil || !update.isDeadItem {
+			t.Errorf("fetchFirebaseItemStats(%s) = %+v, want dead item", id, update)
+		}
+	}
+}
+
+func TestFactoryRejectsUnknownAPI(t *testing.T) {
+	if _, err := factory(&Config{API: "bigquery"}); err == nil {
+		t.Fatal("factory() error = nil, want error for unknown API")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"regexp"
 
+	"github.com/lepinkainen/feed-forge/pkg/api"
 	"github.com/lepinkainen/feed-forge/pkg/feedmeta"
 	"github.com/lepinkainen/feed-forge/pkg/providerfeed"
 	"github.com/lepinkainen/feed-forge/pkg/providers"
@@ -25,19 +26,33 @@ var (
 	}
 )
 
+// defaultLimit is the number of items in a Hacker News feed when no limit is set.
+const defaultLimit = 30
+
 // Provider implements the FeedProvider interface for Hacker News
 type Provider struct {
 	*providers.BaseProvider
 	MinPoints      int
 	Limit          int
+	StoryType      string // Algolia tags filter, see the StoryType constants
+	API            string // APIAlgolia (default) or APIFirebase
+	Comments       bool   // Emit the best comments on top stories instead of the stories
+	ArchiveMode    bool   // Flag dead items instead of deleting them
+	SchemeURL      string // URL template for metadata category schemes, see feedmeta.Config.CategorySchemeURL
 	CategoryMapper *CategoryMapper
 }
 
 // Config holds HackerNews provider configuration for the factory
 type Config struct {
 	providers.GenerateConfig `yaml:",inline"`
-	MinPoints                int `yaml:"min-points"`
-	Limit                    int `yaml:"limit"`
+	MinPoints                int    `yaml:"min-points"`
+	Limit                    int    `yaml:"limit"`
+	StoryType                string `yaml:"story-type"`
+	API                      string `yaml:"hn-api"`
+	Comments                 bool   `yaml:"comments"`
+	ArchiveMode              bool   `yaml:"archive-mode"`
+	CategorySchemeURL        string `yaml:"category-scheme-url"`
+	DomainsConfig            string `yaml:"domains-config"` // Replaces the embedded domains.json
 }
 
 // NewProvider creates a new HackerNews provider
@@ -62,11 +77,24 @@ func NewProvider(minPoints, limit int, categoryMapper *CategoryMapper) (provider
 		Limit:          limit,
 		CategoryMapper: categoryMapper,
 	}
-	provider.SetGenerateFeedFunc(providerfeed.BuildGenerator(provider.FetchItems, previewInfo, nil, provider.OgDB))
+	provider.SetGenerateFeedFunc(providerfeed.BuildGenerator(provider.FetchItems, previewInfo, provider.feedConfig, provider.OgDB))
 
 	return provider, nil
 }
 
+// feedConfig returns the feed metadata, retitled when emitting comments so the
+// comments feed doesn't share the stories feed's ID.
+func (p *Provider) feedConfig() feedmeta.Config {
+	cfg := previewInfo.Config
+	cfg.CategorySchemeURL = p.SchemeURL
+	if p.Comments {
+		cfg.Title = "Hacker News Best Comments"
+		cfg.Description = "Notable comments on top Hacker News stories"
+		cfg.ID = "https://news.ycombinator.com/#comments"
+	}
+	return cfg
+}
+
 // factory creates a HackerNews provider from configuration
 func factory(config any) (providers.FeedProvider, error) {
 	cfg, ok := config.(*Config)
@@ -74,10 +102,22 @@ func factory(config any) (providers.FeedProvider, error) {
 		return nil, fmt.Errorf("invalid config type for hackernews provider: expected *hackernews.Config")
 	}
 
-	provider, err := NewProvider(cfg.MinPoints, cfg.Limit, nil)
+	if cfg.StoryType != "" && !validStoryType(cfg.StoryType) {
+		return nil, fmt.Errorf("invalid hackernews story type %q", cfg.StoryType)
+	}
+	if !validAPI(cfg.API) {
+		return nil, fmt.Errorf("invalid hackernews API %q", cfg.API)
+	}
+
+	provider, err := NewProvider(cfg.MinPoints, cfg.Limit, LoadConfig(cfg.DomainsConfig))
 	if err != nil {
 		return nil, fmt.Errorf("create hackernews provider: %w", err)
 	}
+	provider.(*Provider).StoryType = cfg.StoryType
+	provider.(*Provider).API = cfg.API
+	provider.(*Provider).Comments = cfg.Comments
+	provider.(*Provider).ArchiveMode = cfg.ArchiveMode
+	provider.(*Provider).SchemeURL = cfg.CategorySchemeURL
 
 	return provider, nil
 }
@@ -91,10 +131,11 @@ func init() {
 		ConfigFactory: func() any {
 			return &Config{
 				MinPoints: 50,
-				Limit:     30,
+				Limit:     defaultLimit,
 			}
 		},
-		Preview: previewInfo,
+		Preview:      previewInfo,
+		DefaultLimit: defaultLimit,
 	})
 }
 
@@ -102,8 +143,19 @@ func init() {
 func (p *Provider) FetchItems(limit int) ([]providers.FeedItem, error) {
 	contentDB := p.ContentDB
 
-	// Fetch current front page items
-	newItems := fetchItems()
+	itemLimit := providers.ResolveLimit(limit, p.Limit, defaultLimit)
+
+	if p.Comments {
+		return fetchComments(p.StoryType, p.MinPoints, itemLimit), nil
+	}
+
+	// Fetch current items for the configured story type
+	var newItems []Item
+	if p.API == APIFirebase {
+		newItems = fetchFirebaseItems(p.StoryType)
+	} else {
+		newItems = fetchItems(p.StoryType)
+	}
 
 	// Initialize database schema
 	if err := initializeSchema(contentDB); err != nil {
@@ -113,20 +165,16 @@ func (p *Provider) FetchItems(limit int) ([]providers.FeedItem, error) {
 	// Update database with new items and get list of updated item IDs
 	recentlyUpdated := updateStoredItems(contentDB, newItems)
 
-	// Use provided limit or fall back to provider's default
-	itemLimit := limit
-	if itemLimit == 0 {
-		itemLimit = p.Limit
-	}
-
 	// Get all items from database
 	allItems, err := getAllItems(contentDB, itemLimit, p.MinPoints)
 	if err != nil {
 		return nil, err
 	}
 
-	// Update item stats with current data from Algolia, skipping recently updated items
-	updateItemStats(contentDB.DB(), allItems, recentlyUpdated)
+	// Update item stats with current data from Algolia, skipping recently updated
+	// items. One client serves every worker so its rate limiter is shared.
+	statsClient := api.NewHackerNewsClient().ForProvider("hackernews")
+	updateItemStats(contentDB.DB(), statsClient, itemStatsFetcher(p.API), api.SharedSemaphore(), allItems, recentlyUpdated, p.ArchiveMode)
 
 	// Re-fetch items to get updated stats
 	allItems, err = getAllItems(contentDB, itemLimit, p.MinPoints)
@@ -134,6 +182,9 @@ func (p *Provider) FetchItems(limit int) ([]providers.FeedItem, error) {
 		return nil, err
 	}
 
+	// Remember what this run reports so the next one can show deltas
+	recordReportedStats(contentDB, allItems)
+
 	// Process items to add HackerNews-specific categorization
 	preprocessedItems := preprocessItems(allItems, p.MinPoints, p.CategoryMapper)
 
@@ -155,7 +206,7 @@ func preprocessItems(items []Item, minPoints int, categoryMapper *CategoryMapper
 
 		// Generate HackerNews-specific categories
 		categories := categorizeContent(item.ItemTitle, domain, item.ItemLink, categoryMapper)
-		pointCategory := categorizeByPoints(item.Points, minPoints)
+		pointCategory := categorizeByPoints(item.Points, minPoints, categoryMapper.PointBuckets())
 		categories = append(categories, pointCategory)
 
 		// Populate the item's Domain and Categories fields for the FeedItem interface
@@ -16,8 +16,11 @@ type Item struct {
 	ItemAuthor       string
 	ItemCreatedAt    time.Time
 	UpdatedAt        time.Time
-	Domain           string   // Domain extracted from Link
-	ItemCategories   []string // Categories determined from title, domain, and points
+	FirstSeen        time.Time // When the item was first stored in the database
+	Domain           string    // Domain extracted from Link
+	ItemCategories   []string  // Categories determined from title, domain, and points
+	PointsDelta      int       // Points gained since the previous generation
+	CommentsDelta    int       // Comments gained since the previous generation
 }
 
 // Title returns the title of the Hacker News item
@@ -35,6 +38,14 @@ func (h *Item) CommentsLink() string {
 	return h.ItemCommentsLink
 }
 
+// TagID returns the item's stable ID for tag: URI entry IDs
+func (h *Item) TagID() string {
+	if h.ItemID == "" {
+		return ""
+	}
+	return "item/" + h.ItemID
+}
+
 // Author returns the author of the Hacker News item
 func (h *Item) Author() string {
 	return h.ItemAuthor
@@ -55,6 +66,11 @@ func (h *Item) CreatedAt() time.Time {
 	return h.ItemCreatedAt
 }
 
+// FirstSeenAt returns when feed-forge first stored the item
+func (h *Item) FirstSeenAt() time.Time {
+	return h.FirstSeen
+}
+
 // Categories returns the categories assigned to the item
 func (h *Item) Categories() []string {
 	return h.ItemCategories
@@ -77,6 +93,16 @@ func (h *Item) AuthorURI() string {
 	return fmt.Sprintf("https://news.ycombinator.com/user?id=%s", h.ItemAuthor)
 }
 
+// ScoreDelta returns the change in points since the previous generation
+func (h *Item) ScoreDelta() int {
+	return h.PointsDelta
+}
+
+// CommentDelta returns the change in comment count since the previous generation
+func (h *Item) CommentDelta() int {
+	return h.CommentsDelta
+}
+
 // ItemDomain returns the domain extracted from the item link
 func (h *Item) ItemDomain() string {
 	return h.Domain
@@ -98,6 +124,20 @@ type AlgoliaHit struct {
 	CreatedAt   string `json:"created_at"`
 }
 
+// FirebaseItem represents an item from the official Hacker News Firebase API
+type FirebaseItem struct {
+	ID          int    `json:"id"`
+	Type        string `json:"type"`
+	By          string `json:"by"`
+	Time        int64  `json:"time"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Score       int    `json:"score"`
+	Descendants int    `json:"descendants"`
+	Dead        bool   `json:"dead"`
+	Deleted     bool   `json:"deleted"`
+}
+
 // statsUpdate represents the result of updating an item's statistics
 type statsUpdate struct {
 	itemID       string
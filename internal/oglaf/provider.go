@@ -204,7 +204,7 @@ func NewOglafProvider(feedURL string) (providers.FeedProvider, error) {
 // extractFullComicURL fetches the comic page and finds the actual comic image
 func extractFullComicURL(pageURL string) (string, error) {
 	// Use enhanced HTTP client with proper timeout and retry policy
-	client := api.NewGenericClient()
+	client := api.NewGenericClient().ForProvider("oglaf")
 	resp, err := client.Get(pageURL, nil)
 	if err != nil {
 		return "", err
@@ -327,7 +327,7 @@ func (p *Provider) httpCacheStore() *httpcache.Store {
 
 // fetchRSSFeed fetches and parses the Oglaf RSS feed.
 func (p *Provider) fetchRSSFeed() ([]*RSSItem, error) {
-	client := api.NewGenericClient()
+	client := api.NewGenericClient().ForProvider("oglaf")
 	body, err := httpcache.CachedGet(context.Background(), client, p.httpCacheStore(), p.FeedURL, nil)
 	if err != nil {
 		return nil, err
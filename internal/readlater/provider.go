@@ -0,0 +1,204 @@
+// Package readlater provides a feed of URLs listed in a local file, described
+// by their OpenGraph metadata.
+package readlater
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feed"
+	"github.com/lepinkainen/feed-forge/pkg/feedmeta"
+	"github.com/lepinkainen/feed-forge/pkg/opengraph"
+	"github.com/lepinkainen/feed-forge/pkg/providerfeed"
+	"github.com/lepinkainen/feed-forge/pkg/providers"
+	"github.com/lepinkainen/feed-forge/pkg/urlutils"
+)
+
+var previewInfo = &providers.PreviewInfo{
+	Config: feedmeta.Config{
+		Title:       "Read Later",
+		Link:        "https://github.com/lepinkainen/feed-forge",
+		Description: "Saved links to read later",
+		Author:      "Feed Forge",
+		ID:          "urn:feed-forge:readlater",
+	},
+	ProviderName: "Read Later",
+	TemplateName: "readlater-atom",
+}
+
+// defaultLimit is the number of saved URLs in the feed when no limit is set.
+const defaultLimit = 50
+
+// Provider implements the FeedProvider interface for a read-later URL file
+type Provider struct {
+	*providers.BaseProvider
+	File  string
+	Limit int
+
+	// fetchOG looks up OpenGraph data for the listed URLs.
+	fetchOG func(urls []string) map[string]*opengraph.Data
+}
+
+// Config holds read-later provider configuration for the factory
+type Config struct {
+	providers.GenerateConfig `yaml:",inline"`
+	File                     string `yaml:"file"`
+	Limit                    int    `yaml:"limit"`
+}
+
+// NewProvider creates a new read-later provider reading URLs from file
+func NewProvider(file string, limit int) (providers.FeedProvider, error) {
+	base, err := providers.NewBaseProvider(providers.DatabaseConfig{
+		ContentDBName: "",
+		UseContentDB:  false,
+	})
+	if err != nil {
+		slog.Error("Failed to initialize read-later base provider", "error", err)
+		return nil, fmt.Errorf("initialize readlater base provider: %w", err)
+	}
+
+	provider := &Provider{
+		BaseProvider: base,
+		File:         file,
+		Limit:        limit,
+	}
+	provider.fetchOG = func(urls []string) map[string]*opengraph.Data {
+		return feed.NewOGFetcher(provider.OgDB, previewInfo.Config).FetchConcurrent(urls)
+	}
+	provider.SetGenerateFeedFunc(providerfeed.BuildGenerator(provider.FetchItems, previewInfo, nil, provider.OgDB))
+
+	return provider, nil
+}
+
+// factory creates a read-later provider from configuration
+func factory(config any) (providers.FeedProvider, error) {
+	cfg, ok := config.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("invalid config type for readlater provider: expected *readlater.Config")
+	}
+	if cfg.File == "" {
+		return nil, fmt.Errorf("readlater provider needs a URL file")
+	}
+
+	provider, err := NewProvider(cfg.File, cfg.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("create readlater provider: %w", err)
+	}
+
+	return provider, nil
+}
+
+func init() {
+	providers.MustRegister("readlater", &providers.ProviderInfo{
+		Name:        "readlater",
+		Description: "Generate RSS feeds from a local file of saved URLs",
+		Version:     "1.0.0",
+		Factory:     factory,
+		ConfigFactory: func() any {
+			return &Config{
+				Limit: defaultLimit,
+			}
+		},
+		Preview:      previewInfo,
+		DefaultLimit: defaultLimit,
+	})
+}
+
+// FetchItems implements the FeedProvider interface. URLs appended last are
+// treated as newest and come first.
+func (p *Provider) FetchItems(limit int) ([]providers.FeedItem, error) {
+	urls, modTime, err := readURLFile(p.File)
+	if err != nil {
+		return nil, err
+	}
+	slog.Debug("Read read-later URLs", "file", p.File, "count", len(urls))
+
+	// Newest first
+	for i, j := 0, len(urls)-1; i < j; i, j = i+1, j-1 {
+		urls[i], urls[j] = urls[j], urls[i]
+	}
+
+	itemLimit := providers.ResolveLimit(limit, p.Limit, defaultLimit)
+	if itemLimit > 0 && len(urls) > itemLimit {
+		urls = urls[:itemLimit]
+	}
+
+	ogData := p.fetchOG(urls)
+	items := make([]Item, len(urls))
+	for i, link := range urls {
+		// Entries get a second apart below the file's mtime so their order
+		// survives readers that sort by date.
+		items[i] = newItem(link, ogData[link], modTime.Add(-time.Duration(i)*time.Second))
+	}
+
+	return convertToFeedItems(items), nil
+}
+
+// newItem builds an entry for link from its OpenGraph data, which may be nil.
+func newItem(link string, og *opengraph.Data, addedAt time.Time) Item {
+	item := Item{URL: link, AddedAt: addedAt}
+	if parsed, err := url.Parse(link); err == nil {
+		item.Domain = strings.TrimPrefix(parsed.Hostname(), "www.")
+	}
+	if og != nil {
+		item.PageTitle = og.Title
+		item.Description = og.Description
+		item.Image = og.Image
+		item.SiteName = og.SiteName
+	}
+	return item
+}
+
+// readURLFile returns the unique valid URLs in path, one per line, in file
+// order. Blank lines and lines starting with # are ignored.
+func readURLFile(path string) ([]string, time.Time, error) {
+	// #nosec G304 -- the URL file is an explicit CLI/config input, intentionally read from disk.
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("open read-later file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("stat read-later file: %w", err)
+	}
+
+	var urls []string
+	seen := make(map[string]struct{})
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !urlutils.IsValidURL(line) || (!strings.HasPrefix(line, "http://") && !strings.HasPrefix(line, "https://")) {
+			slog.Warn("Skipping invalid read-later URL", "url", line)
+			continue
+		}
+		if _, ok := seen[line]; ok {
+			continue
+		}
+		seen[line] = struct{}{}
+		urls = append(urls, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, time.Time{}, fmt.Errorf("read read-later file: %w", err)
+	}
+
+	return urls, info.ModTime(), nil
+}
+
+// convertToFeedItems wraps read-later items with the FeedItem interface
+func convertToFeedItems(items []Item) []providers.FeedItem {
+	feedItems := make([]providers.FeedItem, len(items))
+	for i := range items {
+		feedItems[i] = &items[i]
+	}
+	return feedItems
+}
@@ -0,0 +1,112 @@
+package readlater
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lepinkainen/feed-forge/pkg/feed"
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+	"github.com/lepinkainen/feed-forge/pkg/opengraph"
+)
+
+func writeURLFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "readlater.txt")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write url file: %v", err)
+	}
+	return path
+}
+
+func TestReadURLFile(t *testing.T) {
+	path := writeURLFile(t, "# saved links\nhttps://example.com/a\n\n  https://example.com/b  \nnot a url\nftp://example.com/c\nhttps://example.com/a\n")
+
+	urls, modTime, err := readURLFile(path)
+	if err != nil {
+		t.Fatalf("readURLFile() error = %v", err)
+	}
+	want := []string{"https://example.com/a", "https://example.com/b"}
+	if !reflect.DeepEqual(urls, want) {
+		t.Fatalf("readURLFile() = %v, want %v", urls, want)
+	}
+	if modTime.IsZero() {
+		t.Fatal("readURLFile() returned zero mtime")
+	}
+}
+
+func TestFetchItemsBuildsFeedFromOpenGraph(t *testing.T) {
+	path := writeURLFile(t, "https://blog.example/first\nhttps://www.news.example/second\nhttps://untitled.example/third\n")
+
+	var requested []string
+	provider := &Provider{
+		File:  path,
+		Limit: 10,
+		fetchOG: func(urls []string) map[string]*opengraph.Data {
+			requested = urls
+			return map[string]*opengraph.Data{
+				"https://blog.example/first":      {Title: "First Post", Description: "A blog post", SiteName: "Example Blog"},
+				"https://www.news.example/second": {Title: "Second Story", Image: "https://news.example/img.png"},
+			}
+		},
+	}
+
+	items, err := provider.FetchItems(0)
+	if err != nil {
+		t.Fatalf("FetchItems() error = %v", err)
+	}
+
+	// Appended lines are newest, so the file is read bottom-up.
+	wantOrder := []string{"https://untitled.example/third", "https://www.news.example/second", "https://blog.example/first"}
+	if !reflect.DeepEqual(requested, wantOrder) {
+		t.Fatalf("OpenGraph requested %v, want %v", requested, wantOrder)
+	}
+
+	var titles []string
+	for _, item := range items {
+		titles = append(titles, item.Title())
+	}
+	wantTitles := []string{"https://untitled.example/third", "Second Story", "First Post"}
+	if !reflect.DeepEqual(titles, wantTitles) {
+		t.Fatalf("titles = %v, want %v", titles, wantTitles)
+	}
+	if !items[0].CreatedAt().After(items[1].CreatedAt()) {
+		t.Error("newest entry is not dated after the next one")
+	}
+	if items[1].Author() != "news.example" || items[2].Author() != "Example Blog" {
+		t.Errorf("authors = %q, %q", items[1].Author(), items[2].Author())
+	}
+
+	feedItems := make([]feedtypes.FeedItem, len(items))
+	copy(feedItems, items)
+	content, err := feed.GenerateAtomFeedWithEmbeddedTemplate(feedItems, previewInfo.TemplateName, previewInfo.Config, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	for _, want := range []string{
+		"<title>First Post</title>",
+		"<title>Second Story</title>",
+		`<link rel="alternate" type="text/html" href="https://blog.example/first"/>`,
+		"<summary>A blog post</summary>",
+		`<media:thumbnail url="https://news.example/img.png"/>`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("feed missing %q", want)
+		}
+	}
+}
+
+func TestFetchItemsAppliesLimit(t *testing.T) {
+	path := writeURLFile(t, "https://example.com/1\nhttps://example.com/2\nhttps://example.com/3\n")
+	provider := &Provider{File: path, Limit: 2, fetchOG: func([]string) map[string]*opengraph.Data { return nil }}
+
+	items, err := provider.FetchItems(0)
+	if err != nil {
+		t.Fatalf("FetchItems() error = %v", err)
+	}
+	if len(items) != 2 || items[0].Link() != "https://example.com/3" {
+		t.Fatalf("FetchItems() = %d items starting at %q", len(items), items[0].Link())
+	}
+}
@@ -0,0 +1,75 @@
+package readlater
+
+import "time"
+
+// Item is a saved URL, described by the page's OpenGraph metadata.
+type Item struct {
+	URL         string
+	PageTitle   string
+	Description string
+	Image       string
+	SiteName    string
+	Domain      string
+	AddedAt     time.Time
+}
+
+// Title returns the page title, or the URL when the page has none.
+func (i *Item) Title() string {
+	if i.PageTitle != "" {
+		return i.PageTitle
+	}
+	return i.URL
+}
+
+// Link returns the saved URL.
+func (i *Item) Link() string {
+	return i.URL
+}
+
+// CommentsLink returns the saved URL; read-later entries have no discussion.
+func (i *Item) CommentsLink() string {
+	return i.URL
+}
+
+// Author returns the site name, falling back to the domain.
+func (i *Item) Author() string {
+	if i.SiteName != "" {
+		return i.SiteName
+	}
+	return i.Domain
+}
+
+// Score returns 0; saved URLs have no score.
+func (i *Item) Score() int {
+	return 0
+}
+
+// CommentCount returns 0; saved URLs have no comments.
+func (i *Item) CommentCount() int {
+	return 0
+}
+
+// CreatedAt returns when the URL was added, derived from the file's mtime.
+func (i *Item) CreatedAt() time.Time {
+	return i.AddedAt
+}
+
+// Categories returns no categories.
+func (i *Item) Categories() []string {
+	return nil
+}
+
+// ImageURL returns the page's OpenGraph image.
+func (i *Item) ImageURL() string {
+	return i.Image
+}
+
+// Content returns the page's OpenGraph description.
+func (i *Item) Content() string {
+	return i.Description
+}
+
+// ItemDomain returns the host of the saved URL.
+func (i *Item) ItemDomain() string {
+	return i.Domain
+}
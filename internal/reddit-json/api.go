@@ -18,7 +18,7 @@ type RedditAPI struct {
 // If proxySecret is non-empty, it is sent as an X-Proxy-Secret header
 // along with X-Feed-ID and X-Feed-User to avoid leaking credentials in query params.
 func NewRedditAPI(feedURL, proxySecret, feedID, username string) *RedditAPI {
-	enhancedClient := api.NewRedditClient(nil)
+	enhancedClient := api.NewRedditClient(nil).ForProvider("reddit")
 	enhancedClient.SetUserAgent("feed-forge/1.0 (by /u/feedforge)")
 
 	if proxySecret != "" {
@@ -47,15 +47,25 @@ func (r *RedditAPI) FetchRedditHomepage() ([]RedditPost, error) {
 	return listing.Data.Children, nil
 }
 
-// FilterPosts applies score and comment count filters to a list of Reddit posts
-func FilterPosts(posts []RedditPost, minScore, minComments int) []RedditPost {
+// Threshold modes combining the score and comment count filters.
+const (
+	ThresholdAnd = "and" // posts must pass both thresholds
+	ThresholdOr  = "or"  // posts must pass either threshold
+)
+
+// FilterPosts applies score and comment count filters to a list of Reddit
+// posts, requiring both (ThresholdAnd, the default for an empty mode) or
+// either (ThresholdOr) to pass.
+func FilterPosts(posts []RedditPost, minScore, minComments int, mode string) []RedditPost {
 	var filtered []RedditPost
 	for _, post := range posts {
-		if post.Data.Score >= minScore && post.Data.NumComments >= minComments {
+		scoreOK := post.Data.Score >= minScore
+		commentsOK := post.Data.NumComments >= minComments
+		if (mode == ThresholdOr && (scoreOK || commentsOK)) || (scoreOK && commentsOK) {
 			filtered = append(filtered, post)
 		}
 	}
 
-	slog.Debug("Filtered posts", "original", len(posts), "filtered", len(filtered), "minScore", minScore, "minComments", minComments)
+	slog.Debug("Filtered posts", "original", len(posts), "filtered", len(filtered), "minScore", minScore, "minComments", minComments, "mode", mode)
 	return filtered
 }
@@ -119,7 +119,7 @@ func TestRedditProviderFetchItems(t *testing.T) {
 	filesystem.SetCacheDir(cacheDir)
 	t.Cleanup(func() { filesystem.SetCacheDir("") })
 
-	providerAny, err := NewRedditProvider(50, 10, "feed123", "alice", server.URL, "", "")
+	providerAny, err := NewRedditProvider(50, 10, ThresholdAnd, "feed123", "alice", server.URL, "", "")
 	if err != nil {
 		t.Fatalf("NewRedditProvider() error = %v", err)
 	}
@@ -161,7 +161,7 @@ func TestRedditProviderGenerateFeed(t *testing.T) {
 	filesystem.SetCacheDir(t.TempDir())
 	t.Cleanup(func() { filesystem.SetCacheDir("") })
 
-	providerAny, err := NewRedditProvider(50, 10, "feed123", "alice", server.URL, "", "")
+	providerAny, err := NewRedditProvider(50, 10, ThresholdAnd, "feed123", "alice", server.URL, "", "")
 	if err != nil {
 		t.Fatalf("NewRedditProvider() error = %v", err)
 	}
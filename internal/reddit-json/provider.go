@@ -2,7 +2,9 @@ package redditjson
 
 import (
 	"fmt"
+	"os"
 
+	"github.com/lepinkainen/feed-forge/pkg/feed"
 	"github.com/lepinkainen/feed-forge/pkg/feedmeta"
 	"github.com/lepinkainen/feed-forge/pkg/providerfeed"
 	"github.com/lepinkainen/feed-forge/pkg/providers"
@@ -34,6 +36,7 @@ type RedditProvider struct {
 	*providers.BaseProvider
 	MinScore    int
 	MinComments int
+	Threshold   string // ThresholdAnd or ThresholdOr
 	FeedID      string
 	Username    string
 	ProxyURL    string
@@ -44,17 +47,51 @@ type RedditProvider struct {
 // Config holds Reddit provider configuration for the factory
 type Config struct {
 	providers.GenerateConfig `yaml:",inline"`
-	MinScore                 int    `yaml:"min-score"`
-	MinComments              int    `yaml:"min-comments"`
-	FeedID                   string `yaml:"feed-id"`
-	Username                 string `yaml:"username"`
-	ProxyURL                 string `yaml:"proxy-url"`
-	ProxySecret              string `yaml:"proxy-secret"`
-	OGProxyURL               string `yaml:"og-proxy-url"`
+	MinScore                 int               `yaml:"min-score"`
+	MinComments              int               `yaml:"min-comments"`
+	Threshold                string            `yaml:"threshold-mode"`
+	FeedID                   string            `yaml:"feed-id"`
+	Username                 string            `yaml:"username"`
+	ProxyURL                 string            `yaml:"proxy-url"`
+	ProxySecret              string            `yaml:"proxy-secret"`
+	OGProxyURL               string            `yaml:"og-proxy-url"`
+	Presets                  map[string]Preset `yaml:"presets"`
+}
+
+// Preset is a named feed ID/username pair selectable with --preset.
+type Preset struct {
+	FeedID   string `yaml:"feed-id"`
+	Username string `yaml:"username"`
+}
+
+// Environment variables consulted for Reddit credentials left empty in config.
+// The private feed ID and username act as the account credentials for the
+// JSON feed, so they can be kept out of config files entirely.
+const (
+	FeedIDEnv      = "REDDIT_FEED_ID"
+	UsernameEnv    = "REDDIT_USERNAME"
+	ProxySecretEnv = "REDDIT_PROXY_SECRET" // #nosec G101 -- environment variable name, not a credential.
+)
+
+// ApplyEnv fills empty credential fields from FeedIDEnv, UsernameEnv and
+// ProxySecretEnv. Values set in config always win.
+func (c *Config) ApplyEnv() {
+	for _, field := range []struct {
+		value *string
+		env   string
+	}{
+		{&c.FeedID, FeedIDEnv},
+		{&c.Username, UsernameEnv},
+		{&c.ProxySecret, ProxySecretEnv},
+	} {
+		if *field.value == "" {
+			*field.value = os.Getenv(field.env)
+		}
+	}
 }
 
 // NewRedditProvider creates a new Reddit JSON provider
-func NewRedditProvider(minScore, minComments int, feedID, username, proxyURL, proxySecret, ogProxyURL string) (providers.FeedProvider, error) {
+func NewRedditProvider(minScore, minComments int, thresholdMode, feedID, username, proxyURL, proxySecret, ogProxyURL string) (providers.FeedProvider, error) {
 	base, err := providers.NewBaseProvider(providers.DatabaseConfig{
 		ContentDBName: "", // Reddit JSON doesn't use content DB
 		UseContentDB:  false,
@@ -67,6 +104,7 @@ func NewRedditProvider(minScore, minComments int, feedID, username, proxyURL, pr
 		BaseProvider: base,
 		MinScore:     minScore,
 		MinComments:  minComments,
+		Threshold:    thresholdMode,
 		FeedID:       feedID,
 		Username:     username,
 		ProxyURL:     proxyURL,
@@ -84,8 +122,15 @@ func factory(config any) (providers.FeedProvider, error) {
 	if !ok {
 		return nil, fmt.Errorf("invalid config type for reddit provider: expected *redditjson.Config")
 	}
+	cfg.ApplyEnv()
+
+	switch cfg.Threshold {
+	case "", ThresholdAnd, ThresholdOr:
+	default:
+		return nil, fmt.Errorf("invalid threshold-mode %q for reddit provider: expected %q or %q", cfg.Threshold, ThresholdAnd, ThresholdOr)
+	}
 
-	provider, err := NewRedditProvider(cfg.MinScore, cfg.MinComments, cfg.FeedID, cfg.Username, cfg.ProxyURL, cfg.ProxySecret, cfg.OGProxyURL)
+	provider, err := NewRedditProvider(cfg.MinScore, cfg.MinComments, cfg.Threshold, cfg.FeedID, cfg.Username, cfg.ProxyURL, cfg.ProxySecret, cfg.OGProxyURL)
 	if err != nil {
 		return nil, fmt.Errorf("create reddit provider: %w", err)
 	}
@@ -103,6 +148,7 @@ func init() {
 			return &Config{
 				MinScore:    50,
 				MinComments: 10,
+				Threshold:   ThresholdAnd,
 			}
 		},
 		Preview: previewInfo,
@@ -124,12 +170,7 @@ func (p *RedditProvider) FetchItems(limit int) ([]providers.FeedItem, error) {
 	}
 
 	// Filter posts
-	filteredPosts := FilterPosts(posts, p.MinScore, p.MinComments)
-
-	// Apply limit if specified
-	if limit > 0 && limit < len(filteredPosts) {
-		filteredPosts = filteredPosts[:limit]
-	}
+	filteredPosts := FilterPosts(posts, p.MinScore, p.MinComments, p.Threshold)
 
 	// Convert to FeedItem interface
 	feedItems := make([]providers.FeedItem, len(filteredPosts))
@@ -137,6 +178,14 @@ func (p *RedditProvider) FetchItems(limit int) ([]providers.FeedItem, error) {
 		feedItems[i] = &post
 	}
 
+	// The same link is often posted to several subreddits
+	feedItems = feed.MergeDuplicateLinks(feedItems)
+
+	// Apply limit if specified
+	if limit > 0 && limit < len(feedItems) {
+		feedItems = feedItems[:limit]
+	}
+
 	return feedItems, nil
 }
 
@@ -3,9 +3,12 @@ package redditjson
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
 	"github.com/lepinkainen/feed-forge/pkg/filesystem"
 )
 
@@ -93,12 +96,42 @@ func TestFilterPosts(t *testing.T) {
 		}{Title: "drop comments", Score: 100, NumComments: 1}},
 	}
 
-	got := FilterPosts(posts, 50, 10)
+	got := FilterPosts(posts, 50, 10, ThresholdAnd)
 	if len(got) != 1 || got[0].Data.Title != "keep" {
 		t.Fatalf("FilterPosts() = %#v", got)
 	}
 }
 
+func TestFilterPosts_ThresholdMode(t *testing.T) {
+	post := func(title string, score, comments int) RedditPost {
+		p := RedditPost{}
+		p.Data.Title = title
+		p.Data.Score = score
+		p.Data.NumComments = comments
+		return p
+	}
+	posts := []RedditPost{
+		post("score only", 100, 2),
+		post("comments only", 5, 40),
+		post("neither", 5, 2),
+	}
+
+	titles := func(posts []RedditPost) []string {
+		var out []string
+		for _, p := range posts {
+			out = append(out, p.Data.Title)
+		}
+		return out
+	}
+
+	if got := titles(FilterPosts(posts, 50, 10, ThresholdOr)); !slices.Equal(got, []string{"score only", "comments only"}) {
+		t.Errorf("FilterPosts(or) = %v, want posts passing either threshold", got)
+	}
+	if got := titles(FilterPosts(posts, 50, 10, ThresholdAnd)); len(got) != 0 {
+		t.Errorf("FilterPosts(and) = %v, want none", got)
+	}
+}
+
 func TestRedditPostMethods(t *testing.T) {
 	post := RedditPost{}
 	post.Data.Title = "Hello"
@@ -119,6 +152,9 @@ func TestRedditPostMethods(t *testing.T) {
 	if got := post.Categories(); len(got) != 1 || got[0] != "r/golang" {
 		t.Fatalf("Categories() = %v", got)
 	}
+	if got := post.RawCategories(); len(got) != 1 || got[0] != "golang" {
+		t.Fatalf("RawCategories() = %v", got)
+	}
 	if got := post.ImageURL(); got != "https://example.com/preview.jpg" {
 		t.Fatalf("ImageURL() = %q", got)
 	}
@@ -145,3 +181,41 @@ func TestRedditPostImageURL_FallbackToThumbnail(t *testing.T) {
 		t.Fatalf("ImageURL() invalid thumbnail = %q", got)
 	}
 }
+
+func TestConfigApplyEnv(t *testing.T) {
+	t.Setenv(FeedIDEnv, "env-feed")
+	t.Setenv(UsernameEnv, "env-user")
+	t.Setenv(ProxySecretEnv, "env-secret")
+
+	cfg := &Config{}
+	cfg.ApplyEnv()
+	if cfg.FeedID != "env-feed" || cfg.Username != "env-user" || cfg.ProxySecret != "env-secret" {
+		t.Fatalf("ApplyEnv() = %+v, want env values", cfg)
+	}
+
+	cfg = &Config{FeedID: "config-feed", Username: "config-user"}
+	cfg.ApplyEnv()
+	if cfg.FeedID != "config-feed" || cfg.Username != "config-user" {
+		t.Fatalf("ApplyEnv() overrode config values: %+v", cfg)
+	}
+	if cfg.ProxySecret != "env-secret" {
+		t.Fatalf("ApplyEnv() ProxySecret = %q, want env-secret", cfg.ProxySecret)
+	}
+}
+
+func TestRedditPostTagURI(t *testing.T) {
+	post := &RedditPost{}
+	post.Data.URL = "https://example.com/post"
+	post.Data.Permalink = "/r/golang/comments/abc123/hello/"
+	post.Data.CreatedUTC = float64(time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC).Unix())
+
+	got, ok := feedtypes.ItemTagURI(post)
+	if !ok || got != "tag:reddit.com,2024:comments/abc123" {
+		t.Fatalf("ItemTagURI() = %q, %v", got, ok)
+	}
+
+	post.Data.Permalink = "/r/golang/"
+	if id := post.TagID(); id != "" {
+		t.Fatalf("TagID() = %q for permalink without post id, want empty", id)
+	}
+}
@@ -58,6 +58,18 @@ func (r *RedditPost) CommentsLink() string {
 	return "https://www.reddit.com" + r.Data.Permalink
 }
 
+// TagID returns the post's stable ID for tag: URI entry IDs, taken from its
+// permalink (/r/golang/comments/abc123/title/ -> comments/abc123)
+func (r *RedditPost) TagID() string {
+	parts := strings.Split(strings.Trim(r.Data.Permalink, "/"), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] == "comments" && parts[i+1] != "" {
+			return "comments/" + parts[i+1]
+		}
+	}
+	return ""
+}
+
 // Author returns the author of the Reddit post
 func (r *RedditPost) Author() string {
 	return r.Data.Author
@@ -87,6 +99,15 @@ func (r *RedditPost) Categories() []string {
 	return []string{}
 }
 
+// RawCategories returns the subreddit name as Reddit reports it, without the
+// r/ prefix
+func (r *RedditPost) RawCategories() []string {
+	if r.Data.Subreddit != "" {
+		return []string{r.Data.Subreddit}
+	}
+	return []string{}
+}
+
 // ImageURL returns the best available image URL for the post
 func (r *RedditPost) ImageURL() string {
 	// Prefer preview image if available (higher quality)
@@ -25,7 +25,7 @@ var (
 func fetchAtomFeed(feedURL string) ([]atomEntry, error) {
 	slog.Debug("Fetching Tildes Atom feed", "url", feedURL)
 
-	client := api.NewGenericClient()
+	client := api.NewGenericClient().ForProvider("tildes")
 	resp, err := client.Get(feedURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("fetch tildes feed: %w", err)
@@ -28,7 +28,7 @@ const maxStaleAge = 48 * time.Hour
 func fetchAtomFeed(store *httpcache.Store, feedURL string) (*atomFeed, error) {
 	slog.Debug("Fetching YouTube Atom feed", "url", feedURL)
 
-	client := api.NewGenericClient()
+	client := api.NewGenericClient().ForProvider("youtube")
 	headers := map[string]string{"Accept": "application/atom+xml, application/xml;q=0.9, */*;q=0.8"}
 	body, stale, err := httpcache.CachedGetWithStale(context.Background(), client, store, feedURL, headers, maxStaleAge)
 	if err != nil {
@@ -13,7 +13,7 @@ import (
 
 // DiscoverFeedURL fetches a YouTube channel page and returns its advertised RSS feed URL.
 func DiscoverFeedURL(channelPageURL string) (string, error) {
-	client := api.NewGenericClient()
+	client := api.NewGenericClient().ForProvider("youtube")
 	resp, err := client.Get(channelPageURL, map[string]string{
 		"Accept": "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
 	})
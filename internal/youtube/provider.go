@@ -23,6 +23,9 @@ var previewInfo = &providers.PreviewInfo{
 	TemplateName: "youtube-atom",
 }
 
+// defaultLimit is the number of videos in the feed when no limit is set.
+const defaultLimit = 30
+
 // Provider implements providers.FeedProvider for YouTube channel Atom feeds.
 type Provider struct {
 	*providers.BaseProvider
@@ -62,9 +65,10 @@ func init() {
 		Version:     "1.0.0",
 		Factory:     factory,
 		ConfigFactory: func() any {
-			return &Config{Limit: 30}
+			return &Config{Limit: defaultLimit}
 		},
-		Preview: previewInfo,
+		Preview:      previewInfo,
+		DefaultLimit: defaultLimit,
 	})
 }
 
@@ -132,10 +136,7 @@ func (p *Provider) FetchItems(limit int) ([]providers.FeedItem, error) {
 		return items[i].CreatedAt().After(items[j].CreatedAt())
 	})
 
-	itemLimit := p.Limit
-	if limit > 0 {
-		itemLimit = limit
-	}
+	itemLimit := providers.ResolveLimit(limit, p.Limit, defaultLimit)
 	if itemLimit > 0 && itemLimit < len(items) {
 		items = items[:itemLimit]
 	}
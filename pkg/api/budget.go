@@ -0,0 +1,62 @@
+package api
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrBudgetExhausted is returned instead of making a request once the run's
+// request budget is spent.
+var ErrBudgetExhausted = errors.New("request budget exhausted")
+
+// RequestBudget caps the outbound requests made in one run. It is safe for
+// concurrent use; a nil budget is unlimited.
+type RequestBudget struct {
+	max  int64
+	used atomic.Int64
+}
+
+// NewRequestBudget returns a budget allowing max requests. max <= 0 returns
+// nil, an unlimited budget.
+func NewRequestBudget(max int) *RequestBudget {
+	if max <= 0 {
+		return nil
+	}
+	return &RequestBudget{max: int64(max)}
+}
+
+// Spend takes one request from the budget, returning ErrBudgetExhausted when
+// none are left.
+func (b *RequestBudget) Spend() error {
+	if b == nil {
+		return nil
+	}
+	if b.used.Add(1) > b.max {
+		return ErrBudgetExhausted
+	}
+	return nil
+}
+
+// Remaining returns how many requests are left, or -1 for an unlimited budget.
+func (b *RequestBudget) Remaining() int {
+	if b == nil {
+		return -1
+	}
+	return int(max(b.max-b.used.Load(), 0))
+}
+
+// requestBudget is the run-wide budget shared by every client and the
+// OpenGraph fetcher.
+var requestBudget atomic.Pointer[RequestBudget]
+
+// SetRequestBudget limits the run to max outbound requests across all
+// providers and OpenGraph fetches. max <= 0 removes the limit.
+func SetRequestBudget(max int) {
+	requestBudget.Store(NewRequestBudget(max))
+}
+
+// SpendRequest takes one request from the run-wide budget. Callers making
+// requests outside EnhancedClient use it before each request.
+func SpendRequest() error {
+	return requestBudget.Load().Spend()
+}
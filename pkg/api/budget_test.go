@@ -0,0 +1,68 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequestBudget_GetFailsFastOnceSpent(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	SetRequestBudget(2)
+	t.Cleanup(func() { SetRequestBudget(0) })
+
+	client := NewGenericClient()
+	for i := range 2 {
+		var target map[string]any
+		if err := client.GetAndDecode(server.URL, &target, nil); err != nil {
+			t.Fatalf("request %d error = %v", i+1, err)
+		}
+	}
+
+	start := time.Now()
+	resp, err := client.Get(server.URL, nil)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("Get() after budget error = %v, want ErrBudgetExhausted", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("exhausted Get() took %s, want it to fail without retrying", elapsed)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("server hits = %d, want 2", got)
+	}
+}
+
+func TestRequestBudget_NilIsUnlimited(t *testing.T) {
+	budget := NewRequestBudget(0)
+	for range 100 {
+		if err := budget.Spend(); err != nil {
+			t.Fatalf("Spend() on unlimited budget error = %v", err)
+		}
+	}
+	if got := budget.Remaining(); got != -1 {
+		t.Errorf("Remaining() = %d, want -1", got)
+	}
+
+	budget = NewRequestBudget(1)
+	if err := budget.Spend(); err != nil {
+		t.Fatalf("first Spend() error = %v", err)
+	}
+	if err := budget.Spend(); !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("second Spend() error = %v, want ErrBudgetExhausted", err)
+	}
+	if got := budget.Remaining(); got != 0 {
+		t.Errorf("Remaining() = %d, want 0", got)
+	}
+}
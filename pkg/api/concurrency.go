@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Semaphore caps how many requests are in flight at once. A nil Semaphore
+// never blocks.
+type Semaphore chan struct{}
+
+// NewSemaphore returns a Semaphore admitting n concurrent holders, or nil for
+// n <= 0.
+func NewSemaphore(n int) Semaphore {
+	if n <= 0 {
+		return nil
+	}
+	return make(Semaphore, n)
+}
+
+// Acquire blocks until a slot is free or ctx is done.
+func (s Semaphore) Acquire(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees a slot taken by Acquire.
+func (s Semaphore) Release() {
+	if s != nil {
+		<-s
+	}
+}
+
+// maxInFlight is the run-wide cap shared by provider stats refreshes and the
+// OpenGraph fetcher.
+var maxInFlight atomic.Pointer[Semaphore]
+
+// SetMaxInFlight caps the outbound requests in flight at once across stats
+// refreshes and OpenGraph fetches. n <= 0 removes the cap.
+func SetMaxInFlight(n int) {
+	s := NewSemaphore(n)
+	maxInFlight.Store(&s)
+}
+
+// SharedSemaphore returns the run-wide Semaphore set by SetMaxInFlight, nil
+// when there is no cap.
+func SharedSemaphore() Semaphore {
+	if s := maxInFlight.Load(); s != nil {
+		return *s
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSemaphore_NilNeverBlocks(t *testing.T) {
+	var s Semaphore
+	for range 3 {
+		if err := s.Acquire(context.Background()); err != nil {
+			t.Fatalf("nil Acquire() error = %v", err)
+		}
+	}
+	s.Release()
+	if NewSemaphore(0) != nil {
+		t.Fatal("NewSemaphore(0) != nil, want no cap")
+	}
+}
+
+func TestSemaphore_AcquireHonorsContext(t *testing.T) {
+	s := NewSemaphore(1)
+	if err := s.Acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := s.Acquire(ctx); err == nil {
+		t.Fatal("Acquire() on a full semaphore error = nil, want context error")
+	}
+	s.Release()
+	if err := s.Acquire(context.Background()); err != nil {
+		t.Fatalf("Acquire() after Release error = %v", err)
+	}
+}
+
+func TestSetMaxInFlight(t *testing.T) {
+	t.Cleanup(func() { SetMaxInFlight(0) })
+
+	SetMaxInFlight(4)
+	if got := cap(SharedSemaphore()); got != 4 {
+		t.Fatalf("cap(SharedSemaphore()) = %d, want 4", got)
+	}
+	SetMaxInFlight(0)
+	if SharedSemaphore() != nil {
+		t.Fatal("SharedSemaphore() != nil after removing the cap")
+	}
+}
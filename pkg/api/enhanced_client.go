@@ -82,6 +82,9 @@ func (ec *EnhancedClient) GetAndDecodeWithContext(ctx context.Context, url strin
 		if err := ec.rateLimiter.WaitContext(ctx); err != nil {
 			return fmt.Errorf("rate limiter wait: %w", err)
 		}
+		if err := SpendRequest(); err != nil {
+			return err
+		}
 
 		req, err := http.NewRequestWithContext(ctx, "GET", url, http.NoBody)
 		if err != nil {
@@ -107,7 +110,7 @@ func (ec *EnhancedClient) GetAndDecodeWithContext(ctx context.Context, url strin
 
 		if err := json.NewDecoder(res.Body).Decode(target); err != nil {
 			ec.logAPICall(url, duration, false, err)
-			return fmt.Errorf("failed to decode json response: %w", err)
+			return &DecodeError{Err: err}
 		}
 
 		ec.logAPICall(url, duration, true, nil)
@@ -130,6 +133,9 @@ func (ec *EnhancedClient) GetWithContext(ctx context.Context, url string, additi
 		if err := ec.rateLimiter.WaitContext(ctx); err != nil {
 			return fmt.Errorf("rate limiter wait: %w", err)
 		}
+		if err := SpendRequest(); err != nil {
+			return err
+		}
 
 		req, err := http.NewRequestWithContext(ctx, "GET", url, http.NoBody)
 		if err != nil {
@@ -175,6 +181,9 @@ func (ec *EnhancedClient) GetConditional(ctx context.Context, url string, prev C
 		if err := ec.rateLimiter.WaitContext(ctx); err != nil {
 			return fmt.Errorf("rate limiter wait: %w", err)
 		}
+		if err := SpendRequest(); err != nil {
+			return err
+		}
 
 		req, err := http.NewRequestWithContext(ctx, "GET", url, http.NoBody)
 		if err != nil {
@@ -353,10 +362,13 @@ func NewRedditClient(baseClient *http.Client) *EnhancedClient {
 
 // NewHackerNewsClient creates an enhanced client configured for Hacker News API
 func NewHackerNewsClient() *EnhancedClient {
+	retryPolicy := ConservativeRetryPolicy()
+	retryPolicy.RetryOnDecodeError = true // Algolia occasionally truncates 200 responses
+
 	return NewEnhancedClient(&EnhancedClientConfig{
 		BaseClient:  &http.Client{Timeout: 30 * time.Second},
 		RateLimiter: NewSimpleRateLimiter(500 * time.Millisecond), // Conservative rate limit
-		RetryPolicy: ConservativeRetryPolicy(),
+		RetryPolicy: retryPolicy,
 		UserAgent:   "FeedForge/1.0",
 		DefaultHeaders: map[string]string{
 			"Accept": "application/json",
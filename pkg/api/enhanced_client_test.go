@@ -2,8 +2,10 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -351,3 +353,100 @@ func TestEnhancedClient_GetConditional(t *testing.T) {
 		t.Fatalf("retry hits = %d, want 2", got)
 	}
 }
+
+func TestEnhancedClient_GetAndDecodeRetriesTruncatedJSON(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if calls.Add(1) == 1 {
+			_, _ = w.Write([]byte(`{"hits": [{"title": "trunc`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"hits": [{"title": "complete"}]}`))
+	}))
+	defer server.Close()
+
+	newClient := func(retryOnDecode bool) *EnhancedClient {
+		return NewEnhancedClient(&EnhancedClientConfig{
+			RetryPolicy: &RetryPolicy{
+				MaxAttempts:        2,
+				InitialBackoff:     time.Millisecond,
+				MaxBackoff:         time.Millisecond,
+				BackoffMultiplier:  1,
+				RetryOnDecodeError: retryOnDecode,
+			},
+		})
+	}
+
+	var result struct {
+		Hits []struct {
+			Title string `json:"title"`
+		} `json:"hits"`
+	}
+	if err := newClient(true).GetAndDecode(server.URL, &result, nil); err != nil {
+		t.Fatalf("GetAndDecode() error = %v", err)
+	}
+	if calls.Load() != 2 || len(result.Hits) != 1 || result.Hits[0].Title != "complete" {
+		t.Fatalf("calls = %d, result = %+v, want recovery on second attempt", calls.Load(), result)
+	}
+
+	calls.Store(0)
+	err := newClient(false).GetAndDecode(server.URL, &result, nil)
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("GetAndDecode() error = %v, want DecodeError", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("calls = %d, want no retry without RetryOnDecodeError", calls.Load())
+	}
+}
+
+func TestEnhancedClient_ForProviderSendsConfiguredHeaders(t *testing.T) {
+	var gotKey, gotAccept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.Header.Get("X-Api-Key")
+		gotAccept = r.Header.Get("Accept")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	SetProviderHeaders(map[string]map[string]string{
+		"lemmy": {"X-Api-Key": "s3cret"},
+		"other": {"X-Api-Key": "wrong"},
+	})
+	t.Cleanup(func() { SetProviderHeaders(nil) })
+
+	var target map[string]any
+	if err := NewHackerNewsClient().ForProvider("lemmy").GetAndDecode(server.URL, &target, nil); err != nil {
+		t.Fatalf("GetAndDecode() error = %v", err)
+	}
+	if gotKey != "s3cret" {
+		t.Errorf("X-Api-Key = %q, want %q", gotKey, "s3cret")
+	}
+	if gotAccept != "application/json" {
+		t.Errorf("Accept = %q, want the client's own default kept", gotAccept)
+	}
+
+	if err := NewGenericClient().ForProvider("unconfigured").GetAndDecode(server.URL, &target, nil); err != nil {
+		t.Fatalf("GetAndDecode() error = %v", err)
+	}
+	if gotKey != "" {
+		t.Errorf("unconfigured provider sent X-Api-Key = %q", gotKey)
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	got := RedactHeaders(map[string]string{
+		"authorization": "Bearer abc",
+		"X-Api-Key":     "abc",
+		"Accept":        "application/json",
+	})
+	want := map[string]string{
+		"Authorization": "REDACTED",
+		"X-Api-Key":     "REDACTED",
+		"Accept":        "application/json",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RedactHeaders() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// providerHeaders holds the extra request headers configured per provider in
+// the headers: config section. Set via SetProviderHeaders before any provider
+// is created.
+var providerHeaders map[string]map[string]string
+
+// sensitiveHeaderWords mark header names whose values are redacted in logs.
+var sensitiveHeaderWords = []string{"auth", "token", "key", "secret", "cookie", "session", "password"}
+
+// SetProviderHeaders configures the extra headers sent by each provider's
+// clients, keyed by provider name and then header name.
+func SetProviderHeaders(headers map[string]map[string]string) {
+	providerHeaders = headers
+}
+
+// ForProvider merges the headers configured for provider into the client's
+// default headers and returns the client.
+func (ec *EnhancedClient) ForProvider(provider string) *EnhancedClient {
+	headers := providerHeaders[provider]
+	if len(headers) == 0 {
+		return ec
+	}
+	for key, value := range headers {
+		ec.SetDefaultHeader(key, value)
+	}
+	slog.Debug("Applying configured request headers", "provider", provider, "headers", RedactHeaders(headers))
+	return ec
+}
+
+// RedactHeaders returns a copy of headers safe to log: values of headers
+// whose names look like credentials are replaced with "REDACTED".
+func RedactHeaders(headers map[string]string) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for key, value := range headers {
+		if isSensitiveHeader(key) {
+			value = "REDACTED"
+		}
+		redacted[http.CanonicalHeaderKey(key)] = value
+	}
+	return redacted
+}
+
+func isSensitiveHeader(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range sensitiveHeaderWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
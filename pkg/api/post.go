@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// PostForm performs an application/x-www-form-urlencoded POST with rate
+// limiting and retries. Any 2xx response counts as success and its body is
+// discarded.
+func (ec *EnhancedClient) PostForm(ctx context.Context, endpoint string, form url.Values, additionalHeaders map[string]string) error {
+	body := form.Encode()
+
+	operation := func() error {
+		if err := ec.rateLimiter.WaitContext(ctx); err != nil {
+			return fmt.Errorf("rate limiter wait: %w", err)
+		}
+		if err := SpendRequest(); err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		ec.applyHeaders(req, additionalHeaders)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		start := time.Now()
+		res, err := ec.client.Do(req)
+		duration := time.Since(start)
+		if err != nil {
+			ec.logAPICall(endpoint, duration, false, err)
+			return fmt.Errorf("failed to perform POST request: %w", err)
+		}
+		defer func() { _ = res.Body.Close() }()
+		_, _ = io.Copy(io.Discard, res.Body)
+
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			err := fmt.Errorf("unexpected status code: %d %s", res.StatusCode, res.Status)
+			ec.logAPICall(endpoint, duration, false, err)
+			return &HTTPError{StatusCode: res.StatusCode, Message: err.Error(), Err: err}
+		}
+
+		ec.logAPICall(endpoint, duration, true, nil)
+		return nil
+	}
+
+	return ExecuteWithRetryContext(ctx, operation, ec.retryPolicy, fmt.Sprintf("POST %s", endpoint))
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"math"
+	"math/rand/v2"
+	"net"
 	"net/http"
 	"slices"
+	"syscall"
 	"time"
 )
 
@@ -18,38 +22,61 @@ type RetryPolicy struct {
 	MaxBackoff        time.Duration
 	BackoffMultiplier float64
 	RetryableErrors   []int // HTTP status codes that should trigger retries
+
+	// RetryOnDecodeError also retries 2xx responses whose JSON body failed to
+	// decode, which usually means the upstream truncated the response.
+	RetryOnDecodeError bool
+
+	// RetryTransportErrors also retries requests that failed below HTTP:
+	// network timeouts, connection resets and bodies cut off with an
+	// unexpected EOF. The built-in policies enable it.
+	RetryTransportErrors bool
+
+	// Jitter randomizes each backoff by up to this fraction in either
+	// direction (0.2 gives 80%-120%) so clients failing together do not
+	// retry in lockstep. Zero, as in the built-in policies, disables it.
+	Jitter float64
+
+	// Rand is the jitter source. Nil uses the randomly seeded global source;
+	// tests set a fixed seed for reproducible backoffs. A *rand.Rand is not
+	// safe for concurrent use, so share such a policy only between callers
+	// that do not retry at the same time.
+	Rand *rand.Rand
 }
 
 // DefaultRetryPolicy returns a sensible default retry policy
 func DefaultRetryPolicy() *RetryPolicy {
 	return &RetryPolicy{
-		MaxAttempts:       3,
-		InitialBackoff:    1 * time.Second,
-		MaxBackoff:        30 * time.Second,
-		BackoffMultiplier: 2.0,
-		RetryableErrors:   []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		MaxAttempts:          3,
+		InitialBackoff:       1 * time.Second,
+		MaxBackoff:           30 * time.Second,
+		BackoffMultiplier:    2.0,
+		RetryableErrors:      []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		RetryTransportErrors: true,
 	}
 }
 
 // AggressiveRetryPolicy returns a retry policy with more aggressive retries
 func AggressiveRetryPolicy() *RetryPolicy {
 	return &RetryPolicy{
-		MaxAttempts:       5,
-		InitialBackoff:    500 * time.Millisecond,
-		MaxBackoff:        60 * time.Second,
-		BackoffMultiplier: 2.0,
-		RetryableErrors:   []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		MaxAttempts:          5,
+		InitialBackoff:       500 * time.Millisecond,
+		MaxBackoff:           60 * time.Second,
+		BackoffMultiplier:    2.0,
+		RetryableErrors:      []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		RetryTransportErrors: true,
 	}
 }
 
 // ConservativeRetryPolicy returns a retry policy with minimal retries
 func ConservativeRetryPolicy() *RetryPolicy {
 	return &RetryPolicy{
-		MaxAttempts:       2,
-		InitialBackoff:    2 * time.Second,
-		MaxBackoff:        10 * time.Second,
-		BackoffMultiplier: 2.0,
-		RetryableErrors:   []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		MaxAttempts:          2,
+		InitialBackoff:       2 * time.Second,
+		MaxBackoff:           10 * time.Second,
+		BackoffMultiplier:    2.0,
+		RetryableErrors:      []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		RetryTransportErrors: true,
 	}
 }
 
@@ -60,6 +87,9 @@ func (rp *RetryPolicy) CalculateBackoff(attempt int) time.Duration {
 	}
 
 	backoff := float64(rp.InitialBackoff) * math.Pow(rp.BackoffMultiplier, float64(attempt-1))
+	if rp.Jitter > 0 {
+		backoff *= 1 + rp.Jitter*(2*rp.randFloat64()-1)
+	}
 	if backoff > float64(rp.MaxBackoff) {
 		backoff = float64(rp.MaxBackoff)
 	}
@@ -67,6 +97,13 @@ func (rp *RetryPolicy) CalculateBackoff(attempt int) time.Duration {
 	return time.Duration(backoff)
 }
 
+func (rp *RetryPolicy) randFloat64() float64 {
+	if rp.Rand != nil {
+		return rp.Rand.Float64()
+	}
+	return rand.Float64()
+}
+
 func asHTTPError(err error) (*HTTPError, bool) {
 	var httpErr *HTTPError
 	if !errors.As(err, &httpErr) {
@@ -80,7 +117,29 @@ func (rp *RetryPolicy) IsRetryableError(err error) bool {
 	if httpErr, ok := asHTTPError(err); ok {
 		return rp.isRetryableStatusCode(httpErr.StatusCode)
 	}
-	return false
+	var decodeErr *DecodeError
+	if errors.As(err, &decodeErr) {
+		return rp.RetryOnDecodeError
+	}
+	return rp.RetryTransportErrors && isTransientTransportError(err)
+}
+
+// isTransientTransportError reports whether err is a network failure worth
+// retrying: a timeout, a reset or aborted connection, a broken pipe, or a
+// response cut off mid-body.
+func isTransientTransportError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ETIMEDOUT) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
 }
 
 // IsTransientUpstreamError reports whether the error is an HTTP 4xx/5xx from
@@ -127,6 +186,21 @@ func (e *HTTPError) Unwrap() error {
 	return e.Err
 }
 
+// DecodeError reports a successful HTTP response whose body could not be decoded
+type DecodeError struct {
+	Err error
+}
+
+// Error implements the error interface
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("failed to decode json response: %v", e.Err)
+}
+
+// Unwrap returns the wrapped error
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
 // RetryableOperation represents an operation that can be retried
 type RetryableOperation func() error
 
@@ -3,8 +3,15 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
 	"slices"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -54,6 +61,20 @@ func TestRetryPolicy_CalculateBackoff(t *testing.T) {
 	}
 }
 
+func TestRetryPolicy_CalculateBackoff_SeededJitter(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	policy.Jitter = 0.5
+	policy.Rand = rand.New(rand.NewPCG(1, 2))
+
+	// 1s, 2s, 4s, 8s, 16s, 32s scaled by 50%-150%, then capped at 30s
+	want := []time.Duration{1176455659, 1922772435, 4034189590, 7438341948, 20764837590, 28427727537}
+	for i, w := range want {
+		if got := policy.CalculateBackoff(i + 1); got != w {
+			t.Errorf("CalculateBackoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
 func TestRetryPolicy_IsRetryableError(t *testing.T) {
 	policy := DefaultRetryPolicy()
 
@@ -451,3 +472,72 @@ func BenchmarkExecuteWithRetry_NonRetryableError(b *testing.B) {
 		ExecuteWithRetry(operation, policy, "benchmark")
 	}
 }
+
+// timeoutError is a synthetic net.Error reporting a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func TestRetryPolicy_IsRetryableError_TransportErrors(t *testing.T) {
+	resetErr := &url.Error{Op: "Get", URL: "https://example.com", Err: &net.OpError{
+		Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET),
+	}}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"net.Error timeout", fmt.Errorf("fetch: %w", &url.Error{Op: "Get", URL: "https://example.com", Err: timeoutError{}}), true},
+		{"connection reset", resetErr, true},
+		{"unexpected EOF", fmt.Errorf("read body: %w", io.ErrUnexpectedEOF), true},
+		{"cancelled", fmt.Errorf("fetch: %w", context.Canceled), false},
+		{"generic error", errors.New("boom"), false},
+		{"decode error of an empty body", &DecodeError{Err: io.EOF}, false},
+	}
+
+	policy := DefaultRetryPolicy()
+	for _, tt := range tests {
+		if got := policy.IsRetryableError(tt.err); got != tt.want {
+			t.Errorf("%s: IsRetryableError() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	policy.RetryTransportErrors = false
+	if policy.IsRetryableError(resetErr) {
+		t.Error("IsRetryableError(reset) = true with RetryTransportErrors disabled")
+	}
+}
+
+func TestExecuteWithRetry_RetriesTransportErrors(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts:          3,
+		InitialBackoff:       time.Millisecond,
+		MaxBackoff:           time.Millisecond,
+		BackoffMultiplier:    1,
+		RetryTransportErrors: true,
+	}
+
+	for name, transient := range map[string]error{
+		"timeout": timeoutError{},
+		"reset":   os.NewSyscallError("read", syscall.ECONNRESET),
+	} {
+		attempts := 0
+		err := ExecuteWithRetry(func() error {
+			attempts++
+			if attempts < 3 {
+				return fmt.Errorf("GET: %w", transient)
+			}
+			return nil
+		}, policy, name)
+		if err != nil {
+			t.Errorf("%s: ExecuteWithRetry() error = %v", name, err)
+		}
+		if attempts != 3 {
+			t.Errorf("%s: attempts = %d, want 3", name, attempts)
+		}
+	}
+}
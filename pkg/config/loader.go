@@ -78,8 +78,14 @@ func LoadFromURLWithFallback(config *LoaderConfig, target any) error {
 
 // loadFromURL loads configuration from a remote URL using the shared API client
 func loadFromURL(url string, timeout time.Duration, target any) error {
+	// timeout bounds the whole fetch before falling back to the local file,
+	// so a timed-out or dropped connection is not retried.
+	retryPolicy := api.DefaultRetryPolicy()
+	retryPolicy.RetryTransportErrors = false
+
 	client := api.NewEnhancedClient(&api.EnhancedClientConfig{
-		BaseClient: &http.Client{Timeout: timeout},
+		BaseClient:  &http.Client{Timeout: timeout},
+		RetryPolicy: retryPolicy,
 	})
 
 	if err := client.GetAndDecode(url, target, nil); err != nil {
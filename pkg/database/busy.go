@@ -0,0 +1,61 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// ErrDatabaseBusy is returned when an operation keeps failing with
+// SQLITE_BUSY after all retries.
+var ErrDatabaseBusy = errors.New("database is locked by another connection")
+
+// SQLite primary result codes for a locked database.
+const (
+	sqliteBusy   = 5
+	sqliteLocked = 6
+)
+
+// Retry policy for busy errors. Variables so tests can shorten the delay.
+var (
+	busyRetryAttempts = 4
+	busyRetryDelay    = 100 * time.Millisecond
+)
+
+// IsBusy reports whether err is SQLite's "database is locked" (SQLITE_BUSY
+// or SQLITE_LOCKED, including their extended codes).
+func IsBusy(err error) bool {
+	if err == nil {
+		return false
+	}
+	var coded interface{ Code() int }
+	if errors.As(err, &coded) {
+		code := coded.Code() & 0xff
+		return code == sqliteBusy || code == sqliteLocked
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "database is locked") || strings.Contains(msg, "SQLITE_BUSY")
+}
+
+// RetryOnBusy runs fn, retrying with a doubling backoff while it fails with a
+// busy error. Other errors are returned immediately. When the retries run out
+// the error wraps both ErrDatabaseBusy and the last SQLite error. fn must be
+// safe to run again after a failed attempt.
+func RetryOnBusy(op string, fn func() error) error {
+	delay := busyRetryDelay
+	var err error
+	for attempt := 1; attempt <= busyRetryAttempts; attempt++ {
+		err = fn()
+		if !IsBusy(err) {
+			return err
+		}
+		if attempt < busyRetryAttempts {
+			slog.Debug("Database busy, retrying", "operation", op, "attempt", attempt, "delay", delay)
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+	return fmt.Errorf("%s: %w after %d attempts (is another feed-forge run using the same cache?): %w", op, ErrDatabaseBusy, busyRetryAttempts, err)
+}
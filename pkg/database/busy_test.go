@@ -0,0 +1,118 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// codedError mimics the driver's *sqlite.Error.
+type codedError struct {
+	code int
+}
+
+func (e *codedError) Error() string { return fmt.Sprintf("sqlite error %d", e.code) }
+func (e *codedError) Code() int     { return e.code }
+
+func withFastBusyRetry(t *testing.T) {
+	t.Helper()
+	oldAttempts, oldDelay := busyRetryAttempts, busyRetryDelay
+	busyRetryAttempts, busyRetryDelay = 3, time.Millisecond
+	t.Cleanup(func() { busyRetryAttempts, busyRetryDelay = oldAttempts, oldDelay })
+}
+
+func TestIsBusy(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: nil, want: false},
+		{err: &codedError{code: sqliteBusy}, want: true},
+		{err: fmt.Errorf("wrapped: %w", &codedError{code: sqliteBusy | 2<<8}), want: true},
+		{err: &codedError{code: sqliteLocked}, want: true},
+		{err: &codedError{code: 19}, want: false},
+		{err: errors.New("database is locked (5) (SQLITE_BUSY)"), want: true},
+		{err: errors.New("no such table: cache"), want: false},
+	}
+	for _, tt := range tests {
+		if got := IsBusy(tt.err); got != tt.want {
+			t.Errorf("IsBusy(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestRetryOnBusy_RetriesUntilSuccess(t *testing.T) {
+	withFastBusyRetry(t)
+
+	calls := 0
+	err := RetryOnBusy("test op", func() error {
+		calls++
+		if calls < 3 {
+			return &codedError{code: sqliteBusy}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RetryOnBusy() error = %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("fn called %d times, want 3", calls)
+	}
+}
+
+func TestRetryOnBusy_GivesUpWithClearError(t *testing.T) {
+	withFastBusyRetry(t)
+
+	busy := &codedError{code: sqliteBusy}
+	calls := 0
+	err := RetryOnBusy("set cache value", func() error {
+		calls++
+		return busy
+	})
+	if calls != 3 {
+		t.Fatalf("fn called %d times, want 3", calls)
+	}
+	if !errors.Is(err, ErrDatabaseBusy) || !errors.Is(err, busy) {
+		t.Fatalf("RetryOnBusy() error = %v, want ErrDatabaseBusy wrapping the driver error", err)
+	}
+}
+
+func TestRetryOnBusy_DoesNotRetryOtherErrors(t *testing.T) {
+	withFastBusyRetry(t)
+
+	want := errors.New("constraint failed")
+	calls := 0
+	err := RetryOnBusy("test op", func() error {
+		calls++
+		return want
+	})
+	if !errors.Is(err, want) || calls != 1 {
+		t.Fatalf("RetryOnBusy() = %v after %d calls, want %v after 1", err, calls, want)
+	}
+}
+
+func TestTransaction_RetriesWhenBusy(t *testing.T) {
+	withFastBusyRetry(t)
+	db := newTestDatabase(t)
+
+	calls := 0
+	err := db.Transaction(func(tx *sql.Tx) error {
+		calls++
+		if calls == 1 {
+			return &codedError{code: sqliteBusy}
+		}
+		_, err := tx.Exec(`CREATE TABLE retried (id INTEGER)`)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Transaction() error = %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("transaction fn called %d times, want 2", calls)
+	}
+	if _, err := db.DB().Exec(`INSERT INTO retried (id) VALUES (1)`); err != nil {
+		t.Fatalf("retried transaction did not commit: %v", err)
+	}
+}
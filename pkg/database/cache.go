@@ -59,7 +59,9 @@ func (c *Cache) Get(key string) (value string, found bool, err error) {
 		WHERE key = ? AND expires_at > CURRENT_TIMESTAMP
 	`, c.tableName)
 
-	err = c.db.DB().QueryRow(query, key).Scan(&value)
+	err = RetryOnBusy("get cache value", func() error {
+		return c.db.DB().QueryRow(query, key).Scan(&value)
+	})
 	if errors.Is(err, sql.ErrNoRows) {
 		return "", false, nil
 	}
@@ -79,7 +81,10 @@ func (c *Cache) Set(key, value string, ttl time.Duration) error {
 		VALUES (?, ?, ?, CURRENT_TIMESTAMP)
 	`, c.tableName)
 
-	_, err := c.db.DB().Exec(query, key, value, expiresAt)
+	err := RetryOnBusy("set cache value", func() error {
+		_, err := c.db.DB().Exec(query, key, value, expiresAt)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to set cache value: %w", err)
 	}
@@ -91,7 +96,10 @@ func (c *Cache) Set(key, value string, ttl time.Duration) error {
 func (c *Cache) Delete(key string) error {
 	query := fmt.Sprintf(`DELETE FROM %s WHERE key = ?`, c.tableName)
 
-	_, err := c.db.DB().Exec(query, key)
+	err := RetryOnBusy("delete cache value", func() error {
+		_, err := c.db.DB().Exec(query, key)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to delete cache value: %w", err)
 	}
@@ -103,7 +111,12 @@ func (c *Cache) Delete(key string) error {
 func (c *Cache) CleanupExpired() error {
 	query := fmt.Sprintf(`DELETE FROM %s WHERE expires_at < CURRENT_TIMESTAMP`, c.tableName)
 
-	result, err := c.db.DB().Exec(query)
+	var result sql.Result
+	err := RetryOnBusy("cleanup expired cache entries", func() error {
+		var err error
+		result, err = c.db.DB().Exec(query)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to cleanup expired entries: %w", err)
 	}
@@ -151,7 +164,10 @@ func (c *Cache) GetStats() (map[string]any, error) {
 func (c *Cache) Clear() error {
 	query := fmt.Sprintf(`DELETE FROM %s`, c.tableName)
 
-	_, err := c.db.DB().Exec(query)
+	err := RetryOnBusy("clear cache", func() error {
+		_, err := c.db.DB().Exec(query)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to clear cache: %w", err)
 	}
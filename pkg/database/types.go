@@ -152,15 +152,25 @@ func (db *Database) ExecuteSchema(schema string) error {
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
-	_, err := db.db.Exec(schema)
-	return err
+	return RetryOnBusy("execute schema", func() error {
+		_, err := db.db.Exec(schema)
+		return err
+	})
 }
 
-// Transaction executes a function within a database transaction
+// Transaction executes a function within a database transaction. If the
+// database is busy the whole transaction is retried, so fn may run more than
+// once.
 func (db *Database) Transaction(fn func(*sql.Tx) error) error {
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
+	return RetryOnBusy("transaction", func() error {
+		return db.transaction(fn)
+	})
+}
+
+func (db *Database) transaction(fn func(*sql.Tx) error) error {
 	tx, err := db.db.Begin()
 	if err != nil {
 		return err
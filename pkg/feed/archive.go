@@ -0,0 +1,283 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+	"github.com/lepinkainen/feed-forge/pkg/filesystem"
+	"github.com/lepinkainen/feed-forge/pkg/opengraph"
+)
+
+// FeedHistoryNamespace is the RFC 5005 Feed History namespace.
+const FeedHistoryNamespace = "http://purl.org/syndication/history/1.0"
+
+// ArchiveLinks holds the RFC 5005 paging links of one archive page. Empty
+// links are omitted from the output.
+type ArchiveLinks struct {
+	Current string
+	Prev    string
+	Next    string
+	// Archived marks a closed month with <fh:archive/>. The current month
+	// is still open and is not marked.
+	Archived bool
+}
+
+// archivePage is the set of items published in one calendar month.
+type archivePage struct {
+	period string // YYYY-MM
+	items  []feedtypes.FeedItem
+}
+
+// archivedPage is what an archive file already on disk holds.
+type archivedPage struct {
+	entries []archivedEntry
+	links   ArchiveLinks
+}
+
+// archivedEntry is one <entry> element of an existing archive file, kept
+// verbatim so items that left the provider's window stay archived.
+type archivedEntry struct {
+	id  string
+	raw string
+}
+
+// SaveArchiveFeedsWithEmbeddedTemplate files items into monthly archive files
+// next to outputPath (feed.xml -> feed-2024-01.xml, ...). Items are merged
+// into what earlier runs archived: entries that are no longer in items are
+// kept, entries with the same ID are replaced. Every page links its
+// neighbours with prev-archive/next-archive, and pages whose links change
+// are rewritten. Months before the current one are marked <fh:archive/>.
+// Returns the paths of all archive pages, oldest first.
+func SaveArchiveFeedsWithEmbeddedTemplate(items []feedtypes.FeedItem, templateName, outputPath string, config Config, ogDB *opengraph.Database) ([]string, error) {
+	return saveArchiveFeeds(items, templateName, outputPath, config, ogDB, time.Now())
+}
+
+func saveArchiveFeeds(items []feedtypes.FeedItem, templateName, outputPath string, config Config, ogDB *opengraph.Database, now time.Time) ([]string, error) {
+	newItems := make(map[string][]feedtypes.FeedItem)
+	for _, page := range splitArchivePages(items, now) {
+		newItems[page.period] = page.items
+	}
+
+	existing, err := existingArchivePeriods(outputPath)
+	if err != nil {
+		return nil, err
+	}
+	periods := existing
+	for period := range newItems {
+		if !slices.Contains(periods, period) {
+			periods = append(periods, period)
+		}
+	}
+	sort.Strings(periods)
+
+	paths := make([]string, len(periods))
+	for i, period := range periods {
+		paths[i] = archivePath(outputPath, period)
+	}
+
+	currentPeriod := now.UTC().Format("2006-01")
+	written := 0
+	for i, period := range periods {
+		links := ArchiveLinks{Current: filepath.Base(outputPath), Archived: period < currentPeriod}
+		if i > 0 {
+			links.Prev = filepath.Base(paths[i-1])
+		}
+		if i < len(periods)-1 {
+			links.Next = filepath.Base(paths[i+1])
+		}
+
+		previous, err := readArchivedPage(paths[i])
+		if err != nil {
+			return nil, fmt.Errorf("read archive %s: %w", period, err)
+		}
+		pageItems := newItems[period]
+		if len(pageItems) == 0 && previous != nil && previous.links == links {
+			continue
+		}
+
+		if err := writeArchivePage(paths[i], pageItems, previous, links, templateName, config, ogDB); err != nil {
+			return nil, fmt.Errorf("write archive %s: %w", period, err)
+		}
+		written++
+	}
+
+	slog.Debug("Saved archive feeds", "pages", len(periods), "written", written, "outputPath", outputPath)
+	return paths, nil
+}
+
+// writeArchivePage renders items onto the page at path, keeping the entries
+// of previous whose IDs aren't among them.
+func writeArchivePage(path string, items []feedtypes.FeedItem, previous *archivedPage, links ArchiveLinks, templateName string, config Config, ogDB *opengraph.Database) error {
+	var rendered map[string]bool
+	content, err := generateAtomFeed(context.Background(), items, templateName, config, ogDB, func(data *TemplateData) {
+		data.Archive = &links
+		data.ExtraNamespaces = mergeExtraNamespaces(data.ExtraNamespaces, map[string]string{"fh": FeedHistoryNamespace})
+		rendered = make(map[string]bool, len(data.Items))
+		for _, item := range data.Items {
+			rendered[item.ID] = true
+		}
+	}, func(generator *TemplateGenerator) error {
+		return generator.LoadTemplateWithFallback(templateName)
+	})
+	if err != nil {
+		return err
+	}
+
+	if previous != nil {
+		var kept strings.Builder
+		for _, entry := range previous.entries {
+			if entry.id != "" && rendered[entry.id] {
+				continue
+			}
+			kept.WriteString("  ")
+			kept.WriteString(entry.raw)
+			kept.WriteString("\n")
+		}
+		if kept.Len() > 0 {
+			end := strings.LastIndex(content, "</feed>")
+			if end < 0 {
+				return errors.New("rendered archive page has no </feed>")
+			}
+			content = content[:end] + kept.String() + content[end:]
+		}
+	}
+
+	return filesystem.WriteFileAtomic(path, finalizeFeedOutput(content), 0o600)
+}
+
+// readArchivedPage reads the entries and archive links of the page at path,
+// nil when it doesn't exist.
+func readArchivedPage(path string) (*archivedPage, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	raw = bytes.TrimPrefix(raw, []byte(utf8BOM))
+
+	page := &archivedPage{}
+	decoder := xml.NewDecoder(bytes.NewReader(raw))
+	depth := 0
+	var entryStart int64
+	var entry *archivedEntry
+	var inID bool
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return page, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			switch {
+			case depth == 2 && t.Name.Local == "entry":
+				entryStart, entry = offset, &archivedEntry{}
+			case depth == 3 && entry != nil && t.Name.Local == "id":
+				inID = true
+			case depth == 2 && t.Name.Local == "archive" && t.Name.Space == FeedHistoryNamespace:
+				page.links.Archived = true
+			case depth == 2 && t.Name.Local == "link":
+				href := attrValue(t, "href")
+				switch attrValue(t, "rel") {
+				case "current":
+					page.links.Current = href
+				case "prev-archive":
+					page.links.Prev = href
+				case "next-archive":
+					page.links.Next = href
+				}
+			}
+		case xml.CharData:
+			if inID {
+				entry.id += strings.TrimSpace(string(t))
+			}
+		case xml.EndElement:
+			inID = false
+			if depth == 2 && entry != nil && t.Name.Local == "entry" {
+				entry.raw = string(raw[entryStart:decoder.InputOffset()])
+				page.entries = append(page.entries, *entry)
+				entry = nil
+			}
+			depth--
+		}
+	}
+}
+
+func attrValue(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Space == "" && attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// existingArchivePeriods lists the periods of archive files already next to
+// outputPath.
+func existingArchivePeriods(outputPath string) ([]string, error) {
+	ext := filepath.Ext(outputPath)
+	base := strings.TrimSuffix(filepath.Base(outputPath), ext)
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(base) + `-(\d{4}-\d{2})` + regexp.QuoteMeta(ext) + `$`)
+
+	entries, err := os.ReadDir(filepath.Dir(outputPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list archive pages: %w", err)
+	}
+	var periods []string
+	for _, entry := range entries {
+		if match := pattern.FindStringSubmatch(entry.Name()); match != nil && !entry.IsDir() {
+			periods = append(periods, match[1])
+		}
+	}
+	return periods, nil
+}
+
+// splitArchivePages groups items by the month they were created in. Undated
+// items fall in now's month, matching the generation time their entries get
+// in createGenericFeedData.
+func splitArchivePages(items []feedtypes.FeedItem, now time.Time) []archivePage {
+	byPeriod := make(map[string][]feedtypes.FeedItem)
+	for _, item := range items {
+		created := item.CreatedAt()
+		if created.IsZero() {
+			created = now
+		}
+		period := created.UTC().Format("2006-01")
+		byPeriod[period] = append(byPeriod[period], item)
+	}
+
+	pages := make([]archivePage, 0, len(byPeriod))
+	for period, periodItems := range byPeriod {
+		pages = append(pages, archivePage{period: period, items: periodItems})
+	}
+	sort.Slice(pages, func(i, j int) bool { return pages[i].period < pages[j].period })
+	return pages
+}
+
+func archivePath(outputPath, period string) string {
+	ext := filepath.Ext(outputPath)
+	return strings.TrimSuffix(outputPath, ext) + "-" + period + ext
+}
@@ -0,0 +1,196 @@
+package feed
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+func TestSaveArchiveFeeds_MultiPage(t *testing.T) {
+	months := []time.Time{
+		time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+	}
+	items := make([]feedtypes.FeedItem, len(months))
+	for i, createdAt := range months {
+		items[i] = minimalFeedItem{
+			title:        "Item " + createdAt.Format("2006-01"),
+			link:         "https://example.com/" + createdAt.Format("2006-01"),
+			commentsLink: "https://example.com/" + createdAt.Format("2006-01"),
+			createdAt:    createdAt,
+		}
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "feed.xml")
+	now := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	paths, err := saveArchiveFeeds(items, "feissarimokat-atom", outputPath, Config{Title: "Archive"}, nil, now)
+	if err != nil {
+		t.Fatalf("SaveArchiveFeedsWithEmbeddedTemplate() error = %v", err)
+	}
+
+	wantNames := []string{"feed-2024-01.xml", "feed-2024-02.xml", "feed-2024-03.xml"}
+	if len(paths) != len(wantNames) {
+		t.Fatalf("got %d pages, want %d", len(paths), len(wantNames))
+	}
+
+	for i, path := range paths {
+		if filepath.Base(path) != wantNames[i] {
+			t.Fatalf("page %d = %s, want %s", i, filepath.Base(path), wantNames[i])
+		}
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read page: %v", err)
+		}
+		content := string(raw)
+
+		for _, want := range []string{
+			`xmlns:fh="` + FeedHistoryNamespace + `"`,
+			`<link rel="current" href="feed.xml"/>`,
+			"Item " + strings.TrimSuffix(strings.TrimPrefix(wantNames[i], "feed-"), ".xml"),
+		} {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", wantNames[i], want)
+			}
+		}
+
+		// Only closed months are archive documents; March is still open.
+		if got, want := strings.Contains(content, "<fh:archive/>"), i < 2; got != want {
+			t.Errorf("%s <fh:archive/> present = %v, want %v", wantNames[i], got, want)
+		}
+
+		prev := `<link rel="prev-archive"`
+		if i > 0 {
+			prev = `<link rel="prev-archive" href="` + wantNames[i-1] + `"/>`
+		}
+		if got := strings.Contains(content, prev); got != (i > 0) {
+			t.Errorf("%s prev-archive present = %v, want %v", wantNames[i], got, i > 0)
+		}
+
+		next := `<link rel="next-archive"`
+		if i < len(paths)-1 {
+			next = `<link rel="next-archive" href="` + wantNames[i+1] + `"/>`
+		}
+		if got := strings.Contains(content, next); got != (i < len(paths)-1) {
+			t.Errorf("%s next-archive present = %v, want %v", wantNames[i], got, i < len(paths)-1)
+		}
+	}
+}
+
+func TestSaveArchiveFeeds_UndatedItemUsesGenerationMonth(t *testing.T) {
+	items := []feedtypes.FeedItem{
+		minimalFeedItem{title: "Dated", link: "https://example.com/dated", createdAt: time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)},
+		minimalFeedItem{title: "Undated", link: "https://example.com/undated"},
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "feed.xml")
+	now := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	paths, err := saveArchiveFeeds(items, "feissarimokat-atom", outputPath, Config{Title: "Archive"}, nil, now)
+	if err != nil {
+		t.Fatalf("saveArchiveFeeds() error = %v", err)
+	}
+
+	var names []string
+	for _, path := range paths {
+		names = append(names, filepath.Base(path))
+	}
+	if want := []string{"feed-2024-02.xml", "feed-2024-03.xml"}; !slices.Equal(names, want) {
+		t.Fatalf("pages = %v, want %v", names, want)
+	}
+	raw, err := os.ReadFile(paths[1])
+	if err != nil {
+		t.Fatalf("read page: %v", err)
+	}
+	if !strings.Contains(string(raw), "Undated") {
+		t.Fatalf("undated item missing from the generation month's page:\n%s", raw)
+	}
+}
+
+func TestGenerateAtomFeed_NoArchiveMarkersByDefault(t *testing.T) {
+	content, err := GenerateAtomFeedWithEmbeddedTemplate(largeFeedItems(1), "feissarimokat-atom", Config{Title: "Plain"}, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	if strings.Contains(content, "fh:") {
+		t.Fatal("regular feed contains Feed History markup")
+	}
+}
+
+func archiveItem(title string, createdAt time.Time) feedtypes.FeedItem {
+	link := "https://example.com/" + strings.ReplaceAll(strings.ToLower(title), " ", "-")
+	return minimalFeedItem{title: title, link: link, commentsLink: link, createdAt: createdAt}
+}
+
+func TestSaveArchiveFeeds_MergesIntoExistingPages(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "feed.xml")
+	jan := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC)
+
+	first := []feedtypes.FeedItem{archiveItem("Old one", jan), archiveItem("Old two", jan.Add(time.Hour))}
+	if _, err := saveArchiveFeeds(first, "feissarimokat-atom", outputPath, Config{Title: "Archive"}, nil, now); err != nil {
+		t.Fatalf("first run error = %v", err)
+	}
+
+	// The provider window moved on: only one January item is still listed.
+	second := []feedtypes.FeedItem{archiveItem("Old two", jan.Add(time.Hour)), archiveItem("New one", jan.Add(2*time.Hour))}
+	if _, err := saveArchiveFeeds(second, "feissarimokat-atom", outputPath, Config{Title: "Archive"}, nil, now); err != nil {
+		t.Fatalf("second run error = %v", err)
+	}
+
+	raw, err := os.ReadFile(archivePath(outputPath, "2024-01"))
+	if err != nil {
+		t.Fatalf("read page: %v", err)
+	}
+	content := string(raw)
+	for _, title := range []string{"Old one", "Old two", "New one"} {
+		if got := strings.Count(content, "<title>"+title+"</title>"); got != 1 {
+			t.Errorf("page has %d entries titled %q, want 1:\n%s", got, title, content)
+		}
+	}
+	if err := checkWellFormed(raw); err != nil {
+		t.Fatalf("merged page is not well-formed: %v", err)
+	}
+}
+
+func TestSaveArchiveFeeds_RewritesNeighbourLinksAndClosesMonths(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "feed.xml")
+	jan := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	if _, err := saveArchiveFeeds([]feedtypes.FeedItem{archiveItem("January", jan)}, "feissarimokat-atom", outputPath, Config{Title: "Archive"}, nil, jan); err != nil {
+		t.Fatalf("January run error = %v", err)
+	}
+	janPage := archivePath(outputPath, "2024-01")
+	raw, err := os.ReadFile(janPage)
+	if err != nil {
+		t.Fatalf("read January page: %v", err)
+	}
+	if strings.Contains(string(raw), "<fh:archive/>") || strings.Contains(string(raw), "next-archive") {
+		t.Fatalf("open January page should have no archive marker or next link:\n%s", raw)
+	}
+
+	// A February run no longer lists the January item.
+	paths, err := saveArchiveFeeds([]feedtypes.FeedItem{archiveItem("February", feb)}, "feissarimokat-atom", outputPath, Config{Title: "Archive"}, nil, feb)
+	if err != nil {
+		t.Fatalf("February run error = %v", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("got %d pages, want 2", len(paths))
+	}
+
+	raw, err = os.ReadFile(janPage)
+	if err != nil {
+		t.Fatalf("read January page: %v", err)
+	}
+	content := string(raw)
+	for _, want := range []string{"<fh:archive/>", `<link rel="next-archive" href="feed-2024-02.xml"/>`, "<title>January</title>"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("January page missing %q:\n%s", want, content)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package feed
+
+import "github.com/lepinkainen/feed-forge/pkg/opengraph"
+
+// DefaultAudioType is the enclosure type used when a page's og:audio has no
+// og:audio:type.
+const DefaultAudioType = "audio/mpeg"
+
+// itemAudio returns the audio enclosure URL and MIME type from the linked
+// page's OpenGraph data, or empty strings when it has no og:audio.
+func itemAudio(og *opengraph.Data) (audioURL, audioType string) {
+	if og == nil || og.Audio == "" {
+		return "", ""
+	}
+	audioType = og.AudioType
+	if audioType == "" {
+		audioType = DefaultAudioType
+	}
+	return og.Audio, audioType
+}
+
+// itemPlayer returns the linked page's twitter:player, or nil when it has none.
+func itemPlayer(og *opengraph.Data) *opengraph.Player {
+	if og == nil || og.Player.URL == "" {
+		return nil
+	}
+	player := og.Player
+	return &player
+}
@@ -0,0 +1,90 @@
+package feed
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+	"github.com/lepinkainen/feed-forge/pkg/opengraph"
+)
+
+func TestGenerateAtomFeed_AudioEnclosure(t *testing.T) {
+	item := minimalFeedItem{title: "Episode", link: "https://podcast.example.com/12", commentsLink: "https://news.ycombinator.com/item?id=12"}
+	ogData := map[string]*opengraph.Data{
+		item.link: {URL: item.link, Title: "Episode 12", Image: "https://podcast.example.com/cover.jpg", Audio: "https://cdn.example.com/ep12.ogg", AudioType: "audio/ogg"},
+	}
+	audio := `<link rel="enclosure" type="audio/ogg" href="https://cdn.example.com/ep12.ogg"/>`
+	image := `<link rel="enclosure" type="image/jpeg" href="https://podcast.example.com/cover.jpg"/>`
+
+	render := func() string {
+		t.Helper()
+		data := createGenericFeedData([]feedtypes.FeedItem{item}, Config{Title: "Audio"}, ogData)
+		tg := NewTemplateGenerator()
+		if err := tg.LoadTemplateWithFallback("hackernews-atom"); err != nil {
+			t.Fatalf("LoadTemplateWithFallback() error = %v", err)
+		}
+		var out strings.Builder
+		if err := tg.GenerateFromTemplate("hackernews-atom", data, &out); err != nil {
+			t.Fatalf("GenerateFromTemplate() error = %v", err)
+		}
+		return out.String()
+	}
+
+	content := render()
+	if !strings.Contains(content, audio) || !strings.Contains(content, image) {
+		t.Fatalf("feed missing audio or image enclosure:\n%s", content)
+	}
+	if strings.Index(content, audio) > strings.Index(content, image) {
+		t.Error("audio enclosure should come before the image enclosure")
+	}
+
+	withOptions(t, Options{Podcast: true})
+	content = render()
+	if !strings.Contains(content, audio) {
+		t.Errorf("podcast feed missing audio enclosure:\n%s", content)
+	}
+	if strings.Contains(content, image) {
+		t.Error("podcast feed still has the image enclosure")
+	}
+}
+
+func TestItemAudio_DefaultType(t *testing.T) {
+	if url, typ := itemAudio(&opengraph.Data{Audio: "https://example.com/a.mp3"}); url != "https://example.com/a.mp3" || typ != DefaultAudioType {
+		t.Errorf("itemAudio() = %q, %q", url, typ)
+	}
+	if url, typ := itemAudio(&opengraph.Data{}); url != "" || typ != "" {
+		t.Errorf("itemAudio(no audio) = %q, %q", url, typ)
+	}
+}
+
+func TestGenerateAtomFeed_PlayerMediaContent(t *testing.T) {
+	withPlayer := minimalFeedItem{title: "Clip", link: "https://video.example.com/clip", commentsLink: "https://www.reddit.com/r/videos/comments/1"}
+	without := minimalFeedItem{title: "Text", link: "https://example.com/text", commentsLink: "https://www.reddit.com/r/videos/comments/2"}
+	ogData := map[string]*opengraph.Data{
+		withPlayer.link: {URL: withPlayer.link, Player: opengraph.Player{URL: "https://video.example.com/embed/clip", Width: 1280, Height: 720}},
+		without.link:    {URL: without.link, Title: "Text"},
+	}
+
+	for _, templateName := range []string{"hackernews-atom", "reddit-atom", "tildes-atom"} {
+		t.Run(templateName, func(t *testing.T) {
+			data := createGenericFeedData([]feedtypes.FeedItem{withPlayer, without}, Config{Title: "Player"}, ogData)
+			tg := NewTemplateGenerator()
+			if err := tg.LoadTemplateWithFallback(templateName); err != nil {
+				t.Fatalf("LoadTemplateWithFallback() error = %v", err)
+			}
+			var out strings.Builder
+			if err := tg.GenerateFromTemplate(templateName, data, &out); err != nil {
+				t.Fatalf("GenerateFromTemplate() error = %v", err)
+			}
+
+			content := out.String()
+			want := `<media:content url="https://video.example.com/embed/clip" medium="video" type="text/html" width="1280" height="720"/>`
+			if !strings.Contains(content, want) {
+				t.Errorf("feed missing %s:\n%s", want, content)
+			}
+			if got := strings.Count(content, "<media:content"); got != 1 {
+				t.Errorf("got %d media:content elements, want 1 for the item with a player", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package feed
+
+import (
+	"strings"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+// itemContributors returns the non-empty names from the item's optional
+// Contributors() method: people credited besides the authors, such as the
+// account that boosted or crossposted the item.
+func itemContributors(item feedtypes.FeedItem) []string {
+	multi, ok := item.(interface{ Contributors() []string })
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, name := range multi.Contributors() {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// itemAuthors returns the item's authors: the non-empty names from its
+// optional Authors() method, or its single Author() otherwise.
+func itemAuthors(item feedtypes.FeedItem) []string {
+	if multi, ok := item.(interface{ Authors() []string }); ok {
+		var names []string
+		for _, name := range multi.Authors() {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		if len(names) > 0 {
+			return names
+		}
+	}
+	if author := item.Author(); author != "" {
+		return []string{author}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package feed
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+// AuthorPlaceholder is replaced with the (path-escaped) author name in
+// AuthorURLPattern templates.
+const AuthorPlaceholder = "{author}"
+
+// AuthorURLPattern derives an author profile URL for items hosted on a site.
+type AuthorURLPattern struct {
+	// HostPattern matches the item's comments (or link) host, including subdomains.
+	HostPattern string
+	// Template is the profile URL with AuthorPlaceholder in place of the name.
+	Template string
+}
+
+var (
+	authorURLMu       sync.RWMutex
+	authorURLPatterns = []AuthorURLPattern{
+		{HostPattern: "reddit.com", Template: "https://www.reddit.com/user/" + AuthorPlaceholder},
+		{HostPattern: "news.ycombinator.com", Template: "https://news.ycombinator.com/user?id=" + AuthorPlaceholder},
+		{HostPattern: "tildes.net", Template: "https://tildes.net/user/" + AuthorPlaceholder},
+	}
+)
+
+// RegisterAuthorURLPattern adds an author URL pattern. Later registrations for
+// the same host replace earlier ones.
+func RegisterAuthorURLPattern(hostPattern, template string) {
+	authorURLMu.Lock()
+	defer authorURLMu.Unlock()
+
+	hostPattern = strings.ToLower(hostPattern)
+	for i, p := range authorURLPatterns {
+		if p.HostPattern == hostPattern {
+			authorURLPatterns[i].Template = template
+			return
+		}
+	}
+	authorURLPatterns = append(authorURLPatterns, AuthorURLPattern{HostPattern: hostPattern, Template: template})
+}
+
+// itemAuthorURI returns the item's own AuthorURI when it provides one,
+// otherwise a URI built from the first pattern matching the item's host.
+func itemAuthorURI(item feedtypes.FeedItem) string {
+	if authorURI, ok := item.(interface{ AuthorURI() string }); ok {
+		if uri := authorURI.AuthorURI(); uri != "" {
+			return uri
+		}
+	}
+
+	author := item.Author()
+	if author == "" {
+		return ""
+	}
+	host := itemHost(item.CommentsLink())
+	if host == "" {
+		host = itemHost(item.Link())
+	}
+	if host == "" {
+		return ""
+	}
+
+	authorURLMu.RLock()
+	defer authorURLMu.RUnlock()
+	for _, p := range authorURLPatterns {
+		if host == p.HostPattern || strings.HasSuffix(host, "."+p.HostPattern) {
+			return strings.ReplaceAll(p.Template, AuthorPlaceholder, url.PathEscape(author))
+		}
+	}
+	return ""
+}
+
+func itemHost(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
@@ -0,0 +1,60 @@
+package feed
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+func TestItemAuthorURI_BuiltinPatterns(t *testing.T) {
+	tests := []struct {
+		commentsLink string
+		want         string
+	}{
+		{"https://news.ycombinator.com/item?id=1", "https://news.ycombinator.com/user?id=alice"},
+		{"https://old.reddit.com/r/golang/comments/1", "https://www.reddit.com/user/alice"},
+		{"https://tildes.net/~tech/1", "https://tildes.net/user/alice"},
+		{"https://unknown.example/1", ""},
+	}
+	for _, tt := range tests {
+		item := minimalFeedItem{author: "alice", commentsLink: tt.commentsLink}
+		if got := itemAuthorURI(item); got != tt.want {
+			t.Errorf("itemAuthorURI(%s) = %q, want %q", tt.commentsLink, got, tt.want)
+		}
+	}
+}
+
+func TestRegisterAuthorURLPattern_ThirdProvider(t *testing.T) {
+	authorURLMu.RLock()
+	previous := slices.Clone(authorURLPatterns)
+	authorURLMu.RUnlock()
+	t.Cleanup(func() {
+		authorURLMu.Lock()
+		authorURLPatterns = previous
+		authorURLMu.Unlock()
+	})
+
+	RegisterAuthorURLPattern("lobste.rs", "https://lobste.rs/~"+AuthorPlaceholder)
+
+	item := minimalFeedItem{
+		title:        "Lobsters story",
+		link:         "https://example.com/story",
+		commentsLink: "https://lobste.rs/s/abc123",
+		author:       "alice",
+	}
+	data := createGenericFeedData([]feedtypes.FeedItem{item}, Config{}, nil)
+
+	tg := NewTemplateGenerator()
+	if err := tg.LoadTemplateWithFallback("hackernews-atom"); err != nil {
+		t.Fatalf("LoadTemplateWithFallback() error = %v", err)
+	}
+	var out strings.Builder
+	if err := tg.GenerateFromTemplate("hackernews-atom", data, &out); err != nil {
+		t.Fatalf("GenerateFromTemplate() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "<uri>https://lobste.rs/~alice</uri>") {
+		t.Errorf("feed missing registered author URI:\n%s", out.String())
+	}
+}
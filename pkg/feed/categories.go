@@ -0,0 +1,71 @@
+package feed
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+// itemCategories returns the item's categories. With the RawCategories option
+// items that normalize their categories (e.g. Reddit's "r/" prefix) report the
+// source strings from their optional RawCategories() method instead. The
+// result is filtered by the CategoryAllow and CategoryBlock options.
+func itemCategories(item feedtypes.FeedItem) []string {
+	if options.RawCategories {
+		if raw, ok := item.(interface{ RawCategories() []string }); ok {
+			return filterCategories(raw.RawCategories())
+		}
+	}
+	return filterCategories(item.Categories())
+}
+
+// filterCategories drops categories not kept by categoryAllowed, returning
+// categories itself when nothing is dropped.
+func filterCategories(categories []string) []string {
+	if len(options.CategoryAllow) == 0 && len(options.CategoryBlock) == 0 {
+		return categories
+	}
+	return slices.DeleteFunc(slices.Clone(categories), func(c string) bool { return !categoryAllowed(c) })
+}
+
+// categoryAllowed reports whether category passes the CategoryAllow and
+// CategoryBlock options, matched case-insensitively. A blocked category is
+// dropped even when it is also allowed.
+func categoryAllowed(category string) bool {
+	matches := func(c string) bool { return strings.EqualFold(c, category) }
+	if slices.ContainsFunc(options.CategoryBlock, matches) {
+		return false
+	}
+	return len(options.CategoryAllow) == 0 || slices.ContainsFunc(options.CategoryAllow, matches)
+}
+
+// Categories added by freshnessCategory.
+const (
+	FreshCategory   = "fresh"
+	UpdatedCategory = "updated"
+)
+
+// freshnessCategory returns FreshCategory for items first stored within the
+// FreshnessWindow option before now, UpdatedCategory for older items whose
+// score or comment count changed since the previous run, and "" otherwise or
+// when the window is zero. Items without FirstSeenAt() or delta methods are
+// never tagged by the respective rule.
+func freshnessCategory(item feedtypes.FeedItem, now time.Time) string {
+	if options.FreshnessWindow <= 0 {
+		return ""
+	}
+	if seen, ok := item.(interface{ FirstSeenAt() time.Time }); ok {
+		if firstSeen := seen.FirstSeenAt(); !firstSeen.IsZero() && now.Sub(firstSeen) < options.FreshnessWindow {
+			return FreshCategory
+		}
+	}
+	if delta, ok := item.(interface{ ScoreDelta() int }); ok && delta.ScoreDelta() != 0 {
+		return UpdatedCategory
+	}
+	if delta, ok := item.(interface{ CommentDelta() int }); ok && delta.CommentDelta() != 0 {
+		return UpdatedCategory
+	}
+	return ""
+}
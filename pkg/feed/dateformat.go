@@ -0,0 +1,31 @@
+package feed
+
+import "time"
+
+// Formats for Options.DateFormat.
+const (
+	// DateFormatRFC3339 keeps the timestamp's own zone offset and any
+	// sub-second precision.
+	DateFormatRFC3339 = "rfc3339"
+	// DateFormatRFC3339UTC converts to UTC ("Z") with whole seconds.
+	DateFormatRFC3339UTC = "rfc3339-utc"
+	// DateFormatRFC3339NoFrac keeps the zone offset with whole seconds. This
+	// is the default.
+	DateFormatRFC3339NoFrac = "rfc3339-nofrac"
+)
+
+// formatFeedTime formats t for <updated>/<published> according to the
+// DateFormat option, after converting it to the Timezone option's zone.
+func formatFeedTime(t time.Time) string {
+	if options.Timezone != nil {
+		t = t.In(options.Timezone)
+	}
+	switch options.DateFormat {
+	case DateFormatRFC3339:
+		return t.Format(time.RFC3339Nano)
+	case DateFormatRFC3339UTC:
+		return t.UTC().Format(time.RFC3339)
+	default:
+		return t.Format(time.RFC3339)
+	}
+}
@@ -0,0 +1,69 @@
+package feed
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+func TestFormatFeedTime(t *testing.T) {
+	helsinki := time.FixedZone("EEST", 3*60*60)
+	ts := time.Date(2024, 6, 1, 12, 30, 45, 250_000_000, helsinki)
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: DateFormatRFC3339, want: "2024-06-01T12:30:45.25+03:00"},
+		{format: DateFormatRFC3339UTC, want: "2024-06-01T09:30:45Z"},
+		{format: DateFormatRFC3339NoFrac, want: "2024-06-01T12:30:45+03:00"},
+		{format: "", want: "2024-06-01T12:30:45+03:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			withOptions(t, Options{DateFormat: tt.format})
+			if got := formatFeedTime(ts); got != tt.want {
+				t.Errorf("formatFeedTime() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatFeedTime_Timezone(t *testing.T) {
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	withOptions(t, Options{Timezone: helsinki})
+
+	summer := time.Date(2024, 6, 1, 9, 30, 45, 0, time.UTC)
+	if got, want := formatFeedTime(summer), "2024-06-01T12:30:45+03:00"; got != want {
+		t.Errorf("formatFeedTime(summer) = %q, want %q", got, want)
+	}
+	winter := time.Date(2024, 1, 15, 9, 30, 45, 0, time.UTC)
+	if got, want := formatFeedTime(winter), "2024-01-15T11:30:45+02:00"; got != want {
+		t.Errorf("formatFeedTime(winter) = %q, want %q", got, want)
+	}
+}
+
+func TestGenerateAtomFeed_DateFormatAppliesToEntries(t *testing.T) {
+	withOptions(t, Options{DateFormat: DateFormatRFC3339UTC})
+	created := time.Date(2024, 6, 1, 12, 30, 45, 0, time.FixedZone("EEST", 3*60*60))
+	items := []feedtypes.FeedItem{minimalFeedItem{title: "Post", link: "https://example.com/post", createdAt: created}}
+
+	content, err := GenerateAtomFeedWithEmbeddedTemplate(items, "hackernews-atom", Config{Title: "Dates"}, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	for _, want := range []string{"<updated>2024-06-01T09:30:45Z</updated>", "<published>2024-06-01T09:30:45Z</published>"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("feed missing %s", want)
+		}
+	}
+	if strings.Contains(content, "+03:00") {
+		t.Error("feed still contains a non-UTC offset")
+	}
+}
@@ -0,0 +1,53 @@
+package feed
+
+import (
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+	"github.com/lepinkainen/feed-forge/pkg/urlutils"
+)
+
+// Keys for Options.DedupeBy.
+const (
+	DedupeNone  = "none"
+	DedupeURL   = "url"
+	DedupeTitle = "title"
+	DedupeID    = "id"
+)
+
+// DedupeKey returns the key item is deduplicated on for the given strategy:
+// the canonical link for DedupeURL, the normalised title for DedupeTitle and
+// the entry ID for DedupeID. Other strategies return an empty key.
+func DedupeKey(item feedtypes.FeedItem, by string) string {
+	switch by {
+	case DedupeURL:
+		return urlutils.CanonicalURL(item.Link())
+	case DedupeTitle:
+		return urlutils.NormalizeTitle(item.Title())
+	case DedupeID:
+		return feedtypes.ItemGUID(item, "")
+	default:
+		return ""
+	}
+}
+
+// DedupeItems drops items whose DedupeKey matches an earlier item, keeping the
+// first occurrence. Items with an empty key are always kept, and DedupeNone
+// returns the input unchanged.
+func DedupeItems(items []feedtypes.FeedItem, by string) []feedtypes.FeedItem {
+	if by == "" || by == DedupeNone {
+		return items
+	}
+
+	seen := make(map[string]bool, len(items))
+	deduped := make([]feedtypes.FeedItem, 0, len(items))
+	for _, item := range items {
+		key := DedupeKey(item, by)
+		if key != "" {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		deduped = append(deduped, item)
+	}
+	return deduped
+}
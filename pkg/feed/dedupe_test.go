@@ -0,0 +1,51 @@
+package feed
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+func TestDedupeItems(t *testing.T) {
+	// a and b are the same story discussed in two threads; c shares a's
+	// discussion ID but has a different title and link; d is unrelated.
+	items := []feedtypes.FeedItem{
+		minimalFeedItem{title: "Rust 2.0 released", link: "https://www.example.com/rust/?utm_source=hn", commentsLink: "https://news.example/1"},
+		minimalFeedItem{title: "Rust 2.0 Released!", link: "https://example.com/rust", commentsLink: "https://news.example/2"},
+		minimalFeedItem{title: "Rust 2.0: a retrospective", link: "https://blog.example/rust", commentsLink: "https://news.example/1"},
+		minimalFeedItem{title: "Go 2.0 released", link: "https://example.com/go", commentsLink: "https://news.example/3"},
+	}
+
+	tests := []struct {
+		by   string
+		want []string
+	}{
+		{by: DedupeNone, want: []string{"Rust 2.0 released", "Rust 2.0 Released!", "Rust 2.0: a retrospective", "Go 2.0 released"}},
+		{by: DedupeURL, want: []string{"Rust 2.0 released", "Rust 2.0: a retrospective", "Go 2.0 released"}},
+		{by: DedupeTitle, want: []string{"Rust 2.0 released", "Rust 2.0: a retrospective", "Go 2.0 released"}},
+		{by: DedupeID, want: []string{"Rust 2.0 released", "Rust 2.0 Released!", "Go 2.0 released"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			var got []string
+			for _, item := range DedupeItems(items, tt.by) {
+				got = append(got, item.Title())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("DedupeItems(%s) = %v, want %v", tt.by, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDedupeItems_KeepsItemsWithoutKey(t *testing.T) {
+	items := []feedtypes.FeedItem{
+		minimalFeedItem{title: "!!!", link: "https://example.com/a"},
+		minimalFeedItem{title: "???", link: "https://example.com/b"},
+	}
+	if got := DedupeItems(items, DedupeTitle); len(got) != 2 {
+		t.Fatalf("DedupeItems() kept %d items, want 2", len(got))
+	}
+}
@@ -0,0 +1,50 @@
+package feed
+
+import (
+	"log/slog"
+	"strconv"
+)
+
+// Actions for Options.OnDuplicateID.
+const (
+	DuplicateIDWarn   = "warn"
+	DuplicateIDDrop   = "drop"
+	DuplicateIDSuffix = "suffix"
+)
+
+// resolveDuplicateIDs logs entries whose ID repeats an earlier entry's and
+// applies action to them: DuplicateIDWarn (default) keeps them as-is,
+// DuplicateIDDrop removes them, and DuplicateIDSuffix appends "-2", "-3", ...
+// until the ID is unique.
+func resolveDuplicateIDs(items []TemplateItem, action string) []TemplateItem {
+	seen := make(map[string]bool, len(items))
+	resolved := items[:0]
+	for _, item := range items {
+		if !seen[item.ID] {
+			seen[item.ID] = true
+			resolved = append(resolved, item)
+			continue
+		}
+
+		slog.Warn("Duplicate entry ID in feed", "id", item.ID, "title", item.Title, "action", duplicateIDAction(action))
+		switch action {
+		case DuplicateIDDrop:
+			continue
+		case DuplicateIDSuffix:
+			base := item.ID
+			for n := 2; seen[item.ID]; n++ {
+				item.ID = base + "-" + strconv.Itoa(n)
+			}
+			seen[item.ID] = true
+		}
+		resolved = append(resolved, item)
+	}
+	return resolved
+}
+
+func duplicateIDAction(action string) string {
+	if action == "" {
+		return DuplicateIDWarn
+	}
+	return action
+}
@@ -0,0 +1,53 @@
+package feed
+
+import (
+	"testing"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+func TestCreateGenericFeedData_DuplicateIDs(t *testing.T) {
+	// Neither item has a comments link, so both get the same derived ID.
+	items := []feedtypes.FeedItem{
+		minimalFeedItem{title: "First", link: "https://example.com/same"},
+		minimalFeedItem{title: "Second", link: "https://example.com/same"},
+		minimalFeedItem{title: "Other", link: "https://example.com/other"},
+	}
+
+	tests := []struct {
+		action     string
+		wantTitles []string
+		wantUnique bool
+	}{
+		{action: "", wantTitles: []string{"First", "Second", "Other"}, wantUnique: false},
+		{action: DuplicateIDWarn, wantTitles: []string{"First", "Second", "Other"}, wantUnique: false},
+		{action: DuplicateIDDrop, wantTitles: []string{"First", "Other"}, wantUnique: true},
+		{action: DuplicateIDSuffix, wantTitles: []string{"First", "Second", "Other"}, wantUnique: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(duplicateIDAction(tt.action), func(t *testing.T) {
+			withOptions(t, Options{OnDuplicateID: tt.action})
+			data := createGenericFeedData(items, Config{ID: "urn:test"}, nil)
+
+			if len(data.Items) != len(tt.wantTitles) {
+				t.Fatalf("got %d items, want %d", len(data.Items), len(tt.wantTitles))
+			}
+			ids := make(map[string]bool)
+			for i, item := range data.Items {
+				if item.Title != tt.wantTitles[i] {
+					t.Errorf("item %d title = %q, want %q", i, item.Title, tt.wantTitles[i])
+				}
+				ids[item.ID] = true
+			}
+			if unique := len(ids) == len(data.Items); unique != tt.wantUnique {
+				t.Errorf("unique IDs = %v, want %v", unique, tt.wantUnique)
+			}
+			if tt.action == DuplicateIDSuffix {
+				if want := data.Items[0].ID + "-2"; data.Items[1].ID != want {
+					t.Errorf("suffixed ID = %q, want %q", data.Items[1].ID, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package feed
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"strings"
+	"text/template"
+
+	"github.com/lepinkainen/feed-forge/pkg/opengraph"
+)
+
+// EnhancedContentTemplate is the optional override template that renders each
+// item's content HTML. It is only read from the override filesystem; without
+// it the provider templates use their built-in content markup.
+const EnhancedContentTemplate = "enhanced-content"
+
+// EnhancedContentData is passed to the enhanced-content template for each item.
+type EnhancedContentData struct {
+	TemplateItem
+	// OpenGraph is the linked page's OpenGraph data, nil when unavailable.
+	OpenGraph *opengraph.Data
+}
+
+// LoadEnhancedContentTemplate loads the enhanced-content override template if
+// one exists. A missing template is not an error.
+func (tg *TemplateGenerator) LoadEnhancedContentTemplate() error {
+	overrideFS := GetTemplateOverrideFS()
+	if overrideFS == nil {
+		return nil
+	}
+
+	filename := EnhancedContentTemplate + ".tmpl"
+	content, err := fs.ReadFile(overrideFS, filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read override template %s: %w", filename, err)
+	}
+
+	tmpl, err := template.New(EnhancedContentTemplate).Funcs(tg.funcMap).Parse(string(content))
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse template %s: %w", ErrTemplateInvalid, filename, err)
+	}
+	tg.enhancedContent = tmpl
+	slog.Debug("Loading override template", "name", EnhancedContentTemplate, "source", "override_fs")
+	return nil
+}
+
+// renderEnhancedContent fills each item's EnhancedContent from the
+// enhanced-content template, when one is loaded.
+func (tg *TemplateGenerator) renderEnhancedContent(data *TemplateData) error {
+	if tg.enhancedContent == nil {
+		return nil
+	}
+
+	for i := range data.Items {
+		item := &data.Items[i]
+		var content strings.Builder
+		err := tg.enhancedContent.Execute(&content, EnhancedContentData{
+			TemplateItem: *item,
+			OpenGraph:    data.OpenGraphData[item.Link],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to execute template %s: %w", EnhancedContentTemplate, err)
+		}
+		item.EnhancedContent = content.String()
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package feed
+
+import "github.com/lepinkainen/feed-forge/pkg/feedtypes"
+
+// entryID returns the entry ID of item: its RFC 4151 tag URI with the
+// TagURIIDs option when it has one, and feedtypes.ItemGUID otherwise.
+func entryID(item feedtypes.FeedItem, namespace string) string {
+	if options.TagURIIDs {
+		if uri, ok := feedtypes.ItemTagURI(item); ok {
+			return uri
+		}
+	}
+	return feedtypes.ItemGUID(item, namespace)
+}
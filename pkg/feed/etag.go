@@ -0,0 +1,27 @@
+package feed
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+// ContentETag returns a strong HTTP ETag for the feed built from items in the
+// given variant (usually the output format). It hashes the items rather than
+// the rendered feed, whose <updated> changes on every render, so the tag only
+// changes when an entry is added, removed or edited, or its score or comment
+// count moves.
+func ContentETag(items []feedtypes.FeedItem, variant string) string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s\n%d\n", variant, len(items))
+	for _, item := range items {
+		_, _ = fmt.Fprintf(h, "%s\n%s\n%s\n%s\n%s\n%d\n%d\n%s\n%s\n%s\n",
+			feedtypes.ItemGUID(item, ""), item.Title(), item.Link(), item.CommentsLink(),
+			item.Author(), item.Score(), item.CommentCount(),
+			item.CreatedAt().UTC().Format(time.RFC3339Nano), item.ImageURL(), item.Content())
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
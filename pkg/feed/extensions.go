@@ -0,0 +1,73 @@
+package feed
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// builtinNamespaces are prefixes declared by the feed templates themselves.
+var builtinNamespaces = map[string]bool{"xml": true, "xmlns": true, "media": true}
+
+var namespacePrefixPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9._-]*$`)
+
+// validExtraXML returns fragment if it is well-formed XML, otherwise logs and
+// returns an empty string so a broken item cannot corrupt the whole feed. A
+// fragment that closes the <extra> wrapper early to smuggle in sibling
+// elements (</extra><evil/><extra>) is rejected too.
+func validExtraXML(fragment string) string {
+	fragment = strings.TrimSpace(fragment)
+	if fragment == "" {
+		return ""
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader("<extra>" + fragment + "</extra>"))
+	depth := 0
+	closed := false
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return fragment
+		}
+		if err != nil {
+			slog.Warn("Dropping malformed item ExtraXML", "error", err)
+			return ""
+		}
+		if closed {
+			slog.Warn("Dropping item ExtraXML that closes its wrapper early")
+			return ""
+		}
+		switch token.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+			closed = depth == 0
+		}
+	}
+}
+
+// mergeExtraNamespaces adds valid prefix -> URI declarations from extra into
+// dst. Built-in prefixes and conflicting redeclarations are ignored.
+func mergeExtraNamespaces(dst, extra map[string]string) map[string]string {
+	for prefix, uri := range extra {
+		if builtinNamespaces[prefix] || !namespacePrefixPattern.MatchString(prefix) || uri == "" {
+			slog.Warn("Ignoring invalid extra namespace", "prefix", prefix, "uri", uri)
+			continue
+		}
+		if existing, ok := dst[prefix]; ok {
+			if existing != uri {
+				slog.Warn("Ignoring conflicting extra namespace", "prefix", prefix, "uri", uri, "existing", existing)
+			}
+			continue
+		}
+		if dst == nil {
+			dst = make(map[string]string)
+		}
+		dst[prefix] = uri
+	}
+	return dst
+}
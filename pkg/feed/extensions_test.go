@@ -0,0 +1,84 @@
+package feed
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+type geoFeedItem struct {
+	minimalFeedItem
+	extraXML string
+}
+
+func (g geoFeedItem) ExtraXML() string { return g.extraXML }
+
+func (geoFeedItem) ExtraNamespaces() map[string]string {
+	return map[string]string{"geo": "http://www.w3.org/2003/01/geo/wgs84_pos#"}
+}
+
+func TestGenerateAtomFeed_ItemExtensions(t *testing.T) {
+	items := []feedtypes.FeedItem{
+		geoFeedItem{
+			minimalFeedItem: minimalFeedItem{title: "Located", link: "https://example.com/a", commentsLink: "https://example.com/a"},
+			extraXML:        `<geo:point>60.17 24.94</geo:point>`,
+		},
+		geoFeedItem{
+			minimalFeedItem: minimalFeedItem{title: "Broken", link: "https://example.com/b", commentsLink: "https://example.com/b"},
+			extraXML:        `<geo:point>unclosed`,
+		},
+	}
+
+	content, err := GenerateAtomFeedWithEmbeddedTemplate(items, "feissarimokat-atom", Config{Title: "Geo"}, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+
+	if !strings.Contains(content, `xmlns:geo="http://www.w3.org/2003/01/geo/wgs84_pos#"`) {
+		t.Errorf("feed root missing geo namespace:\n%s", content)
+	}
+	if !strings.Contains(content, `<geo:point>60.17 24.94</geo:point>`) {
+		t.Errorf("entry missing custom element:\n%s", content)
+	}
+	if strings.Contains(content, "unclosed") {
+		t.Errorf("malformed ExtraXML should be dropped:\n%s", content)
+	}
+
+	var parsed struct{}
+	if err := xml.Unmarshal([]byte(content), &parsed); err != nil {
+		t.Fatalf("generated feed is not well-formed: %v", err)
+	}
+}
+
+func TestValidExtraXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		fragment string
+		want     string
+	}{
+		{"element", `<geo:point>60.17 24.94</geo:point>`, `<geo:point>60.17 24.94</geo:point>`},
+		{"siblings", `<geo:lat>60</geo:lat><geo:long>24</geo:long>`, `<geo:lat>60</geo:lat><geo:long>24</geo:long>`},
+		{"unclosed", `<geo:point>unclosed`, ""},
+		{"wrapper escape", `</extra><evil/><extra>`, ""},
+		{"wrapper escape with text", `text</extra>tail<extra>`, ""},
+		{"stray end", `</extra>`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validExtraXML(tt.fragment); got != tt.want {
+				t.Errorf("validExtraXML(%q) = %q, want %q", tt.fragment, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeExtraNamespaces_RejectsBuiltinsAndConflicts(t *testing.T) {
+	got := mergeExtraNamespaces(nil, map[string]string{"media": "urn:other", "bad prefix": "urn:x", "geo": "urn:geo"})
+	got = mergeExtraNamespaces(got, map[string]string{"geo": "urn:different"})
+
+	if len(got) != 1 || got["geo"] != "urn:geo" {
+		t.Fatalf("mergeExtraNamespaces() = %#v, want only geo=urn:geo", got)
+	}
+}
@@ -0,0 +1,95 @@
+package feed
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+	"github.com/lepinkainen/feed-forge/pkg/filesystem"
+	"github.com/lepinkainen/feed-forge/pkg/opengraph"
+)
+
+// Output formats a feed can be written in.
+const (
+	FormatAtom = "atom"
+	FormatRSS  = "rss"
+	FormatJSON = "json"
+	FormatHTML = "html" // static HTML page rather than a feed
+)
+
+// ValidFormat reports whether format is a known output format. Empty means
+// FormatAtom.
+func ValidFormat(format string) bool {
+	switch format {
+	case "", FormatAtom, FormatRSS, FormatJSON, FormatHTML:
+		return true
+	}
+	return false
+}
+
+// FormatExtension returns the file extension written for format.
+func FormatExtension(format string) string {
+	switch format {
+	case FormatJSON:
+		return ".json"
+	case FormatHTML:
+		return ".html"
+	}
+	return ".xml"
+}
+
+// OutfileForFormat gives outfile the extension of format when it has the
+// default .xml extension or none, so feed.xml becomes feed.json for JSON
+// Feed output. Other extensions were chosen explicitly and are kept.
+func OutfileForFormat(outfile, format string) string {
+	ext := filepath.Ext(outfile)
+	if ext != "" && !strings.EqualFold(ext, ".xml") {
+		return outfile
+	}
+	return strings.TrimSuffix(outfile, ext) + FormatExtension(format)
+}
+
+// SaveFeedToFile writes items to outputPath in format: Atom from the
+// provider's template, RSS, JSON Feed or the HTML page otherwise.
+func SaveFeedToFile(items []feedtypes.FeedItem, format, templateName, outputPath string, config Config, ogDB *opengraph.Database) error {
+	var body []byte
+	var err error
+	switch format {
+	case "", FormatAtom:
+		return SaveAtomFeedToFileWithEmbeddedTemplate(items, templateName, outputPath, config, ogDB)
+	case FormatRSS:
+		body, err = GenerateRSSFeed(items, config)
+		if err == nil {
+			body = finalizeFeedOutput(string(body))
+		}
+	case FormatJSON:
+		body, err = GenerateJSONFeed(items, config)
+	case FormatHTML:
+		body, err = GenerateHTMLPage(items, config, ogDB)
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+	if err != nil {
+		return err
+	}
+	return filesystem.WriteFileAtomic(outputPath, body, 0o600)
+}
+
+// HasEntries reports whether contents, a document written in format, holds
+// at least one entry.
+func HasEntries(contents []byte, format string) bool {
+	switch format {
+	case FormatJSON:
+		var doc struct {
+			Items []json.RawMessage `json:"items"`
+		}
+		return json.Unmarshal(contents, &doc) == nil && len(doc.Items) > 0
+	case FormatRSS:
+		return strings.Contains(string(contents), "<item>") || strings.Contains(string(contents), "<item ")
+	case FormatHTML:
+		return strings.Contains(string(contents), `class="item"`)
+	}
+	return strings.Contains(string(contents), "<entry")
+}
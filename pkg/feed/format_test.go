@@ -0,0 +1,83 @@
+package feed
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+func TestOutfileForFormat(t *testing.T) {
+	tests := []struct {
+		outfile, format, want string
+	}{
+		{"feed.xml", FormatAtom, "feed.xml"},
+		{"feed.xml", FormatRSS, "feed.xml"},
+		{"feed.xml", FormatJSON, "feed.json"},
+		{"out/feed.XML", FormatHTML, "out/feed.html"},
+		{"feed", FormatJSON, "feed.json"},
+		{"feed.atom", FormatJSON, "feed.atom"},
+	}
+	for _, tt := range tests {
+		if got := OutfileForFormat(tt.outfile, tt.format); got != tt.want {
+			t.Errorf("OutfileForFormat(%q, %q) = %q, want %q", tt.outfile, tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestHasEntries(t *testing.T) {
+	tests := []struct {
+		format, contents string
+		want             bool
+	}{
+		{FormatAtom, "<feed><entry></entry></feed>", true},
+		{FormatAtom, "<feed></feed>", false},
+		{FormatRSS, "<rss><channel><item></item></channel></rss>", true},
+		{FormatRSS, "<rss><channel></channel></rss>", false},
+		{FormatJSON, `{"items":[{"id":"1"}]}`, true},
+		{FormatJSON, `{"items":[]}`, false},
+		{FormatHTML, `<div class="item"></div>`, true},
+		{FormatHTML, `<p class="empty">No items.</p>`, false},
+	}
+	for _, tt := range tests {
+		if got := HasEntries([]byte(tt.contents), tt.format); got != tt.want {
+			t.Errorf("HasEntries(%q, %s) = %v, want %v", tt.contents, tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestSaveFeedToFile_MaxFeedSizeAllFormats(t *testing.T) {
+	const maxSize = 8 * 1024
+	withOptions(t, Options{MaxFeedSize: maxSize})
+
+	for _, format := range []string{FormatAtom, FormatRSS, FormatJSON, FormatHTML} {
+		path := filepath.Join(t.TempDir(), OutfileForFormat("feed.xml", format))
+		err := SaveFeedToFile(largeFeedItems(40), format, "feissarimokat-atom", path, Config{Title: "Big"}, nil)
+		if !errors.Is(err, ErrFeedTooLarge) {
+			t.Errorf("%s: error = %v, want ErrFeedTooLarge", format, err)
+		}
+		if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+			t.Errorf("%s: oversized feed was written", format)
+		}
+	}
+}
+
+func TestSaveFeedToFile_JSONNoCategories(t *testing.T) {
+	withOptions(t, Options{NoCategories: true})
+	items := []feedtypes.FeedItem{minimalFeedItem{title: "Tagged", link: "https://example.com/t", categories: []string{"go"}}}
+
+	path := filepath.Join(t.TempDir(), "feed.json")
+	if err := SaveFeedToFile(items, FormatJSON, "", path, Config{Title: "Tags"}, nil); err != nil {
+		t.Fatalf("SaveFeedToFile() error = %v", err)
+	}
+	body, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if strings.Contains(string(body), `"tags"`) {
+		t.Fatalf("JSON Feed has tags with NoCategories:\n%s", body)
+	}
+}
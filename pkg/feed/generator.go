@@ -5,49 +5,78 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"os"
+	"maps"
+	"net/mail"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/lepinkainen/feed-forge/pkg/api"
 	"github.com/lepinkainen/feed-forge/pkg/feedmeta"
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+	"github.com/lepinkainen/feed-forge/pkg/filesystem"
 	"github.com/lepinkainen/feed-forge/pkg/opengraph"
-	"github.com/lepinkainen/feed-forge/pkg/providers"
+	"github.com/lepinkainen/feed-forge/pkg/textutils"
+	"github.com/lepinkainen/feed-forge/pkg/urlutils"
 )
 
 // Config contains metadata for feed generation.
 type Config = feedmeta.Config
 
 // GenerateAtomFeedWithEmbeddedTemplate creates an Atom RSS feed using embedded templates with local override.
-func GenerateAtomFeedWithEmbeddedTemplate(items []providers.FeedItem, templateName string, config Config, ogDB *opengraph.Database) (string, error) {
+func GenerateAtomFeedWithEmbeddedTemplate(items []feedtypes.FeedItem, templateName string, config Config, ogDB *opengraph.Database) (string, error) {
 	return GenerateAtomFeedWithEmbeddedTemplateWithContext(context.Background(), items, templateName, config, ogDB)
 }
 
 // GenerateAtomFeedWithEmbeddedTemplateWithContext creates an Atom RSS feed using embedded templates with local override.
-func GenerateAtomFeedWithEmbeddedTemplateWithContext(ctx context.Context, items []providers.FeedItem, templateName string, config Config, ogDB *opengraph.Database) (string, error) {
-	return generateAtomFeed(ctx, items, templateName, config, ogDB, func(generator *TemplateGenerator) error {
+func GenerateAtomFeedWithEmbeddedTemplateWithContext(ctx context.Context, items []feedtypes.FeedItem, templateName string, config Config, ogDB *opengraph.Database) (string, error) {
+	return generateAtomFeed(ctx, items, templateName, config, ogDB, nil, func(generator *TemplateGenerator) error {
 		return generator.LoadTemplateWithFallback(templateName)
 	})
 }
 
 // SaveAtomFeedToFileWithEmbeddedTemplate generates and saves an Atom feed using embedded templates with local override.
-func SaveAtomFeedToFileWithEmbeddedTemplate(items []providers.FeedItem, templateName, outputPath string, config Config, ogDB *opengraph.Database) error {
+func SaveAtomFeedToFileWithEmbeddedTemplate(items []feedtypes.FeedItem, templateName, outputPath string, config Config, ogDB *opengraph.Database) error {
 	return SaveAtomFeedToFileWithEmbeddedTemplateWithContext(context.Background(), items, templateName, outputPath, config, ogDB)
 }
 
 // SaveAtomFeedToFileWithEmbeddedTemplateWithContext generates and saves an Atom feed using embedded templates with local override.
-func SaveAtomFeedToFileWithEmbeddedTemplateWithContext(ctx context.Context, items []providers.FeedItem, templateName, outputPath string, config Config, ogDB *opengraph.Database) error {
+func SaveAtomFeedToFileWithEmbeddedTemplateWithContext(ctx context.Context, items []feedtypes.FeedItem, templateName, outputPath string, config Config, ogDB *opengraph.Database) error {
+	return saveAtomFeed(ctx, items, templateName, outputPath, config, ogDB, nil)
+}
+
+// saveAtomFeed generates and saves an Atom feed, applying decorate (when set)
+// to the template data after the save-time options.
+func saveAtomFeed(ctx context.Context, items []feedtypes.FeedItem, templateName, outputPath string, config Config, ogDB *opengraph.Database, decorate func(*TemplateData)) error {
 	slog.Debug("Generating and saving Atom feed with embedded template", "outputPath", outputPath, "itemCount", len(items))
 
-	atomContent, err := GenerateAtomFeedWithEmbeddedTemplateWithContext(ctx, items, templateName, config, ogDB)
+	// With Trends, deltas come from the stats the previous run wrote to outputPath.
+	if options.Trends {
+		previous, err := ReadFeedStats(outputPath)
+		if err != nil {
+			slog.Warn("Ignoring previous feed for trends", "outputPath", outputPath, "error", err)
+		}
+		next := decorate
+		decorate = func(data *TemplateData) {
+			applyTrendDeltas(data, previous)
+			if next != nil {
+				next(data)
+			}
+		}
+	}
+
+	atomContent, err := generateAtomFeed(ctx, items, templateName, config, ogDB, decorate, func(generator *TemplateGenerator) error {
+		return generator.LoadTemplateWithFallback(templateName)
+	})
 	if err != nil {
 		slog.Error("Failed to generate Atom feed", "error", err)
 		return err
 	}
 
-	return os.WriteFile(outputPath, []byte(atomContent), 0o600)
+	return filesystem.WriteFileAtomic(outputPath, finalizeFeedOutput(atomContent), 0o600)
 }
 
-func generateAtomFeed(ctx context.Context, items []providers.FeedItem, templateName string, config Config, ogDB *opengraph.Database, loadTemplate func(*TemplateGenerator) error) (string, error) {
+func generateAtomFeed(ctx context.Context, items []feedtypes.FeedItem, templateName string, config Config, ogDB *opengraph.Database, decorate func(*TemplateData), loadTemplate func(*TemplateGenerator) error) (string, error) {
 	slog.Debug("Generating Atom feed", "templateName", templateName, "itemCount", len(items))
 
 	templateGenerator := NewTemplateGenerator()
@@ -55,8 +84,12 @@ func generateAtomFeed(ctx context.Context, items []providers.FeedItem, templateN
 		slog.Error("Failed to load template", "templateName", templateName, "error", err)
 		return "", err
 	}
+	if err := templateGenerator.LoadEnhancedContentTemplate(); err != nil {
+		slog.Error("Failed to load template", "templateName", EnhancedContentTemplate, "error", err)
+		return "", err
+	}
 
-	urls := externalItemURLs(items)
+	urls := ogFetchURLs(items, config)
 
 	var ogData map[string]*opengraph.Data
 	if ogDB != nil {
@@ -65,20 +98,44 @@ func generateAtomFeed(ctx context.Context, items []providers.FeedItem, templateN
 		ogData = ogFetcher.FetchConcurrentWithContext(ctx, urls)
 	}
 
-	templateData := createGenericFeedData(items, config, ogData)
+	render := func(items []feedtypes.FeedItem) (string, error) {
+		templateData := createGenericFeedData(items, config, ogData)
+		if decorate != nil {
+			decorate(templateData)
+		}
+		if err := templateGenerator.renderEnhancedContent(templateData); err != nil {
+			return "", err
+		}
+
+		var atomContent strings.Builder
+		if err := templateGenerator.GenerateFromTemplate(templateName, templateData, &atomContent); err != nil {
+			slog.Error("Failed to generate template feed", "templateName", templateName, "error", err)
+			return "", err
+		}
+		return atomContent.String(), nil
+	}
 
-	var atomContent strings.Builder
-	if err := templateGenerator.GenerateFromTemplate(templateName, templateData, &atomContent); err != nil {
-		slog.Error("Failed to generate template feed", "templateName", templateName, "error", err)
+	result, err := renderWithinMaxFeedSize(items, render)
+	if err != nil {
 		return "", err
 	}
 
-	result := atomContent.String()
 	slog.Debug("Atom feed generated successfully", "templateName", templateName, "feedSize", len(result))
 	return result, nil
 }
 
-func externalItemURLs(items []providers.FeedItem) []string {
+// ogFetchURLs returns the linked pages to look up OpenGraph data for, at most
+// config.OpenGraphMaxFetches of them when set.
+func ogFetchURLs(items []feedtypes.FeedItem, config Config) []string {
+	urls := externalItemURLs(items)
+	if limit := config.OpenGraphMaxFetches; limit > 0 && len(urls) > limit {
+		slog.Debug("Capping OpenGraph fetches", "urls", len(urls), "max", limit)
+		urls = urls[:limit]
+	}
+	return urls
+}
+
+func externalItemURLs(items []feedtypes.FeedItem) []string {
 	urls := make([]string, 0, len(items))
 	seen := make(map[string]struct{}, len(items))
 	for _, item := range items {
@@ -95,53 +152,189 @@ func externalItemURLs(items []providers.FeedItem) []string {
 	return urls
 }
 
+// NewOGFetcher returns an OpenGraph fetcher set up the same way feed generation
+// sets up its own: proxy from config, plus the run-wide Options.
+func NewOGFetcher(ogDB *opengraph.Database, config Config) *opengraph.Fetcher {
+	return createOGFetcher(ogDB, config)
+}
+
 // createOGFetcher creates an OpenGraph fetcher, optionally with proxy support.
 func createOGFetcher(ogDB *opengraph.Database, config Config) *opengraph.Fetcher {
+	var fetcher *opengraph.Fetcher
 	if config.ProxyURL != "" && config.ProxySecret != "" {
-		return opengraph.NewFetcherWithProxy(ogDB, &opengraph.ProxyConfig{
+		fetcher = opengraph.NewFetcherWithProxy(ogDB, &opengraph.ProxyConfig{
 			URL:    config.ProxyURL,
 			Secret: config.ProxySecret,
 		})
+	} else {
+		fetcher = opengraph.NewFetcher(ogDB)
+	}
+	fetcher.ForceRefresh = options.RefreshOG
+	if len(options.PaywallDomains) > 0 {
+		fetcher.PaywallDomains = options.PaywallDomains
+	}
+	fetcher.DumpDir = options.OGDumpDir
+	fetcher.SiteNames = options.SiteNames
+	fetcher.Outbound = api.SharedSemaphore()
+	fetcher.RespectRobots = options.RespectRobots
+	if len(options.Soft404Phrases) > 0 {
+		fetcher.Soft404Phrases = options.Soft404Phrases
+	}
+	if options.OGMaxRedirects > 0 {
+		fetcher.MaxRedirects = options.OGMaxRedirects
+	}
+	fetcher.MinBodyBytes = options.OGMinBodyBytes
+	if options.OGTimeout > 0 {
+		fetcher.RequestTimeout = options.OGTimeout
+	}
+	if options.OGMemoryCache > 0 {
+		fetcher.MemoryCacheEntries = options.OGMemoryCache
+	}
+	switch {
+	case options.OGLanguage != "":
+		fetcher.AcceptLanguage = options.OGLanguage
+	case config.Language != "":
+		fetcher.AcceptLanguage = acceptLanguageFor(config.Language)
 	}
-	return opengraph.NewFetcher(ogDB)
+	if options.InsecureTLS || len(options.InsecureTLSDomains) > 0 {
+		if err := fetcher.EnableInsecureTLS(options.InsecureTLSDomains); err != nil {
+			slog.Warn("Could not relax OpenGraph TLS verification", "error", err)
+		}
+	}
+	return fetcher
+}
+
+// acceptLanguageFor builds an Accept-Language value preferring the feed's
+// locale, with English as a fallback for sites without a localized version.
+func acceptLanguageFor(language string) string {
+	if strings.HasPrefix(strings.ToLower(language), "en") {
+		return language
+	}
+	return language + ",en;q=0.5"
 }
 
+// feedAuthorEmail returns the feed's author email, falling back to the run-wide
+// Options.AuthorEmail. Invalid addresses are dropped rather than emitted.
+func feedAuthorEmail(config Config) string {
+	email := config.AuthorEmail
+	if email == "" {
+		email = options.AuthorEmail
+	}
+	if email == "" {
+		return ""
+	}
+	if err := ValidateAuthorEmail(email); err != nil {
+		slog.Warn("Ignoring invalid feed author email", "email", email, "error", err)
+		return ""
+	}
+	return email
+}
+
+// ValidateAuthorEmail checks that email is a bare address like
+// "feeds@example.com", as required by Atom's <email> element.
+func ValidateAuthorEmail(email string) error {
+	addr, err := mail.ParseAddress(email)
+	if err != nil {
+		return fmt.Errorf("invalid email %q: %w", email, err)
+	}
+	if addr.Address != email || !strings.Contains(email[strings.LastIndex(email, "@")+1:], ".") {
+		return fmt.Errorf("invalid email %q: expected a bare address like name@example.com", email)
+	}
+	return nil
+}
+
+// PaywallCategory is added to items whose link was detected as paywalled.
+const PaywallCategory = "paywall"
+
 // createGenericFeedData converts FeedItems to template data structure.
 // This replaces the provider-specific CreateRedditFeedData and CreateHackerNewsFeedData functions.
-func createGenericFeedData(items []providers.FeedItem, config Config, ogData map[string]*opengraph.Data) *TemplateData {
+func createGenericFeedData(items []feedtypes.FeedItem, config Config, ogData map[string]*opengraph.Data) *TemplateData {
 	now := time.Now()
 
 	data := &TemplateData{
-		FeedTitle:       config.Title,
-		FeedLink:        config.Link,
-		FeedDescription: config.Description,
-		FeedAuthor:      config.Author,
-		FeedID:          config.ID,
-		Updated:         now.Format(time.RFC3339),
-		Generator:       "Feed Forge",
-		OpenGraphData:   ogData,
-		Items:           make([]TemplateItem, len(items)),
+		FeedTitle:        config.Title,
+		FeedLink:         config.Link,
+		FeedDescription:  config.Description,
+		FeedAuthor:       config.Author,
+		FeedAuthorEmail:  feedAuthorEmail(config),
+		FeedID:           config.ID,
+		FeedHTMLLink:     config.HTMLURL,
+		SelfLink:         config.SelfURL,
+		Updated:          formatFeedTime(now),
+		Generator:        feedmeta.GeneratorName,
+		GeneratorURI:     feedmeta.GeneratorURI,
+		GeneratorVersion: feedmeta.Version,
+		NoEnclosures:     options.NoEnclosures,
+		NoCategories:     options.NoCategories,
+		Podcast:          options.Podcast,
+		OpenGraphData:    ogData,
+		Items:            make([]TemplateItem, len(items)),
+	}
+	if config.SelfURL != "" {
+		data.HubLink = options.Hub
+	}
+	data.CategorySchemeURL = config.CategorySchemeURL
+
+	if options.Trends {
+		data.ExtraNamespaces = mergeExtraNamespaces(data.ExtraNamespaces, map[string]string{statsPrefix: StatsNamespace})
 	}
 
+	undated := 0
 	for i, item := range items {
+		title := item.Title()
+		if options.StripEmoji {
+			title = textutils.StripEmoji(title)
+		}
+		if options.CollapseWhitespace {
+			title = textutils.CollapseWhitespace(title)
+		}
+		title = truncateTitle(title, options.MaxTitleLength)
+
+		// Items without a date would be written as 0001-01-01; date them to
+		// this run instead so readers still order them sensibly.
+		created := item.CreatedAt()
+		if created.IsZero() {
+			created = now
+			undated++
+		}
+
 		templateItem := TemplateItem{
-			Title:        item.Title(),
+			Title:        title,
 			Link:         item.Link(),
 			CommentsLink: item.CommentsLink(),
-			ID:           item.CommentsLink(),
-			Updated:      item.CreatedAt().Format(time.RFC3339),
-			Published:    item.CreatedAt().Format(time.RFC3339),
+			ID:           entryID(item, config.ID),
+			Updated:      formatFeedTime(created),
+			Published:    formatFeedTime(created),
 			Author:       item.Author(),
-			Categories:   item.Categories(),
+			Categories:   itemCategories(item),
 			Score:        item.Score(),
 			Comments:     item.CommentCount(),
-			Content:      item.Content(),
+			Content:      capContentBytes(truncateContent(item.Content(), item.Link(), options.ContentMaxChars), item.Link(), options.ContentMaxBytes),
 			Summary:      fmt.Sprintf("Score: %d | Comments: %d", item.Score(), item.CommentCount()),
 			ImageURL:     item.ImageURL(),
 		}
+		if options.CollapseWhitespace {
+			templateItem.Summary = textutils.CollapseWhitespace(templateItem.Summary)
+		}
+		if options.StripQuery {
+			templateItem.Link = urlutils.StripQuery(templateItem.Link)
+		}
 
-		if authorURI, ok := item.(interface{ AuthorURI() string }); ok {
-			templateItem.AuthorURI = authorURI.AuthorURI()
+		if seen, ok := item.(interface{ FirstSeenAt() time.Time }); ok && options.StableUpdated && !seen.FirstSeenAt().IsZero() {
+			templateItem.Updated = formatFeedTime(seen.FirstSeenAt())
+		}
+		if authors := itemAuthors(item); len(authors) > 1 {
+			templateItem.Author = authors[0]
+			templateItem.CoAuthors = authors[1:]
+		}
+		templateItem.Contributors = itemContributors(item)
+		templateItem.AuthorURI = itemAuthorURI(item)
+		templateItem.ReadingTime = itemReadingTime(item.Content(), ogData[item.Link()])
+		if options.AutoDir {
+			templateItem.TitleDir, templateItem.ContentDir = itemTextDirs(title, item.Content(), ogData[item.Link()])
+		}
+		if options.ShowProvenance {
+			templateItem.Provenance = previewProvenance(ogData[item.Link()], now)
 		}
 		if subreddit, ok := item.(interface{ Subreddit() string }); ok {
 			templateItem.Subreddit = subreddit.Subreddit()
@@ -149,9 +342,73 @@ func createGenericFeedData(items []providers.FeedItem, config Config, ogData map
 		if domain, ok := item.(interface{ ItemDomain() string }); ok {
 			templateItem.Domain = domain.ItemDomain()
 		}
+		if delta, ok := item.(interface{ ScoreDelta() int }); ok {
+			templateItem.ScoreDelta = delta.ScoreDelta()
+		}
+		if delta, ok := item.(interface{ CommentDelta() int }); ok {
+			templateItem.CommentDelta = delta.CommentDelta()
+		}
+		templateItem.AudioURL, templateItem.AudioType = itemAudio(ogData[item.Link()])
+		templateItem.Player = itemPlayer(ogData[item.Link()])
+		if og := ogData[item.Link()]; options.DefaultImage != "" && templateItem.ImageURL == "" && (og == nil || og.Image == "") {
+			templateItem.DefaultThumbnail = options.DefaultImage
+		}
+		if og := ogData[item.Link()]; og != nil && og.Paywalled {
+			templateItem.Paywalled = true
+			if !options.RawCategories && categoryAllowed(PaywallCategory) {
+				templateItem.Categories = append(slices.Clone(templateItem.Categories), PaywallCategory)
+			}
+		}
+		if category := freshnessCategory(item, now); category != "" && !options.RawCategories && categoryAllowed(category) {
+			templateItem.Categories = append(slices.Clone(templateItem.Categories), category)
+		}
+		if extra, ok := item.(interface{ ExtraXML() string }); ok {
+			templateItem.ExtraXML = validExtraXML(extra.ExtraXML())
+		}
+		if extra, ok := item.(interface{ ExtraNamespaces() map[string]string }); ok {
+			data.ExtraNamespaces = mergeExtraNamespaces(data.ExtraNamespaces, extra.ExtraNamespaces())
+		}
+		if options.Trends {
+			templateItem.ExtraXML += statsXML(templateItem.Score, templateItem.Comments)
+		}
 
 		data.Items[i] = templateItem
 	}
+	if undated > 0 {
+		slog.Warn("Items without a date, using the feed generation time", "feed", config.Title, "count", undated)
+	}
+	if options.DynamicSubtitle {
+		if subtitle, ok := dynamicSubtitle(items, data.Items); ok {
+			data.FeedDescription = subtitle
+		}
+	}
+	data.Items = resolveDuplicateIDs(data.Items, options.OnDuplicateID)
+	if options.StripQuery {
+		data.OpenGraphData = stripQueryKeys(ogData)
+	}
 
 	return data
 }
+
+// stripQueryKeys returns ogData with every entry also filed under its link
+// without the query string, so templates looking previews up by the
+// stripped TemplateItem.Link still find them.
+func stripQueryKeys(ogData map[string]*opengraph.Data) map[string]*opengraph.Data {
+	if len(ogData) == 0 {
+		return ogData
+	}
+	keyed := make(map[string]*opengraph.Data, len(ogData))
+	for link, og := range ogData {
+		keyed[link] = og
+	}
+	// Sorted so links differing only in their query resolve the same way
+	// every run.
+	for _, link := range slices.Sorted(maps.Keys(ogData)) {
+		if stripped := urlutils.StripQuery(link); stripped != link {
+			if _, taken := keyed[stripped]; !taken {
+				keyed[stripped] = ogData[link]
+			}
+		}
+	}
+	return keyed
+}
@@ -1,12 +1,20 @@
 package feed
 
 import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
 	"reflect"
+	"regexp"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/lepinkainen/feed-forge/pkg/feedmeta"
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
 	"github.com/lepinkainen/feed-forge/pkg/opengraph"
-	"github.com/lepinkainen/feed-forge/pkg/providers"
 )
 
 type minimalFeedItem struct {
@@ -39,7 +47,7 @@ func (m minimalFeedItem) AuthorURI() string    { return m.authorURI }
 func (m minimalFeedItem) Subreddit() string    { return m.subreddit }
 func (m minimalFeedItem) ItemDomain() string   { return m.domain }
 
-var _ providers.FeedItem = minimalFeedItem{}
+var _ feedtypes.FeedItem = minimalFeedItem{}
 
 func fetcherHasProxy(fetcher any) bool {
 	v := reflect.ValueOf(fetcher)
@@ -73,7 +81,7 @@ func TestCreateOGFetcher_UsesProxyOnlyWhenConfigComplete(t *testing.T) {
 
 func TestCreateGenericFeedData_PreservesOptionalFields(t *testing.T) {
 	createdAt := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
-	items := []providers.FeedItem{minimalFeedItem{
+	items := []feedtypes.FeedItem{minimalFeedItem{
 		title:        "Post",
 		link:         "https://example.com/post",
 		commentsLink: "https://example.com/comments",
@@ -113,3 +121,817 @@ func TestCreateGenericFeedData_PreservesOptionalFields(t *testing.T) {
 		t.Fatalf("OpenGraphData not attached: %#v", data.OpenGraphData)
 	}
 }
+
+func TestCreateOGFetcher_AppliesRefreshOption(t *testing.T) {
+	previous := GetOptions()
+	t.Cleanup(func() { SetOptions(previous) })
+
+	SetOptions(Options{RefreshOG: true})
+	if fetcher := createOGFetcher(nil, Config{}); !fetcher.ForceRefresh {
+		t.Fatal("createOGFetcher() ForceRefresh = false, want true")
+	}
+
+	SetOptions(Options{})
+	if fetcher := createOGFetcher(nil, Config{}); fetcher.ForceRefresh {
+		t.Fatal("createOGFetcher() ForceRefresh = true, want false")
+	}
+}
+
+func TestCreateGenericFeedData_IDWithoutCommentsLink(t *testing.T) {
+	item := minimalFeedItem{link: "https://example.com/comic", createdAt: time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)}
+	config := Config{ID: "https://example.com/feed"}
+
+	first := createGenericFeedData([]feedtypes.FeedItem{item}, config, nil).Items[0].ID
+	second := createGenericFeedData([]feedtypes.FeedItem{item}, config, nil).Items[0].ID
+	if first == "" {
+		t.Fatal("ID = empty, want derived id")
+	}
+	if first != second {
+		t.Fatalf("ID not stable across runs: %q != %q", first, second)
+	}
+}
+
+func TestPaywalledItemGetsBadgeAndCategory(t *testing.T) {
+	item := minimalFeedItem{
+		title:        "Locked story",
+		link:         "https://paywalled.example/story",
+		commentsLink: "https://news.ycombinator.com/item?id=1",
+		categories:   []string{"paywalled.example"},
+	}
+	ogData := map[string]*opengraph.Data{
+		item.link: {URL: item.link, Title: "Locked story", Paywalled: true},
+	}
+
+	data := createGenericFeedData([]feedtypes.FeedItem{item}, Config{}, ogData)
+	got := data.Items[0]
+	if !got.Paywalled {
+		t.Fatal("Paywalled = false, want true")
+	}
+	if want := []string{"paywalled.example", PaywallCategory}; !reflect.DeepEqual(got.Categories, want) {
+		t.Fatalf("Categories = %v, want %v", got.Categories, want)
+	}
+
+	tg := NewTemplateGenerator()
+	if err := tg.LoadTemplateWithFallback("hackernews-atom"); err != nil {
+		t.Fatalf("LoadTemplateWithFallback() error = %v", err)
+	}
+	var out strings.Builder
+	if err := tg.GenerateFromTemplate("hackernews-atom", data, &out); err != nil {
+		t.Fatalf("GenerateFromTemplate() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "🔒 Paywalled") {
+		t.Errorf("feed missing paywall badge:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), `term="paywall"`) {
+		t.Errorf("feed missing paywall category:\n%s", out.String())
+	}
+}
+
+type rawCategoryItem struct {
+	minimalFeedItem
+	raw []string
+}
+
+func (r rawCategoryItem) RawCategories() []string { return r.raw }
+
+func TestGenerateAtomFeed_RawCategories(t *testing.T) {
+	item := rawCategoryItem{
+		minimalFeedItem: minimalFeedItem{
+			title:      "Locked story",
+			link:       "https://paywalled.example/story",
+			categories: []string{"r/golang"},
+		},
+		raw: []string{"golang", "Go & Tools"},
+	}
+	ogData := map[string]*opengraph.Data{
+		item.link: {URL: item.link, Title: "Locked story", Paywalled: true},
+	}
+
+	data := createGenericFeedData([]feedtypes.FeedItem{item}, Config{}, ogData)
+	if want := []string{"r/golang", PaywallCategory}; !reflect.DeepEqual(data.Items[0].Categories, want) {
+		t.Fatalf("default Categories = %v, want %v", data.Items[0].Categories, want)
+	}
+
+	withOptions(t, Options{RawCategories: true})
+	data = createGenericFeedData([]feedtypes.FeedItem{item}, Config{}, ogData)
+	if want := item.raw; !reflect.DeepEqual(data.Items[0].Categories, want) {
+		t.Fatalf("raw Categories = %v, want %v", data.Items[0].Categories, want)
+	}
+
+	tg := NewTemplateGenerator()
+	if err := tg.LoadTemplateWithFallback("reddit-atom"); err != nil {
+		t.Fatalf("LoadTemplateWithFallback() error = %v", err)
+	}
+	var out strings.Builder
+	if err := tg.GenerateFromTemplate("reddit-atom", data, &out); err != nil {
+		t.Fatalf("GenerateFromTemplate() error = %v", err)
+	}
+	content := out.String()
+	for _, want := range []string{`<category term="golang"`, `<category term="Go &amp; Tools"`} {
+		if !strings.Contains(content, want) {
+			t.Errorf("feed missing %s", want)
+		}
+	}
+	for _, unwanted := range []string{`term="r/golang"`, `term="paywall"`} {
+		if strings.Contains(content, unwanted) {
+			t.Errorf("feed has normalized category %s", unwanted)
+		}
+	}
+}
+
+func TestCreateGenericFeedData_CategoryFilters(t *testing.T) {
+	item := minimalFeedItem{
+		title:      "Story",
+		link:       "https://paywalled.example/story",
+		categories: []string{"example.com", "Rising", "Popular 50+"},
+	}
+	ogData := map[string]*opengraph.Data{
+		item.link: {URL: item.link, Title: "Story", Paywalled: true},
+	}
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{"block", Options{CategoryBlock: []string{"rising"}}, []string{"example.com", "Popular 50+", PaywallCategory}},
+		{"allow", Options{CategoryAllow: []string{"Popular 50+", "PAYWALL"}}, []string{"Popular 50+", PaywallCategory}},
+		{"block wins over allow", Options{CategoryAllow: []string{"Rising", "example.com"}, CategoryBlock: []string{"Rising"}}, []string{"example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withOptions(t, tt.opts)
+			got := createGenericFeedData([]feedtypes.FeedItem{item}, Config{}, ogData).Items[0].Categories
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Categories = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if want := []string{"example.com", "Rising", "Popular 50+"}; !reflect.DeepEqual(item.categories, want) {
+		t.Fatalf("item categories modified: %v", item.categories)
+	}
+}
+
+// trackedFeedItem adds the first-seen and delta methods of providers that
+// track items across runs.
+type trackedFeedItem struct {
+	minimalFeedItem
+	firstSeen    time.Time
+	scoreDelta   int
+	commentDelta int
+}
+
+func (i trackedFeedItem) FirstSeenAt() time.Time { return i.firstSeen }
+func (i trackedFeedItem) ScoreDelta() int        { return i.scoreDelta }
+func (i trackedFeedItem) CommentDelta() int      { return i.commentDelta }
+
+func TestCreateGenericFeedData_FreshnessCategories(t *testing.T) {
+	now := time.Now()
+	base := minimalFeedItem{title: "Story", link: "https://example.com/story", categories: []string{"example.com"}}
+	tests := []struct {
+		name   string
+		window time.Duration
+		item   trackedFeedItem
+		want   []string
+	}{
+		{"fresh within window", time.Hour, trackedFeedItem{minimalFeedItem: base, firstSeen: now.Add(-10 * time.Minute)}, []string{"example.com", FreshCategory}},
+		{"not fresh outside window", time.Hour, trackedFeedItem{minimalFeedItem: base, firstSeen: now.Add(-3 * time.Hour)}, []string{"example.com"}},
+		{"fresh beats updated", time.Hour, trackedFeedItem{minimalFeedItem: base, firstSeen: now.Add(-time.Minute), scoreDelta: 5}, []string{"example.com", FreshCategory}},
+		{"updated outside window", time.Hour, trackedFeedItem{minimalFeedItem: base, firstSeen: now.Add(-3 * time.Hour), commentDelta: 2}, []string{"example.com", UpdatedCategory}},
+		{"disabled", 0, trackedFeedItem{minimalFeedItem: base, firstSeen: now.Add(-time.Minute), scoreDelta: 5}, []string{"example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withOptions(t, Options{FreshnessWindow: tt.window})
+			got := createGenericFeedData([]feedtypes.FeedItem{tt.item}, Config{}, nil).Items[0].Categories
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Categories = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateOGFetcher_AcceptLanguage(t *testing.T) {
+	previous := GetOptions()
+	t.Cleanup(func() { SetOptions(previous) })
+
+	SetOptions(Options{})
+	if got := createOGFetcher(nil, Config{}).AcceptLanguage; got != opengraph.DefaultAcceptLanguage {
+		t.Fatalf("AcceptLanguage = %q, want default", got)
+	}
+	if got := createOGFetcher(nil, Config{Language: "fi"}).AcceptLanguage; got != "fi,en;q=0.5" {
+		t.Fatalf("AcceptLanguage = %q, want feed locale", got)
+	}
+
+	SetOptions(Options{OGLanguage: "sv"})
+	if got := createOGFetcher(nil, Config{Language: "fi"}).AcceptLanguage; got != "sv" {
+		t.Fatalf("AcceptLanguage = %q, want --og-lang override", got)
+	}
+}
+
+func TestCreateGenericFeedData_BackfillsMissingDates(t *testing.T) {
+	before := time.Now().Add(-time.Second)
+	items := []feedtypes.FeedItem{minimalFeedItem{title: "Undated", link: "https://example.com/undated"}}
+
+	data := createGenericFeedData(items, Config{Title: "Dates"}, nil)
+	got := data.Items[0]
+	if strings.HasPrefix(got.Updated, "0001") || strings.HasPrefix(got.Published, "0001") {
+		t.Fatalf("zero date emitted: updated=%q published=%q", got.Updated, got.Published)
+	}
+	if got.Updated != data.Updated || got.Published != data.Updated {
+		t.Errorf("updated=%q published=%q, want the feed generation time %q", got.Updated, got.Published, data.Updated)
+	}
+	published, err := time.Parse(time.RFC3339, got.Published)
+	if err != nil || published.Before(before.Truncate(time.Second)) {
+		t.Errorf("published = %q (err %v), want the current time", got.Published, err)
+	}
+}
+
+func TestGenerateAtomFeed_DefaultImage(t *testing.T) {
+	withOptions(t, Options{DefaultImage: "https://feeds.example.com/placeholder.png"})
+	items := []feedtypes.FeedItem{
+		minimalFeedItem{title: "No image", link: "https://example.com/plain"},
+		minimalFeedItem{title: "Own image", link: "https://example.com/pic", imageURL: "https://example.com/pic.jpg"},
+	}
+
+	for _, templateName := range []string{"reddit-atom", "hackernews-atom", "tildes-atom", "youtube-atom"} {
+		content, err := GenerateAtomFeedWithEmbeddedTemplate(items, templateName, Config{Title: "Images"}, nil)
+		if err != nil {
+			t.Fatalf("%s: GenerateAtomFeedWithEmbeddedTemplate() error = %v", templateName, err)
+		}
+		if got := strings.Count(content, `<media:thumbnail url="https://feeds.example.com/placeholder.png"/>`); got != 1 {
+			t.Errorf("%s: default thumbnail emitted %d times, want once for the imageless item", templateName, got)
+		}
+	}
+
+	ogData := map[string]*opengraph.Data{"https://example.com/plain": {URL: "https://example.com/plain", Image: "https://example.com/og.png"}}
+	data := createGenericFeedData(items[:1], Config{}, ogData)
+	if got := data.Items[0].DefaultThumbnail; got != "" {
+		t.Errorf("DefaultThumbnail = %q for an item with an OpenGraph image, want empty", got)
+	}
+}
+
+func TestCreateGenericFeedData_StripEmoji(t *testing.T) {
+	withOptions(t, Options{StripEmoji: true})
+
+	item := minimalFeedItem{title: "🎉 Release party → tonight", link: "https://example.com/party"}
+	got := createGenericFeedData([]feedtypes.FeedItem{item}, Config{}, nil).Items[0].Title
+	if got != "Release party → tonight" {
+		t.Fatalf("Title = %q, want emoji removed", got)
+	}
+}
+
+func TestCreateGenericFeedData_CollapseWhitespace(t *testing.T) {
+	item := minimalFeedItem{title: "  Ask HN:\tWhat are\n\nyou   working on? \n", link: "https://example.com/ask"}
+
+	withOptions(t, Options{})
+	if got := createGenericFeedData([]feedtypes.FeedItem{item}, Config{}, nil).Items[0].Title; got != item.title {
+		t.Fatalf("Title = %q, want it untouched by default", got)
+	}
+
+	withOptions(t, Options{CollapseWhitespace: true})
+	if got := createGenericFeedData([]feedtypes.FeedItem{item}, Config{}, nil).Items[0].Title; got != "Ask HN: What are you working on?" {
+		t.Fatalf("Title = %q, want single-spaced and trimmed", got)
+	}
+}
+
+func TestGenerateAtomFeed_DynamicSubtitle(t *testing.T) {
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	items := []feedtypes.FeedItem{
+		minimalFeedItem{title: "Older story", link: "https://example.com/older", createdAt: base},
+		minimalFeedItem{title: "Newest story & more", link: "https://example.com/newest", createdAt: base.Add(2 * time.Hour)},
+		minimalFeedItem{title: "Middle story", link: "https://example.com/middle", createdAt: base.Add(time.Hour)},
+	}
+	config := Config{Title: "Dynamic", Description: "Static description"}
+
+	withOptions(t, Options{})
+	if got := createGenericFeedData(items, config, nil).FeedDescription; got != "Static description" {
+		t.Fatalf("FeedDescription = %q, want the static description by default", got)
+	}
+
+	withOptions(t, Options{DynamicSubtitle: true})
+	content, err := GenerateAtomFeedWithEmbeddedTemplate(items, "hackernews-atom", config, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	if !strings.Contains(content, "<subtitle>Latest: Newest story &amp; more</subtitle>") {
+		t.Fatalf("feed subtitle should name the newest item:\n%s", content)
+	}
+
+	if got := createGenericFeedData(nil, config, nil).FeedDescription; got != "Static description" {
+		t.Fatalf("FeedDescription = %q for an empty feed, want the static description", got)
+	}
+}
+
+func TestCreateGenericFeedData_StripQuery(t *testing.T) {
+	item := minimalFeedItem{title: "Shared", link: "https://example.com/r/golang/comments/abc/shared_post/?utm_source=share&utm_medium=web2x"}
+
+	withOptions(t, Options{})
+	if got := createGenericFeedData([]feedtypes.FeedItem{item}, Config{}, nil).Items[0].Link; got != item.link {
+		t.Fatalf("Link = %q, want it untouched by default", got)
+	}
+
+	withOptions(t, Options{StripQuery: true})
+	if got := createGenericFeedData([]feedtypes.FeedItem{item}, Config{}, nil).Items[0].Link; got != "https://example.com/r/golang/comments/abc/shared_post/" {
+		t.Fatalf("Link = %q, want query removed and path kept", got)
+	}
+}
+
+func TestGenerateAtomFeed_StripQueryKeepsPreview(t *testing.T) {
+	item := minimalFeedItem{title: "Tracked", link: "https://ex.com/a?utm=1", commentsLink: "https://news.example.com/a"}
+	ogData := map[string]*opengraph.Data{
+		item.link: {URL: item.link, Title: "Preview title", Image: "https://ex.com/a.jpg"},
+	}
+
+	for _, stripQuery := range []bool{false, true} {
+		withOptions(t, Options{StripQuery: stripQuery})
+		data := createGenericFeedData([]feedtypes.FeedItem{item}, Config{Title: "Strip"}, ogData)
+		tg := NewTemplateGenerator()
+		if err := tg.LoadTemplateWithFallback("hackernews-atom"); err != nil {
+			t.Fatalf("LoadTemplateWithFallback() error = %v", err)
+		}
+		var out strings.Builder
+		if err := tg.GenerateFromTemplate("hackernews-atom", data, &out); err != nil {
+			t.Fatalf("GenerateFromTemplate() error = %v", err)
+		}
+
+		content := out.String()
+		for _, want := range []string{
+			"<h4>Preview title</h4>",
+			`<link rel="enclosure" type="image/jpeg" href="https://ex.com/a.jpg"/>`,
+		} {
+			if !strings.Contains(content, want) {
+				t.Errorf("StripQuery=%v: entry missing %s:\n%s", stripQuery, want, content)
+			}
+		}
+		if stripQuery && strings.Contains(content, "utm=1") {
+			t.Errorf("StripQuery=true: entry still links the query string:\n%s", content)
+		}
+
+		page, err := renderHTMLPage([]feedtypes.FeedItem{item}, Config{Title: "Strip"}, ogData)
+		if err != nil {
+			t.Fatalf("renderHTMLPage() error = %v", err)
+		}
+		if !strings.Contains(string(page), `<img src="https://ex.com/a.jpg"`) {
+			t.Errorf("StripQuery=%v: HTML page lost the preview image:\n%s", stripQuery, page)
+		}
+	}
+}
+
+func TestGenerateRSSAndJSONFeed_ApplyItemOptions(t *testing.T) {
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	item := minimalFeedItem{
+		title:     "Tracked",
+		link:      "https://example.com/a?utm_source=share",
+		createdAt: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+	}
+	withOptions(t, Options{StripQuery: true, Timezone: helsinki})
+
+	rss, err := GenerateRSSFeed([]feedtypes.FeedItem{item}, Config{Title: "Opts"})
+	if err != nil {
+		t.Fatalf("GenerateRSSFeed() error = %v", err)
+	}
+	if strings.Contains(string(rss), "utm_source") {
+		t.Errorf("RSS output kept the query string:\n%s", rss)
+	}
+	if !strings.Contains(string(rss), "<pubDate>Tue, 02 Jan 2024 12:00:00 +0200</pubDate>") {
+		t.Errorf("RSS pubDate not converted to Timezone:\n%s", rss)
+	}
+
+	js, err := GenerateJSONFeed([]feedtypes.FeedItem{item}, Config{Title: "Opts"})
+	if err != nil {
+		t.Fatalf("GenerateJSONFeed() error = %v", err)
+	}
+	if strings.Contains(string(js), "utm_source") {
+		t.Errorf("JSON Feed output kept the query string:\n%s", js)
+	}
+	if !strings.Contains(string(js), `"date_published": "2024-01-02T12:00:00+02:00"`) {
+		t.Errorf("JSON Feed date_published not converted to Timezone:\n%s", js)
+	}
+}
+
+func TestGenerateAtomFeed_AbbreviateCounts(t *testing.T) {
+	items := []feedtypes.FeedItem{minimalFeedItem{title: "Popular", link: "https://example.com/p", commentsLink: "https://news.example.com/p", score: 12345, comments: 1500}}
+
+	withOptions(t, Options{})
+	content, err := GenerateAtomFeedWithEmbeddedTemplate(items, "hackernews-atom", Config{Title: "Counts"}, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	if !strings.Contains(content, "<strong>Score:</strong> 12345 | <strong>Comments:</strong> 1500") {
+		t.Fatal("counts should be shown in full by default")
+	}
+
+	withOptions(t, Options{AbbreviateCounts: true})
+	content, err = GenerateAtomFeedWithEmbeddedTemplate(items, "hackernews-atom", Config{Title: "Counts"}, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	if !strings.Contains(content, "<strong>Score:</strong> 12.3k | <strong>Comments:</strong> 1.5k") {
+		t.Errorf("abbreviated counts missing from content:\n%s", content)
+	}
+	if !strings.Contains(content, `term="points:12345"`) {
+		t.Error("metadata categories should keep the exact count")
+	}
+}
+
+func TestGenerateAtomFeed_AutoDir(t *testing.T) {
+	items := []feedtypes.FeedItem{
+		minimalFeedItem{title: "مرحبا بالعالم", link: "https://example.com/ar", content: "<p>هذا نص عربي طويل</p>"},
+		minimalFeedItem{title: "שלום עולם", link: "https://example.com/he"},
+		minimalFeedItem{title: "Hello world", link: "https://example.com/en", content: "<p>Plain English</p>"},
+	}
+
+	withOptions(t, Options{})
+	content, err := GenerateAtomFeedWithEmbeddedTemplate(items, "hackernews-atom", Config{Title: "Dir"}, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	if strings.Contains(content, `dir="rtl"`) {
+		t.Fatal("dir attribute emitted without AutoDir")
+	}
+
+	withOptions(t, Options{AutoDir: true})
+	content, err = GenerateAtomFeedWithEmbeddedTemplate(items, "hackernews-atom", Config{Title: "Dir"}, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	for _, want := range []string{
+		`<title type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml" dir="rtl">مرحبا بالعالم</div></title>`,
+		`<title type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml" dir="rtl">שלום עולם</div></title>`,
+		"<title>Hello world</title>",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("feed missing %s", want)
+		}
+	}
+	if got := strings.Count(content, `<content type="html"><![CDATA[<div dir="rtl">`); got != 1 {
+		t.Errorf("got %d rtl-wrapped <content> elements, want 1 for the Arabic item", got)
+	}
+
+	// Atom has no dir attribute; direction may only appear on XHTML markup.
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("feed does not parse: %v", err)
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Space == "http://www.w3.org/2005/Atom" {
+			for _, attr := range start.Attr {
+				if attr.Name.Local == "dir" {
+					t.Errorf("Atom element <%s> has a dir attribute", start.Name.Local)
+				}
+			}
+		}
+	}
+}
+
+func TestOGFetchURLs_MaxFetches(t *testing.T) {
+	items := make([]feedtypes.FeedItem, 20)
+	for i := range items {
+		items[i] = minimalFeedItem{title: fmt.Sprintf("Item %d", i), link: fmt.Sprintf("https://example.com/%d", i)}
+	}
+
+	if got := ogFetchURLs(items, Config{}); len(got) != 20 {
+		t.Fatalf("ogFetchURLs() without cap queued %d URLs, want 20", len(got))
+	}
+
+	got := ogFetchURLs(items, Config{OpenGraphMaxFetches: 5})
+	want := []string{"https://example.com/0", "https://example.com/1", "https://example.com/2", "https://example.com/3", "https://example.com/4"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("ogFetchURLs() = %v, want the first 5 item links", got)
+	}
+}
+
+func TestGenerateAtomFeed_AuthorEmail(t *testing.T) {
+	withOptions(t, Options{})
+	items := []feedtypes.FeedItem{minimalFeedItem{title: "Post", link: "https://example.com/post"}}
+
+	content, err := GenerateAtomFeedWithEmbeddedTemplate(items, "hackernews-atom", Config{Author: "Forge", AuthorEmail: "feeds@example.com"}, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	if !strings.Contains(content, "<author><name>Forge</name><email>feeds@example.com</email></author>") {
+		t.Fatal("feed author email missing")
+	}
+
+	content, err = GenerateAtomFeedWithEmbeddedTemplate(items, "hackernews-atom", Config{Author: "Forge"}, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	if strings.Contains(content, "<email") {
+		t.Fatal("feed contains <email> without an author email configured")
+	}
+}
+
+func TestGenerateAtomFeed_GeneratorVersionAndURI(t *testing.T) {
+	withOptions(t, Options{})
+	orig := feedmeta.Version
+	feedmeta.Version = "1.2.3"
+	t.Cleanup(func() { feedmeta.Version = orig })
+
+	items := []feedtypes.FeedItem{minimalFeedItem{title: "Post", link: "https://example.com/post"}}
+	for _, templateName := range []string{"hackernews-atom", "reddit-atom", "fingerpori-atom", "feissarimokat-atom", "oglaf-atom", "tildes-atom", "youtube-atom"} {
+		content, err := GenerateAtomFeedWithEmbeddedTemplate(items, templateName, Config{Title: "Versioned"}, nil)
+		if err != nil {
+			t.Fatalf("%s: GenerateAtomFeedWithEmbeddedTemplate() error = %v", templateName, err)
+		}
+
+		var parsed struct {
+			Generator struct {
+				URI     string `xml:"uri,attr"`
+				Version string `xml:"version,attr"`
+				Name    string `xml:",chardata"`
+			} `xml:"generator"`
+		}
+		if err := xml.Unmarshal([]byte(content), &parsed); err != nil {
+			t.Fatalf("%s: parse feed: %v", templateName, err)
+		}
+		if parsed.Generator.URI != feedmeta.GeneratorURI || parsed.Generator.Version != "1.2.3" || parsed.Generator.Name != feedmeta.GeneratorName {
+			t.Errorf("%s: generator = %+v", templateName, parsed.Generator)
+		}
+	}
+}
+
+func TestGenerateAtomFeed_SelfAndHTMLLinks(t *testing.T) {
+	withOptions(t, Options{})
+	items := []feedtypes.FeedItem{minimalFeedItem{title: "Post", link: "https://example.com/post"}}
+	cfg := Config{
+		Title:   "Linked",
+		Link:    "https://news.example/",
+		SelfURL: "https://feeds.example/hn.xml",
+		HTMLURL: "https://news.example/front",
+	}
+
+	type link struct {
+		Rel  string `xml:"rel,attr"`
+		Type string `xml:"type,attr"`
+		Href string `xml:"href,attr"`
+	}
+	for _, templateName := range []string{"hackernews-atom", "reddit-atom", "fingerpori-atom", "feissarimokat-atom", "oglaf-atom", "tildes-atom", "youtube-atom"} {
+		content, err := GenerateAtomFeedWithEmbeddedTemplate(items, templateName, cfg, nil)
+		if err != nil {
+			t.Fatalf("%s: GenerateAtomFeedWithEmbeddedTemplate() error = %v", templateName, err)
+		}
+
+		var parsed struct {
+			Links []link `xml:"link"`
+		}
+		if err := xml.Unmarshal([]byte(content), &parsed); err != nil {
+			t.Fatalf("%s: parse feed: %v", templateName, err)
+		}
+		want := []link{
+			{Rel: "alternate", Type: "text/html", Href: "https://news.example/front"},
+			{Rel: "self", Type: "application/atom+xml", Href: "https://feeds.example/hn.xml"},
+		}
+		if !reflect.DeepEqual(parsed.Links, want) {
+			t.Errorf("%s: feed links = %+v, want %+v", templateName, parsed.Links, want)
+		}
+	}
+}
+
+func TestGenerateAtomFeed_NoEnclosures(t *testing.T) {
+	items := []feedtypes.FeedItem{minimalFeedItem{
+		title:    "Picture post",
+		link:     "https://example.com/post",
+		imageURL: "https://images.example/pic.jpg",
+		content:  `<img src="https://images.example/pic.jpg"/>`,
+	}}
+
+	for _, noEnclosures := range []bool{false, true} {
+		withOptions(t, Options{NoEnclosures: noEnclosures})
+		for _, templateName := range []string{"reddit-atom", "fingerpori-atom", "feissarimokat-atom", "oglaf-atom"} {
+			content, err := GenerateAtomFeedWithEmbeddedTemplate(items, templateName, Config{Title: "Pictures"}, nil)
+			if err != nil {
+				t.Fatalf("%s: GenerateAtomFeedWithEmbeddedTemplate() error = %v", templateName, err)
+			}
+			if got := strings.Contains(content, `rel="enclosure"`); got == noEnclosures {
+				t.Errorf("%s: NoEnclosures=%v, enclosure emitted = %v", templateName, noEnclosures, got)
+			}
+			if !strings.Contains(content, `<img src="https://images.example/pic.jpg"/>`) {
+				t.Errorf("%s: NoEnclosures=%v dropped the inline content image", templateName, noEnclosures)
+			}
+		}
+	}
+}
+
+type multiAuthorItem struct {
+	minimalFeedItem
+	authors []string
+}
+
+func (m multiAuthorItem) Authors() []string { return m.authors }
+
+func TestGenerateAtomFeed_MultipleAuthors(t *testing.T) {
+	items := []feedtypes.FeedItem{
+		multiAuthorItem{
+			minimalFeedItem: minimalFeedItem{title: "Co-written", link: "https://example.com/a", author: "alice"},
+			authors:         []string{"alice", "bob"},
+		},
+		minimalFeedItem{title: "Solo", link: "https://example.com/b", author: "carol"},
+	}
+
+	for _, templateName := range []string{"hackernews-atom", "reddit-atom", "readlater-atom"} {
+		content, err := GenerateAtomFeedWithEmbeddedTemplate(items, templateName, Config{Title: "Authors"}, nil)
+		if err != nil {
+			t.Fatalf("%s: GenerateAtomFeedWithEmbeddedTemplate() error = %v", templateName, err)
+		}
+		entries := strings.Split(content, "<entry>")
+		if len(entries) != 3 {
+			t.Fatalf("%s: got %d entries, want 2", templateName, len(entries)-1)
+		}
+		if got := strings.Count(entries[1], "<author>"); got != 2 {
+			t.Errorf("%s: co-written entry has %d <author> blocks, want 2", templateName, got)
+		}
+		for _, name := range []string{"<name>alice</name>", "<name>bob</name>"} {
+			if !strings.Contains(entries[1], name) {
+				t.Errorf("%s: co-written entry missing %s", templateName, name)
+			}
+		}
+		if got := strings.Count(entries[2], "<author>"); got != 1 {
+			t.Errorf("%s: single-author entry has %d <author> blocks, want 1", templateName, got)
+		}
+	}
+}
+
+type contributorItem struct {
+	minimalFeedItem
+	contributors []string
+}
+
+func (c contributorItem) Contributors() []string { return c.contributors }
+
+func TestGenerateAtomFeed_Contributors(t *testing.T) {
+	items := []feedtypes.FeedItem{
+		contributorItem{
+			minimalFeedItem: minimalFeedItem{title: "Boosted", link: "https://example.com/a", author: "alice"},
+			contributors:    []string{"bob", " ", "carol"},
+		},
+		minimalFeedItem{title: "Plain", link: "https://example.com/b", author: "dave"},
+	}
+
+	for _, templateName := range []string{"hackernews-atom", "reddit-atom", "readlater-atom", "youtube-atom"} {
+		content, err := GenerateAtomFeedWithEmbeddedTemplate(items, templateName, Config{Title: "Contributors"}, nil)
+		if err != nil {
+			t.Fatalf("%s: GenerateAtomFeedWithEmbeddedTemplate() error = %v", templateName, err)
+		}
+		entries := strings.Split(content, "<entry>")
+		if len(entries) != 3 {
+			t.Fatalf("%s: got %d entries, want 2", templateName, len(entries)-1)
+		}
+		if got := strings.Count(entries[1], "<contributor>"); got != 2 {
+			t.Errorf("%s: boosted entry has %d <contributor> blocks, want 2", templateName, got)
+		}
+		compact := strings.Join(strings.Fields(entries[1]), "")
+		for _, want := range []string{"<contributor><name>bob</name></contributor>", "<contributor><name>carol</name></contributor>"} {
+			if !strings.Contains(compact, want) {
+				t.Errorf("%s: boosted entry missing %s", templateName, want)
+			}
+		}
+		if strings.Count(entries[1], "<author>") != 1 {
+			t.Errorf("%s: contributors should not add <author> blocks", templateName)
+		}
+		if strings.Contains(entries[2], "<contributor>") {
+			t.Errorf("%s: entry without contributors has a <contributor> element", templateName)
+		}
+	}
+}
+
+func TestGenerateAtomFeed_CategoriesOnlyInCategoryElements(t *testing.T) {
+	items := []feedtypes.FeedItem{minimalFeedItem{
+		title:        "Representative post",
+		link:         "https://example.com/post",
+		commentsLink: "https://news.ycombinator.com/item?id=1",
+		author:       "alice",
+		score:        120,
+		comments:     45,
+		createdAt:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		categories:   []string{"leakcheck-alpha", "leakcheck-beta"},
+		content:      "<p>Body text</p>",
+	}}
+	textRE := regexp.MustCompile(`(?s)<summary>(.*?)</summary>|<content[^>]*>(.*?)</content>`)
+
+	for _, templateName := range []string{"hackernews-atom", "reddit-atom", "tildes-atom", "oglaf-atom", "readlater-atom"} {
+		content, err := GenerateAtomFeedWithEmbeddedTemplate(items, templateName, Config{Title: "Categories"}, nil)
+		if err != nil {
+			t.Fatalf("%s: GenerateAtomFeedWithEmbeddedTemplate() error = %v", templateName, err)
+		}
+		for _, category := range []string{"leakcheck-alpha", "leakcheck-beta"} {
+			if !strings.Contains(content, `<category term="`+category+`"`) {
+				t.Errorf("%s: missing <category> element for %s", templateName, category)
+			}
+		}
+		matches := textRE.FindAllString(content, -1)
+		if len(matches) == 0 {
+			t.Fatalf("%s: no <summary> or <content> found", templateName)
+		}
+		for _, text := range matches {
+			if strings.Contains(text, "leakcheck-") {
+				t.Errorf("%s: category text leaked into %s", templateName, text)
+			}
+		}
+	}
+}
+
+func TestGenerateAtomFeed_HackerNewsCategoryScheme(t *testing.T) {
+	items := []feedtypes.FeedItem{minimalFeedItem{
+		title:    "Post",
+		link:     "https://example.com/post",
+		score:    120,
+		comments: 45,
+		domain:   "example.com",
+	}}
+
+	content, err := GenerateAtomFeedWithEmbeddedTemplate(items, "hackernews-atom", Config{Title: "HN"}, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	if !strings.Contains(content, `<category term="points:120" label="Points: 120" scheme="hackernews-metadata"/>`) {
+		t.Errorf("default scheme missing:\n%s", content)
+	}
+
+	cfg := Config{Title: "HN", CategorySchemeURL: "https://hn.algolia.com/?query={term}"}
+	content, err = GenerateAtomFeedWithEmbeddedTemplate(items, "hackernews-atom", cfg, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	if strings.Contains(content, `scheme="hackernews-metadata"`) {
+		t.Errorf("default scheme still used with a URL template:\n%s", content)
+	}
+	for _, want := range []string{
+		`scheme="https://hn.algolia.com/?query=120"`,
+		`scheme="https://hn.algolia.com/?query=45"`,
+		`scheme="https://hn.algolia.com/?query=example.com"`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("missing %s", want)
+		}
+	}
+}
+
+func TestValidateAuthorEmail(t *testing.T) {
+	for _, email := range []string{"feeds@example.com", "first.last+rss@sub.example.org"} {
+		if err := ValidateAuthorEmail(email); err != nil {
+			t.Errorf("ValidateAuthorEmail(%q) error = %v", email, err)
+		}
+	}
+	for _, email := range []string{"not-an-email", "Forge <feeds@example.com>", "feeds@localhost", "@example.com"} {
+		if err := ValidateAuthorEmail(email); err == nil {
+			t.Errorf("ValidateAuthorEmail(%q) error = nil, want error", email)
+		}
+	}
+}
+
+func TestNoCategories_OmitsAllCategoryElements(t *testing.T) {
+	items := []feedtypes.FeedItem{minimalFeedItem{
+		title:      "Categorized",
+		link:       "https://example.com/categorized",
+		score:      120,
+		comments:   8,
+		categories: []string{"example.com", "Hot 100+"},
+	}}
+	config := Config{Title: "No categories", Link: "https://example.com/", ID: "https://example.com/"}
+	templates := []string{"hackernews-atom", "reddit-atom", "fingerpori-atom", "feissarimokat-atom", "oglaf-atom", "readlater-atom", "tildes-atom", "youtube-atom"}
+
+	withOptions(t, Options{})
+	withCategories, err := GenerateAtomFeedWithEmbeddedTemplate(items, "hackernews-atom", config, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	if !strings.Contains(withCategories, "<category") {
+		t.Fatal("default feed has no <category> elements to suppress")
+	}
+
+	withOptions(t, Options{NoCategories: true})
+	for _, name := range templates {
+		content, err := GenerateAtomFeedWithEmbeddedTemplate(items, name, config, nil)
+		if err != nil {
+			t.Fatalf("%s: GenerateAtomFeedWithEmbeddedTemplate() error = %v", name, err)
+		}
+		if strings.Contains(content, "<category") {
+			t.Errorf("%s: output contains <category with NoCategories:\n%s", name, content)
+		}
+	}
+	rss, err := GenerateRSSFeed(items, config)
+	if err != nil {
+		t.Fatalf("GenerateRSSFeed() error = %v", err)
+	}
+	if strings.Contains(string(rss), "<category") {
+		t.Errorf("RSS output contains <category with NoCategories:\n%s", rss)
+	}
+	js, err := GenerateJSONFeed(items, config)
+	if err != nil {
+		t.Fatalf("GenerateJSONFeed() error = %v", err)
+	}
+	if strings.Contains(string(js), `"tags"`) {
+		t.Errorf("JSON Feed output contains tags with NoCategories:\n%s", js)
+	}
+	if got := items[0].Categories(); len(got) != 2 {
+		t.Errorf("item categories = %v, want them kept", got)
+	}
+}
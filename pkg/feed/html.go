@@ -0,0 +1,102 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+	"github.com/lepinkainen/feed-forge/pkg/opengraph"
+)
+
+// HTMLPageTemplate is the template GenerateHTMLPage renders.
+const HTMLPageTemplate = "feed-html.tmpl"
+
+// htmlPage is the data passed to HTMLPageTemplate.
+type htmlPage struct {
+	Title       string
+	Link        string
+	Description string
+	Updated     string
+	Items       []htmlPageItem
+}
+
+// htmlPageItem is one item on the HTML page.
+type htmlPageItem struct {
+	Title        string
+	Link         string
+	CommentsLink string
+	Published    string
+	Score        string // Label and count, as FormatStat renders them
+	Comments     string
+	Thumbnail    string // Item image, else OpenGraph image, else Options.DefaultImage
+	Summary      string // Linked page's OpenGraph description
+}
+
+// GenerateHTMLPage renders items as a static, human-readable HTML page with
+// each item's title, link, score, thumbnail and summary. When ogDB is set,
+// linked pages are looked up for thumbnails and summaries as in Atom feeds.
+// MaxFeedSize limits the page as it does a feed.
+func GenerateHTMLPage(items []feedtypes.FeedItem, config Config, ogDB *opengraph.Database) ([]byte, error) {
+	var ogData map[string]*opengraph.Data
+	if ogDB != nil {
+		ogData = createOGFetcher(ogDB, config).FetchConcurrentWithContext(context.Background(), ogFetchURLs(items, config))
+	}
+	out, err := renderWithinMaxFeedSize(items, func(items []feedtypes.FeedItem) (string, error) {
+		page, err := renderHTMLPage(items, config, ogData)
+		return string(page), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out), nil
+}
+
+func renderHTMLPage(items []feedtypes.FeedItem, config Config, ogData map[string]*opengraph.Data) ([]byte, error) {
+	data := createGenericFeedData(items, config, ogData)
+
+	page := htmlPage{
+		Title:       data.FeedTitle,
+		Link:        data.FeedLink,
+		Description: data.FeedDescription,
+		Updated:     data.Updated,
+		Items:       make([]htmlPageItem, len(data.Items)),
+	}
+	for i, item := range data.Items {
+		pageItem := htmlPageItem{
+			Title:        item.Title,
+			Link:         item.Link,
+			CommentsLink: item.CommentsLink,
+			Published:    item.Published,
+			Score:        FormatStat(options.StatLabels.ScoreOr("Score:"), FormatCount(item.Score)),
+			Comments:     FormatStat(options.StatLabels.CommentsOr("Comments:"), FormatCount(item.Comments)),
+			Thumbnail:    item.ImageURL,
+		}
+		if og := data.OpenGraphData[item.Link]; og != nil {
+			if pageItem.Thumbnail == "" {
+				pageItem.Thumbnail = og.Image
+			}
+			pageItem.Summary = og.Description
+		}
+		if pageItem.Thumbnail == "" {
+			pageItem.Thumbnail = item.DefaultThumbnail
+		}
+		page.Items[i] = pageItem
+	}
+
+	tmplContent, err := ReadTemplateContent(HTMLPageTemplate)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New(HTMLPageTemplate).Parse(tmplContent)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse template %s: %w", ErrTemplateInvalid, HTMLPageTemplate, err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, page); err != nil {
+		return nil, fmt.Errorf("render %s: %w", HTMLPageTemplate, err)
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,58 @@
+package feed
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+	"github.com/lepinkainen/feed-forge/pkg/opengraph"
+)
+
+func TestRenderHTMLPage(t *testing.T) {
+	withOptions(t, Options{})
+	items := []feedtypes.FeedItem{
+		minimalFeedItem{title: "Own <image>", link: "https://example.com/a", commentsLink: "https://news.example.com/a", score: 120, comments: 45, imageURL: "https://example.com/a.jpg"},
+		minimalFeedItem{title: "OpenGraph image", link: "https://example.com/b", commentsLink: "https://news.example.com/b", score: 80, comments: 3},
+	}
+	ogData := map[string]*opengraph.Data{
+		"https://example.com/b": {URL: "https://example.com/b", Image: "https://example.com/og-b.png", Description: "A linked page & its summary"},
+	}
+
+	body, err := renderHTMLPage(items, Config{Title: "HTML Feed", Link: "https://news.example.com/"}, ogData)
+	if err != nil {
+		t.Fatalf("renderHTMLPage() error = %v", err)
+	}
+
+	content := string(body)
+	for _, want := range []string{
+		"<title>HTML Feed</title>",
+		`<a href="https://example.com/a">Own &lt;image&gt;</a>`,
+		`<img src="https://example.com/a.jpg"`,
+		"Score: 120",
+		`<a href="https://news.example.com/a">Comments: 45</a>`,
+		`<a href="https://example.com/b">OpenGraph image</a>`,
+		`<img src="https://example.com/og-b.png"`,
+		`<p class="summary">A linked page &amp; its summary</p>`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("HTML page missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestRenderHTMLPage_StatLabelsPrecedeCounts(t *testing.T) {
+	withOptions(t, Options{StatLabels: StatLabels{Score: "↑", Comments: "💬"}, AbbreviateCounts: true})
+	items := []feedtypes.FeedItem{
+		minimalFeedItem{title: "Popular", link: "https://example.com/a", score: 12345, comments: 7},
+	}
+
+	body, err := renderHTMLPage(items, Config{Title: "HTML Feed"}, nil)
+	if err != nil {
+		t.Fatalf("renderHTMLPage() error = %v", err)
+	}
+	for _, want := range []string{FormatStat("↑", "12.3k"), FormatStat("💬", "7")} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("HTML page missing %q:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package feed
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+// JSONFeedVersion is the JSON Feed spec version written by GenerateJSONFeed.
+const JSONFeedVersion = "https://jsonfeed.org/version/1.1"
+
+type jsonFeed struct {
+	Version     string           `json:"version"`
+	Title       string           `json:"title"`
+	HomePageURL string           `json:"home_page_url,omitempty"`
+	FeedURL     string           `json:"feed_url,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Language    string           `json:"language,omitempty"`
+	Authors     []jsonFeedAuthor `json:"authors,omitempty"`
+	Items       []jsonFeedItem   `json:"items"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url,omitempty"`
+	ExternalURL   string           `json:"external_url,omitempty"`
+	Title         string           `json:"title,omitempty"`
+	ContentHTML   string           `json:"content_html"`
+	Image         string           `json:"image,omitempty"`
+	DatePublished string           `json:"date_published,omitempty"`
+	Authors       []jsonFeedAuthor `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+}
+
+// GenerateJSONFeed renders items as a JSON Feed 1.1 document. Items with a
+// comments page use it as their url and the linked article as external_url.
+// Items go through createGenericFeedData like Atom entries, so the per-item
+// options apply, and MaxFeedSize is enforced as for Atom.
+func GenerateJSONFeed(items []feedtypes.FeedItem, config Config) ([]byte, error) {
+	out, err := renderWithinMaxFeedSize(items, func(items []feedtypes.FeedItem) (string, error) {
+		return renderJSONFeed(items, config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out), nil
+}
+
+func renderJSONFeed(items []feedtypes.FeedItem, config Config) (string, error) {
+	data := createGenericFeedData(items, config, nil)
+	doc := jsonFeed{
+		Version:     JSONFeedVersion,
+		Title:       config.Title,
+		HomePageURL: config.Link,
+		FeedURL:     config.SelfURL,
+		Description: data.FeedDescription,
+		Language:    config.Language,
+		Items:       make([]jsonFeedItem, len(data.Items)),
+	}
+	if config.HTMLURL != "" {
+		doc.HomePageURL = config.HTMLURL
+	}
+	if config.Author != "" {
+		doc.Authors = []jsonFeedAuthor{{Name: config.Author}}
+	}
+
+	for i, item := range data.Items {
+		entry := jsonFeedItem{
+			ID:            item.ID,
+			URL:           item.Link,
+			Title:         item.Title,
+			ContentHTML:   item.Content,
+			Image:         item.ImageURL,
+			DatePublished: item.Published,
+			Tags:          item.Categories,
+		}
+		if data.NoCategories {
+			entry.Tags = nil
+		}
+		if item.CommentsLink != "" && item.CommentsLink != item.Link {
+			entry.URL = item.CommentsLink
+			entry.ExternalURL = item.Link
+		}
+		if item.Author != "" {
+			entry.Authors = append(entry.Authors, jsonFeedAuthor{Name: item.Author, URL: item.AuthorURI})
+		}
+		for _, author := range item.CoAuthors {
+			entry.Authors = append(entry.Authors, jsonFeedAuthor{Name: author})
+		}
+		doc.Items[i] = entry
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encode JSON Feed: %w", err)
+	}
+	return string(out), nil
+}
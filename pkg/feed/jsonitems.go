@@ -0,0 +1,108 @@
+package feed
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+// JSONItem is a FeedItem stored as JSON. It lets captured items be replayed
+// through the generator without hitting provider APIs.
+type JSONItem struct {
+	ItemTitle        string    `json:"title"`
+	ItemLink         string    `json:"link"`
+	ItemCommentsLink string    `json:"comments_link,omitempty"`
+	ItemAuthor       string    `json:"author,omitempty"`
+	ItemAuthorURI    string    `json:"author_uri,omitempty"`
+	ItemScore        int       `json:"score"`
+	ItemCommentCount int       `json:"comment_count"`
+	ItemCreatedAt    time.Time `json:"created_at"`
+	ItemCategories   []string  `json:"categories,omitempty"`
+	ItemImageURL     string    `json:"image_url,omitempty"`
+	ItemContent      string    `json:"content,omitempty"`
+}
+
+var _ feedtypes.FeedItem = (*JSONItem)(nil)
+
+// Title returns the item title
+func (j *JSONItem) Title() string { return j.ItemTitle }
+
+// Link returns the item link
+func (j *JSONItem) Link() string { return j.ItemLink }
+
+// CommentsLink returns the comments link
+func (j *JSONItem) CommentsLink() string { return j.ItemCommentsLink }
+
+// Author returns the item author
+func (j *JSONItem) Author() string { return j.ItemAuthor }
+
+// AuthorURI returns the author profile URL
+func (j *JSONItem) AuthorURI() string { return j.ItemAuthorURI }
+
+// Score returns the item score
+func (j *JSONItem) Score() int { return j.ItemScore }
+
+// CommentCount returns the comment count
+func (j *JSONItem) CommentCount() int { return j.ItemCommentCount }
+
+// CreatedAt returns the creation time
+func (j *JSONItem) CreatedAt() time.Time { return j.ItemCreatedAt }
+
+// Categories returns the item categories
+func (j *JSONItem) Categories() []string { return j.ItemCategories }
+
+// ImageURL returns the item image URL
+func (j *JSONItem) ImageURL() string { return j.ItemImageURL }
+
+// Content returns the item body content
+func (j *JSONItem) Content() string { return j.ItemContent }
+
+// NewJSONItem copies the FeedItem fields of item into a JSONItem.
+func NewJSONItem(item feedtypes.FeedItem) *JSONItem {
+	j := &JSONItem{
+		ItemTitle:        item.Title(),
+		ItemLink:         item.Link(),
+		ItemCommentsLink: item.CommentsLink(),
+		ItemAuthor:       item.Author(),
+		ItemScore:        item.Score(),
+		ItemCommentCount: item.CommentCount(),
+		ItemCreatedAt:    item.CreatedAt(),
+		ItemCategories:   item.Categories(),
+		ItemImageURL:     item.ImageURL(),
+		ItemContent:      item.Content(),
+	}
+	if authorURI, ok := item.(interface{ AuthorURI() string }); ok {
+		j.ItemAuthorURI = authorURI.AuthorURI()
+	}
+	return j
+}
+
+// WriteItemsJSON writes items as an indented JSON array.
+func WriteItemsJSON(w io.Writer, items []feedtypes.FeedItem) error {
+	out := make([]*JSONItem, len(items))
+	for i, item := range items {
+		out[i] = NewJSONItem(item)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(out); err != nil {
+		return fmt.Errorf("encode items: %w", err)
+	}
+	return nil
+}
+
+// ReadItemsJSON reads a JSON array of items written by WriteItemsJSON.
+func ReadItemsJSON(r io.Reader) ([]feedtypes.FeedItem, error) {
+	var decoded []*JSONItem
+	if err := json.NewDecoder(r).Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("decode items: %w", err)
+	}
+	items := make([]feedtypes.FeedItem, len(decoded))
+	for i, item := range decoded {
+		items[i] = item
+	}
+	return items, nil
+}
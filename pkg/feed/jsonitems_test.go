@@ -0,0 +1,72 @@
+package feed
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+func TestItemsJSONRoundTripThroughGeneration(t *testing.T) {
+	original := []feedtypes.FeedItem{
+		minimalFeedItem{
+			title:        "First & foremost",
+			link:         "https://example.com/first",
+			commentsLink: "https://news.ycombinator.com/item?id=1",
+			author:       "alice",
+			score:        120,
+			comments:     30,
+			createdAt:    time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+			categories:   []string{"example.com"},
+		},
+		minimalFeedItem{
+			title:        "Second",
+			link:         "https://example.org/second",
+			commentsLink: "https://news.ycombinator.com/item?id=2",
+			author:       "bob",
+			score:        75,
+			comments:     4,
+			createdAt:    time.Date(2024, 3, 2, 8, 30, 0, 0, time.UTC),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteItemsJSON(&buf, original); err != nil {
+		t.Fatalf("WriteItemsJSON() error = %v", err)
+	}
+	replayed, err := ReadItemsJSON(&buf)
+	if err != nil {
+		t.Fatalf("ReadItemsJSON() error = %v", err)
+	}
+	if len(replayed) != len(original) {
+		t.Fatalf("ReadItemsJSON() len = %d, want %d", len(replayed), len(original))
+	}
+
+	config := Config{Title: "Replay", ID: "https://example.com/feed"}
+	want, err := GenerateAtomFeedWithEmbeddedTemplate(original, "hackernews-atom", config, nil)
+	if err != nil {
+		t.Fatalf("generate(original) error = %v", err)
+	}
+	got, err := GenerateAtomFeedWithEmbeddedTemplate(replayed, "hackernews-atom", config, nil)
+	if err != nil {
+		t.Fatalf("generate(replayed) error = %v", err)
+	}
+
+	for _, fragment := range []string{
+		"<title>First &amp; foremost</title>",
+		"<id>https://news.ycombinator.com/item?id=1</id>",
+		"<published>2024-03-02T08:30:00Z</published>",
+		"<name>bob</name>",
+		`term="points:120"`,
+		`term="example.com"`,
+	} {
+		if !strings.Contains(want, fragment) {
+			t.Fatalf("original feed missing %q", fragment)
+		}
+		if !strings.Contains(got, fragment) {
+			t.Errorf("replayed feed missing %q", fragment)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package feed
+
+import (
+	"slices"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+	"github.com/lepinkainen/feed-forge/pkg/urlutils"
+)
+
+// MergeDuplicateLinks combines items sharing a canonical URL into a single
+// item at the position of the first occurrence. The merged item keeps the
+// first item's title, links and metadata, takes the highest score and comment
+// count of the group, and concatenates their categories without repeats.
+// Items with an empty link are never merged.
+func MergeDuplicateLinks(items []feedtypes.FeedItem) []feedtypes.FeedItem {
+	index := make(map[string]int, len(items))
+	merged := make([]feedtypes.FeedItem, 0, len(items))
+	for _, item := range items {
+		key := urlutils.CanonicalURL(item.Link())
+		if key == "" {
+			merged = append(merged, item)
+			continue
+		}
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(merged)
+			merged = append(merged, item)
+			continue
+		}
+
+		m, ok := merged[i].(*mergedItem)
+		if !ok {
+			m = newMergedItem(merged[i])
+			merged[i] = m
+		}
+		m.add(item)
+	}
+	return merged
+}
+
+// mergedItem is a FeedItem synthesized from several items with the same link.
+type mergedItem struct {
+	feedtypes.FeedItem
+	score         int
+	comments      int
+	categories    []string
+	rawCategories []string
+}
+
+func newMergedItem(first feedtypes.FeedItem) *mergedItem {
+	return &mergedItem{
+		FeedItem:      first,
+		score:         first.Score(),
+		comments:      first.CommentCount(),
+		categories:    slices.Clone(first.Categories()),
+		rawCategories: slices.Clone(sourceCategories(first)),
+	}
+}
+
+// sourceCategories returns the item's RawCategories() when it has them, its
+// Categories() otherwise.
+func sourceCategories(item feedtypes.FeedItem) []string {
+	if raw, ok := item.(interface{ RawCategories() []string }); ok {
+		return raw.RawCategories()
+	}
+	return item.Categories()
+}
+
+func (m *mergedItem) add(item feedtypes.FeedItem) {
+	m.score = max(m.score, item.Score())
+	m.comments = max(m.comments, item.CommentCount())
+	for _, category := range item.Categories() {
+		if !slices.Contains(m.categories, category) {
+			m.categories = append(m.categories, category)
+		}
+	}
+	for _, category := range sourceCategories(item) {
+		if !slices.Contains(m.rawCategories, category) {
+			m.rawCategories = append(m.rawCategories, category)
+		}
+	}
+}
+
+func (m *mergedItem) Score() int           { return m.score }
+func (m *mergedItem) CommentCount() int    { return m.comments }
+func (m *mergedItem) Categories() []string { return m.categories }
+
+// RawCategories concatenates the merged items' source categories, so
+// RawCategories output matches what the items would emit on their own.
+func (m *mergedItem) RawCategories() []string { return m.rawCategories }
+
+// GUID keeps the first item's own entry ID, if it has one.
+func (m *mergedItem) GUID() string {
+	if g, ok := m.FeedItem.(feedtypes.GUIDItem); ok {
+		return g.GUID()
+	}
+	return ""
+}
+
+// TagID forwards the first item's tag URI ID, if it has one.
+func (m *mergedItem) TagID() string {
+	if t, ok := m.FeedItem.(feedtypes.TagIDItem); ok {
+		return t.TagID()
+	}
+	return ""
+}
+
+// Subreddit forwards the first item's subreddit, if it has one.
+func (m *mergedItem) Subreddit() string {
+	if s, ok := m.FeedItem.(interface{ Subreddit() string }); ok {
+		return s.Subreddit()
+	}
+	return ""
+}
+
+// ItemDomain forwards the first item's domain, if it has one.
+func (m *mergedItem) ItemDomain() string {
+	if d, ok := m.FeedItem.(interface{ ItemDomain() string }); ok {
+		return d.ItemDomain()
+	}
+	return ""
+}
+
+// AuthorURI forwards the first item's author URI, if it has one.
+func (m *mergedItem) AuthorURI() string {
+	if a, ok := m.FeedItem.(interface{ AuthorURI() string }); ok {
+		return a.AuthorURI()
+	}
+	return ""
+}
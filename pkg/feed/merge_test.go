@@ -0,0 +1,60 @@
+package feed
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+func TestMergeDuplicateLinks(t *testing.T) {
+	items := []feedtypes.FeedItem{
+		minimalFeedItem{title: "Big news", link: "https://example.com/story", commentsLink: "https://reddit.example/r/news/1", score: 120, comments: 40, categories: []string{"r/news"}, subreddit: "news"},
+		minimalFeedItem{title: "Unrelated", link: "https://example.com/other", score: 10, categories: []string{"r/golang"}},
+		minimalFeedItem{title: "Big news (xpost)", link: "https://www.example.com/story?utm_source=reddit", commentsLink: "https://reddit.example/r/worldnews/2", score: 300, comments: 25, categories: []string{"r/worldnews", "r/news"}, subreddit: "worldnews"},
+	}
+
+	merged := MergeDuplicateLinks(items)
+	if len(merged) != 2 {
+		t.Fatalf("MergeDuplicateLinks() returned %d items, want 2", len(merged))
+	}
+
+	got := merged[0]
+	if got.Title() != "Big news" || got.CommentsLink() != "https://reddit.example/r/news/1" {
+		t.Errorf("merged item = %q (%s), want the first occurrence", got.Title(), got.CommentsLink())
+	}
+	if got.Score() != 300 || got.CommentCount() != 40 {
+		t.Errorf("merged score/comments = %d/%d, want 300/40", got.Score(), got.CommentCount())
+	}
+	if want := []string{"r/news", "r/worldnews"}; !reflect.DeepEqual(got.Categories(), want) {
+		t.Errorf("merged categories = %v, want %v", got.Categories(), want)
+	}
+	if s, ok := got.(interface{ Subreddit() string }); !ok || s.Subreddit() != "news" {
+		t.Error("merged item should keep the first item's subreddit")
+	}
+	if !reflect.DeepEqual(items[0].Categories(), []string{"r/news"}) {
+		t.Errorf("source item categories modified: %v", items[0].Categories())
+	}
+	if !reflect.DeepEqual(merged[1], items[1]) {
+		t.Errorf("unique item changed: %v", merged[1])
+	}
+}
+
+func TestMergeDuplicateLinks_ForwardsRawCategories(t *testing.T) {
+	withOptions(t, Options{RawCategories: true})
+	items := []feedtypes.FeedItem{
+		rawCategoryItem{minimalFeedItem{title: "Story", link: "https://example.com/story", categories: []string{"r/news"}}, []string{"news"}},
+		rawCategoryItem{minimalFeedItem{title: "Story (xpost)", link: "https://example.com/story", categories: []string{"r/worldnews"}}, []string{"worldnews", "news"}},
+	}
+
+	merged := MergeDuplicateLinks(items)
+	if len(merged) != 1 {
+		t.Fatalf("MergeDuplicateLinks() returned %d items, want 1", len(merged))
+	}
+	if want := []string{"news", "worldnews"}; !reflect.DeepEqual(itemCategories(merged[0]), want) {
+		t.Errorf("raw categories of merged item = %v, want %v", itemCategories(merged[0]), want)
+	}
+	if want := []string{"r/news", "r/worldnews"}; !reflect.DeepEqual(merged[0].Categories(), want) {
+		t.Errorf("merged categories = %v, want %v", merged[0].Categories(), want)
+	}
+}
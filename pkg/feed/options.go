@@ -0,0 +1,201 @@
+package feed
+
+import "time"
+
+// Options holds run-wide generation settings shared by every provider. They are
+// usually populated from root CLI flags before any feed is generated.
+type Options struct {
+	// RefreshOG bypasses the OpenGraph cache and refetches every link.
+	RefreshOG bool
+	// SkipEmpty keeps an existing feed with entries instead of overwriting it
+	// with an empty one.
+	SkipEmpty bool
+	// FetchLimit is how many items providers retrieve and process. Zero uses
+	// each provider's own limit.
+	FetchLimit int
+	// FeedLimit caps how many of the fetched (filtered and sorted) items are
+	// written to the feed. Zero emits everything fetched.
+	FeedLimit int
+	// DedupeBy drops items that repeat an earlier item's key: DedupeNone
+	// (default), DedupeURL, DedupeTitle or DedupeID.
+	DedupeBy string
+	// GlobalDedupe drops items whose canonical link another feed emitted in
+	// an earlier run, within GlobalDedupeTTL (seen.DefaultTTL when zero).
+	GlobalDedupe    bool
+	GlobalDedupeTTL time.Duration
+	// OnDuplicateID selects what happens to entries whose ID repeats an
+	// earlier entry's: DuplicateIDWarn (default), DuplicateIDDrop or
+	// DuplicateIDSuffix. Duplicates are always logged.
+	OnDuplicateID string
+	// Rank re-sorts fetched items before FeedLimit is applied: RankNone
+	// (default, provider order) or RankHotness.
+	Rank string
+	// HotnessGravity is the age penalty for RankHotness. Zero uses
+	// DefaultHotnessGravity.
+	HotnessGravity float64
+	// MaxFeedSize caps the generated feed in bytes. Zero disables the cap.
+	MaxFeedSize int
+	// MaxFeedSizeAction selects what happens when a feed exceeds MaxFeedSize:
+	// MaxFeedSizeError (default) or MaxFeedSizeTrim.
+	MaxFeedSizeAction string
+	// PaywallDomains overrides the OpenGraph fetcher's paywalled domain list
+	// when non-empty.
+	PaywallDomains []string
+	// RespectRobots makes OpenGraph fetches honor robots.txt Disallow rules
+	// and Crawl-delay.
+	RespectRobots bool
+	// SiteNames adds host -> publication name entries to the OpenGraph
+	// fetcher's fallback for pages without og:site_name.
+	SiteNames map[string]string
+	// Soft404Phrases overrides the phrases that mark a fetched page as a
+	// "not found" page when non-empty.
+	Soft404Phrases []string
+	// OGDumpDir, when set, makes the OpenGraph fetcher write each fetched
+	// page's HTML and extracted data there for debugging.
+	OGDumpDir string
+	// OGMaxRedirects overrides how many redirects OpenGraph fetches follow
+	// when positive.
+	OGMaxRedirects int
+	// OGMinBodyBytes treats fetched pages smaller than this without og:* tags
+	// as failed OpenGraph fetches. Zero disables the check.
+	OGMinBodyBytes int
+	// OGMaxFetches sets Config.OpenGraphMaxFetches on every provider feed
+	// when positive.
+	OGMaxFetches int
+	// OGMemoryCache overrides how many resolved URLs each OpenGraph
+	// fetcher keeps in memory (the database cache is unaffected). Zero keeps
+	// the default.
+	OGMemoryCache int
+	// OGTimeout overrides how long a single OpenGraph page fetch may take
+	// (opengraph.DefaultRequestTimeout when zero).
+	OGTimeout time.Duration
+	// OGLanguage overrides the Accept-Language sent with OpenGraph fetches
+	// for every feed. Empty uses the feed's own locale, then English.
+	OGLanguage string
+	// InsecureTLS skips TLS certificate verification for OpenGraph fetches.
+	InsecureTLS bool
+	// InsecureTLSDomains limits skipped verification to these hosts and their
+	// subdomains. Setting it enables insecure TLS for them even without
+	// InsecureTLS.
+	InsecureTLSDomains []string
+	// DynamicSubtitle replaces the feed's <subtitle> with "Latest: " and the
+	// newest item's title.
+	DynamicSubtitle bool
+	// AuthorEmail is emitted as the feed-level author <email> for feeds that
+	// don't set their own.
+	AuthorEmail string
+	// ContentMaxChars truncates item content whose text is longer than this
+	// many characters, linking to the item to read the rest. Zero disables it.
+	ContentMaxChars int
+	// ContentMaxBytes is a hard cap on each entry's content in bytes, applied
+	// after ContentMaxChars and keeping the markup valid. Zero uses
+	// DefaultContentMaxBytes; negative disables the cap.
+	ContentMaxBytes int
+	// NoEnclosures omits rel="enclosure" image links from entries. Inline
+	// content images and media:thumbnail are kept.
+	NoEnclosures bool
+	// NoCategories omits every <category> element from Atom and RSS output,
+	// provider metadata categories included. Items keep their categories
+	// for filtering and ranking.
+	NoCategories bool
+	// DefaultImage is the media:thumbnail of items with neither their own
+	// image nor an OpenGraph image.
+	DefaultImage string
+	// Podcast drops the image enclosure of items whose linked page has
+	// og:audio, leaving the audio as their only enclosure for podcast clients.
+	Podcast bool
+	// DateFormat selects how feed and entry timestamps are written:
+	// DateFormatRFC3339NoFrac (default), DateFormatRFC3339 or
+	// DateFormatRFC3339UTC.
+	DateFormat string
+	// Timezone, when set, converts feed and entry timestamps to this zone
+	// before formatting. The CLI rejects it with DateFormatRFC3339UTC, which
+	// would write UTC regardless.
+	Timezone *time.Location
+	// StableUpdated writes each entry's <updated> as the time the item was
+	// first seen, for items that report one, so stat refreshes never change it.
+	StableUpdated bool
+	// RawCategories emits the source's category strings verbatim: items'
+	// RawCategories() where provided, and no added "paywall" category.
+	RawCategories bool
+	// CategoryAllow, when non-empty, keeps only entry categories matching
+	// one of these names (case-insensitively).
+	CategoryAllow []string
+	// CategoryBlock removes entry categories matching one of these names
+	// (case-insensitively), even if CategoryAllow lists them.
+	CategoryBlock []string
+	// ShowProvenance adds a line to link previews noting the site and how
+	// long ago the preview was fetched.
+	ShowProvenance bool
+	// AbbreviateCounts shows scores and comment counts in entry content and
+	// the preview list abbreviated, e.g. 12.3k.
+	AbbreviateCounts bool
+	// StatLabels overrides the score and comment labels in entry content and
+	// the preview list.
+	StatLabels StatLabels
+	// FreshnessWindow tags items first seen less than this long ago with
+	// FreshCategory and older items whose stats changed since the previous
+	// run with UpdatedCategory. Zero disables both.
+	FreshnessWindow time.Duration
+	// Trends writes each entry's score and comment count as ff:score and
+	// ff:comments elements and, when saving, derives score and comment
+	// deltas from those recorded in the previous output file.
+	Trends bool
+	// StripEmoji removes emoji from item titles.
+	StripEmoji bool
+	// MaxTitleLength truncates longer item titles at a word boundary with
+	// "…". Zero disables it.
+	MaxTitleLength int
+	// StripQuery removes the query string from item links.
+	StripQuery bool
+	// TagURIIDs uses RFC 4151 tag: URIs as entry IDs for items with a
+	// stable source ID, instead of their comments link.
+	TagURIIDs bool
+	// AutoDir marks titles and content written predominantly in a
+	// right-to-left script with dir="rtl" on an XHTML title or an HTML <div>
+	// around the content.
+	AutoDir bool
+	// CollapseWhitespace squeezes whitespace runs in item titles and
+	// summaries to single spaces and trims their ends.
+	CollapseWhitespace bool
+	// XMLStandalone adds standalone="yes" to the XML declaration of saved feeds.
+	XMLStandalone bool
+	// XMLBOM prefixes saved feeds with a UTF-8 byte order mark.
+	XMLBOM bool
+	// Hub is a WebSub hub URL. When set together with FeedBaseURL, feeds link
+	// to it and the hub is notified after each feed is written.
+	Hub string
+	// FeedBaseURL is the public base URL generated feeds are served from.
+	FeedBaseURL string
+	// SelfURL overrides the feed's rel="self" link. Empty derives it from
+	// FeedBaseURL when Hub is set, and omits it otherwise.
+	SelfURL string
+	// HTMLURL is the human-readable source page, emitted as the feed's
+	// rel="alternate" type="text/html" link instead of the provider default.
+	HTMLURL string
+	// Archive additionally writes monthly archive pages next to each feed.
+	Archive bool
+	// Paginate additionally writes the feed as numbered pages of PageSize
+	// items (DefaultPageSize when <= 0) linked with RFC 5005 paging links.
+	Paginate bool
+	PageSize int
+}
+
+// Actions for feeds that exceed Options.MaxFeedSize.
+const (
+	MaxFeedSizeError = "error"
+	MaxFeedSizeTrim  = "trim"
+)
+
+// options is the active run-wide configuration. Set via SetOptions.
+var options Options
+
+// SetOptions configures run-wide generation settings.
+func SetOptions(o Options) {
+	options = o
+}
+
+// GetOptions returns the run-wide generation settings.
+func GetOptions() Options {
+	return options
+}
@@ -0,0 +1,137 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+	"github.com/lepinkainen/feed-forge/pkg/filesystem"
+	"github.com/lepinkainen/feed-forge/pkg/opengraph"
+)
+
+// DefaultPageSize is the number of items per page when Options.PageSize is unset.
+const DefaultPageSize = 50
+
+// PagingLinks holds the RFC 5005 paged-feed links of one page. Empty links
+// are omitted from the output.
+type PagingLinks struct {
+	First string
+	Last  string
+	Prev  string
+	Next  string
+}
+
+// SavePagedFeedsWithEmbeddedTemplate writes the feed at outputPath and splits
+// items, in feed order, into pages of pageSize (DefaultPageSize when <= 0)
+// written next to it (feed.xml -> feed-1.xml, feed-2.xml, ...). The feed at
+// outputPath holds every item and links the first, second and last pages
+// with first/next/last so readers can find the rest; each page links the
+// first and last pages and its neighbours with first/last/previous/next.
+// Pages left over from an earlier run with more items are removed. Returns
+// the page paths, first page first.
+func SavePagedFeedsWithEmbeddedTemplate(items []feedtypes.FeedItem, templateName, outputPath string, pageSize int, config Config, ogDB *opengraph.Database) ([]string, error) {
+	pages := splitPages(items, pageSize)
+
+	paths := make([]string, len(pages))
+	for i := range pages {
+		paths[i] = pagePath(outputPath, i+1)
+	}
+
+	var head *PagingLinks
+	if len(paths) > 0 {
+		head = &PagingLinks{
+			First: filepath.Base(paths[0]),
+			Last:  filepath.Base(paths[len(paths)-1]),
+		}
+		if len(paths) > 1 {
+			head.Next = filepath.Base(paths[1])
+		}
+	}
+	if err := saveAtomFeed(context.Background(), items, templateName, outputPath, config, ogDB, func(data *TemplateData) {
+		data.Paging = head
+	}); err != nil {
+		return nil, err
+	}
+
+	for i, page := range pages {
+		links := &PagingLinks{
+			First: filepath.Base(paths[0]),
+			Last:  filepath.Base(paths[len(paths)-1]),
+		}
+		if i > 0 {
+			links.Prev = filepath.Base(paths[i-1])
+		}
+		if i < len(pages)-1 {
+			links.Next = filepath.Base(paths[i+1])
+		}
+
+		content, err := generateAtomFeed(context.Background(), page, templateName, config, ogDB, func(data *TemplateData) {
+			data.Paging = links
+		}, func(generator *TemplateGenerator) error {
+			return generator.LoadTemplateWithFallback(templateName)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("generate page %d: %w", i+1, err)
+		}
+		if err := filesystem.WriteFileAtomic(paths[i], finalizeFeedOutput(content), 0o600); err != nil {
+			return nil, fmt.Errorf("write page %d: %w", i+1, err)
+		}
+	}
+
+	if err := removeStalePages(outputPath, len(pages)); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Saved paged feeds", "pages", len(pages), "pageSize", pageSize, "outputPath", outputPath)
+	return paths, nil
+}
+
+// removeStalePages deletes the pages next to outputPath numbered above last.
+func removeStalePages(outputPath string, last int) error {
+	ext := filepath.Ext(outputPath)
+	base := strings.TrimSuffix(filepath.Base(outputPath), ext)
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(base) + `-(\d+)` + regexp.QuoteMeta(ext) + `$`)
+
+	entries, err := os.ReadDir(filepath.Dir(outputPath))
+	if err != nil {
+		return fmt.Errorf("list paged feeds: %w", err)
+	}
+	for _, entry := range entries {
+		match := pattern.FindStringSubmatch(entry.Name())
+		if match == nil || entry.IsDir() {
+			continue
+		}
+		if page, err := strconv.Atoi(match[1]); err != nil || page <= last {
+			continue
+		}
+		stale := filepath.Join(filepath.Dir(outputPath), entry.Name())
+		if err := os.Remove(stale); err != nil {
+			return fmt.Errorf("remove stale page: %w", err)
+		}
+		slog.Debug("Removed stale paged feed", "path", stale)
+	}
+	return nil
+}
+
+func splitPages(items []feedtypes.FeedItem, pageSize int) [][]feedtypes.FeedItem {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	var pages [][]feedtypes.FeedItem
+	for start := 0; start < len(items); start += pageSize {
+		pages = append(pages, items[start:min(start+pageSize, len(items))])
+	}
+	return pages
+}
+
+func pagePath(outputPath string, page int) string {
+	ext := filepath.Ext(outputPath)
+	return strings.TrimSuffix(outputPath, ext) + "-" + strconv.Itoa(page) + ext
+}
@@ -0,0 +1,147 @@
+package feed
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+func TestSavePagedFeeds_SplitsAndLinksPages(t *testing.T) {
+	items := make([]feedtypes.FeedItem, 25)
+	for i := range items {
+		items[i] = minimalFeedItem{
+			title:        fmt.Sprintf("Item %02d", i+1),
+			link:         fmt.Sprintf("https://example.com/%d", i+1),
+			commentsLink: fmt.Sprintf("https://example.com/%d", i+1),
+			createdAt:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Duration(i) * time.Hour),
+		}
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "feed.xml")
+	paths, err := SavePagedFeedsWithEmbeddedTemplate(items, "feissarimokat-atom", outputPath, 10, Config{Title: "Paged"}, nil)
+	if err != nil {
+		t.Fatalf("SavePagedFeedsWithEmbeddedTemplate() error = %v", err)
+	}
+
+	wantNames := []string{"feed-1.xml", "feed-2.xml", "feed-3.xml"}
+	wantCounts := []int{10, 10, 5}
+	if len(paths) != len(wantNames) {
+		t.Fatalf("got %d pages, want %d", len(paths), len(wantNames))
+	}
+
+	for i, path := range paths {
+		if filepath.Base(path) != wantNames[i] {
+			t.Fatalf("page %d = %s, want %s", i, filepath.Base(path), wantNames[i])
+		}
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read page: %v", err)
+		}
+		content := string(raw)
+
+		if got := strings.Count(content, "<entry>"); got != wantCounts[i] {
+			t.Errorf("%s has %d entries, want %d", wantNames[i], got, wantCounts[i])
+		}
+		for _, want := range []string{
+			`<link rel="first" href="feed-1.xml"/>`,
+			`<link rel="last" href="feed-3.xml"/>`,
+			fmt.Sprintf("Item %02d", i*10+1),
+		} {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", wantNames[i], want)
+			}
+		}
+
+		prev := `<link rel="previous"`
+		if i > 0 {
+			prev = `<link rel="previous" href="` + wantNames[i-1] + `"/>`
+		}
+		if got := strings.Contains(content, prev); got != (i > 0) {
+			t.Errorf("%s previous present = %v, want %v", wantNames[i], got, i > 0)
+		}
+
+		next := `<link rel="next"`
+		if i < len(paths)-1 {
+			next = `<link rel="next" href="` + wantNames[i+1] + `"/>`
+		}
+		if got := strings.Contains(content, next); got != (i < len(paths)-1) {
+			t.Errorf("%s next present = %v, want %v", wantNames[i], got, i < len(paths)-1)
+		}
+	}
+}
+
+func pagedItems(n int) []feedtypes.FeedItem {
+	items := make([]feedtypes.FeedItem, n)
+	for i := range items {
+		items[i] = minimalFeedItem{
+			title:        fmt.Sprintf("Item %02d", i+1),
+			link:         fmt.Sprintf("https://example.com/%d", i+1),
+			commentsLink: fmt.Sprintf("https://example.com/%d", i+1),
+			createdAt:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Duration(i) * time.Hour),
+		}
+	}
+	return items
+}
+
+func TestSavePagedFeeds_HeadFeedLinksPages(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "feed.xml")
+	if _, err := SavePagedFeedsWithEmbeddedTemplate(pagedItems(25), "feissarimokat-atom", outputPath, 10, Config{Title: "Paged"}, nil); err != nil {
+		t.Fatalf("SavePagedFeedsWithEmbeddedTemplate() error = %v", err)
+	}
+
+	raw, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("read head feed: %v", err)
+	}
+	content := string(raw)
+	for _, want := range []string{
+		`<link rel="first" href="feed-1.xml"/>`,
+		`<link rel="next" href="feed-2.xml"/>`,
+		`<link rel="last" href="feed-3.xml"/>`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("head feed missing %q", want)
+		}
+	}
+	if strings.Contains(content, `rel="previous"`) {
+		t.Error("head feed should have no previous link")
+	}
+	if got := strings.Count(content, "<entry>"); got != 25 {
+		t.Errorf("head feed has %d entries, want all 25", got)
+	}
+}
+
+func TestSavePagedFeeds_RemovesStalePages(t *testing.T) {
+	dir := t.TempDir()
+	outputPath := filepath.Join(dir, "feed.xml")
+	if _, err := SavePagedFeedsWithEmbeddedTemplate(pagedItems(35), "feissarimokat-atom", outputPath, 10, Config{Title: "Paged"}, nil); err != nil {
+		t.Fatalf("first run error = %v", err)
+	}
+	unrelated := filepath.Join(dir, "feed-2024-01.xml")
+	if err := os.WriteFile(unrelated, []byte("archive"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	paths, err := SavePagedFeedsWithEmbeddedTemplate(pagedItems(12), "feissarimokat-atom", outputPath, 10, Config{Title: "Paged"}, nil)
+	if err != nil {
+		t.Fatalf("second run error = %v", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("got %d pages, want 2", len(paths))
+	}
+	for _, name := range []string{"feed-3.xml", "feed-4.xml"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Errorf("stale page %s still exists (stat error = %v)", name, err)
+		}
+	}
+	for _, name := range []string{"feed-1.xml", "feed-2.xml", "feed-2024-01.xml"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s should remain: %v", name, err)
+		}
+	}
+}
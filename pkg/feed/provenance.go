@@ -0,0 +1,44 @@
+package feed
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/opengraph"
+)
+
+// previewProvenance describes where and when an item's link preview was
+// fetched, e.g. "Preview fetched from example.com, cached 2h ago". Empty when
+// there is no fetch time to report.
+func previewProvenance(og *opengraph.Data, now time.Time) string {
+	if og == nil || og.FetchedAt.IsZero() {
+		return ""
+	}
+	source := og.SiteName
+	if source == "" {
+		if u, err := url.Parse(og.URL); err == nil {
+			source = strings.TrimPrefix(u.Hostname(), "www.")
+		}
+	}
+	if source == "" {
+		return fmt.Sprintf("Preview cached %s", cacheAge(now.Sub(og.FetchedAt)))
+	}
+	return fmt.Sprintf("Preview fetched from %s, cached %s", source, cacheAge(now.Sub(og.FetchedAt)))
+}
+
+// cacheAge renders how long ago a preview was fetched in the largest whole
+// unit: "just now", "5m ago", "2h ago", "3d ago".
+func cacheAge(age time.Duration) string {
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age/time.Minute))
+	case age < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age/time.Hour))
+	default:
+		return fmt.Sprintf("%dd ago", int(age/(24*time.Hour)))
+	}
+}
@@ -0,0 +1,63 @@
+package feed
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+	"github.com/lepinkainen/feed-forge/pkg/opengraph"
+)
+
+func TestPreviewProvenance(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		og   *opengraph.Data
+		want string
+	}{
+		{name: "nil", og: nil, want: ""},
+		{name: "never fetched", og: &opengraph.Data{URL: "https://example.com/a"}, want: ""},
+		{name: "site name", og: &opengraph.Data{SiteName: "Example News", FetchedAt: now.Add(-2 * time.Hour)}, want: "Preview fetched from Example News, cached 2h ago"},
+		{name: "host fallback", og: &opengraph.Data{URL: "https://www.example.com/a", FetchedAt: now.Add(-5 * time.Minute)}, want: "Preview fetched from example.com, cached 5m ago"},
+		{name: "days", og: &opengraph.Data{URL: "https://example.com/a", FetchedAt: now.Add(-72 * time.Hour)}, want: "Preview fetched from example.com, cached 3d ago"},
+		{name: "fresh", og: &opengraph.Data{URL: "https://example.com/a", FetchedAt: now}, want: "Preview fetched from example.com, cached just now"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := previewProvenance(tt.og, now); got != tt.want {
+				t.Errorf("previewProvenance() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateAtomFeed_ShowProvenanceUsesCachedFetchTime(t *testing.T) {
+	item := minimalFeedItem{title: "Post", link: "https://example.com/post", commentsLink: "https://news.ycombinator.com/item?id=1"}
+	ogData := map[string]*opengraph.Data{
+		item.link: {URL: item.link, Title: "Post", SiteName: "Example", FetchedAt: time.Now().Add(-3*time.Hour - time.Minute)},
+	}
+
+	render := func() string {
+		t.Helper()
+		data := createGenericFeedData([]feedtypes.FeedItem{item}, Config{Title: "Provenance"}, ogData)
+		tg := NewTemplateGenerator()
+		if err := tg.LoadTemplateWithFallback("hackernews-atom"); err != nil {
+			t.Fatalf("LoadTemplateWithFallback() error = %v", err)
+		}
+		var out strings.Builder
+		if err := tg.GenerateFromTemplate("hackernews-atom", data, &out); err != nil {
+			t.Fatalf("GenerateFromTemplate() error = %v", err)
+		}
+		return out.String()
+	}
+
+	if strings.Contains(render(), "Preview fetched") {
+		t.Error("provenance shown without ShowProvenance")
+	}
+
+	withOptions(t, Options{ShowProvenance: true})
+	if content := render(); !strings.Contains(content, "<p><small>Preview fetched from Example, cached 3h ago</small></p>") {
+		t.Errorf("feed missing provenance line:\n%s", content)
+	}
+}
@@ -0,0 +1,51 @@
+package feed
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+// Sort modes for Options.Rank.
+const (
+	RankNone    = "none"
+	RankHotness = "hotness"
+)
+
+// DefaultHotnessGravity is the age penalty exponent used by Hacker News.
+const DefaultHotnessGravity = 1.8
+
+// Hotness scores an item HN-style: score / (ageHours + 2)^gravity, so newer
+// items can outrank older ones that had longer to collect votes.
+func Hotness(score int, createdAt time.Time, gravity float64, now time.Time) float64 {
+	ageHours := max(now.Sub(createdAt).Hours(), 0)
+	return float64(score) / math.Pow(ageHours+2, gravity)
+}
+
+// RankByHotness returns items sorted by descending Hotness. The input slice is
+// not modified and ties keep their original order.
+func RankByHotness(items []feedtypes.FeedItem, gravity float64, now time.Time) []feedtypes.FeedItem {
+	if gravity <= 0 {
+		gravity = DefaultHotnessGravity
+	}
+
+	type scored struct {
+		item    feedtypes.FeedItem
+		hotness float64
+	}
+	scoredItems := make([]scored, len(items))
+	for i, item := range items {
+		scoredItems[i] = scored{item: item, hotness: Hotness(item.Score(), item.CreatedAt(), gravity, now)}
+	}
+	sort.SliceStable(scoredItems, func(i, j int) bool {
+		return scoredItems[i].hotness > scoredItems[j].hotness
+	})
+
+	ranked := make([]feedtypes.FeedItem, len(scoredItems))
+	for i, s := range scoredItems {
+		ranked[i] = s.item
+	}
+	return ranked
+}
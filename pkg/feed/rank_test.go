@@ -0,0 +1,44 @@
+package feed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+func TestRankByHotness_NewerItemOutranksOlder(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	old := minimalFeedItem{title: "old", score: 500, createdAt: now.Add(-48 * time.Hour)}
+	fresh := minimalFeedItem{title: "fresh", score: 60, createdAt: now.Add(-1 * time.Hour)}
+	middle := minimalFeedItem{title: "middle", score: 200, createdAt: now.Add(-10 * time.Hour)}
+
+	items := []feedtypes.FeedItem{old, middle, fresh}
+	ranked := RankByHotness(items, DefaultHotnessGravity, now)
+
+	var titles []string
+	for _, item := range ranked {
+		titles = append(titles, item.Title())
+	}
+	want := []string{"fresh", "middle", "old"}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Fatalf("ranked = %v, want %v", titles, want)
+		}
+	}
+	if items[0].Title() != "old" {
+		t.Fatal("RankByHotness() modified the input slice")
+	}
+}
+
+func TestHotness(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	// Two hours old with gravity 1: 100 / (2 + 2) = 25.
+	if got := Hotness(100, now.Add(-2*time.Hour), 1, now); got != 25 {
+		t.Fatalf("Hotness() = %v, want 25", got)
+	}
+	// Items dated in the future are treated as brand new.
+	if got := Hotness(100, now.Add(time.Hour), 1, now); got != 50 {
+		t.Fatalf("Hotness(future) = %v, want 50", got)
+	}
+}
@@ -0,0 +1,41 @@
+package feed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/opengraph"
+)
+
+// ReadingWordsPerMinute is the reading speed EstimateReadingTime assumes.
+const ReadingWordsPerMinute = 200
+
+// EstimateReadingTime returns how long text takes to read at
+// ReadingWordsPerMinute, rounded up to whole minutes. Text without words
+// gives zero.
+func EstimateReadingTime(text string) time.Duration {
+	words := len(strings.Fields(text))
+	if words == 0 {
+		return 0
+	}
+	minutes := (words + ReadingWordsPerMinute - 1) / ReadingWordsPerMinute
+	return time.Duration(minutes) * time.Minute
+}
+
+// itemReadingTime estimates the reading time of an item from its content,
+// falling back to the linked page's OpenGraph description.
+func itemReadingTime(content string, og *opengraph.Data) time.Duration {
+	if text := htmlText(content); text != "" {
+		return EstimateReadingTime(text)
+	}
+	if og != nil {
+		return EstimateReadingTime(og.Description)
+	}
+	return 0
+}
+
+// formatReadingTime renders a reading time as "5 min read".
+func formatReadingTime(d time.Duration) string {
+	return fmt.Sprintf("%d min read", int(d/time.Minute))
+}
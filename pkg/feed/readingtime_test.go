@@ -0,0 +1,57 @@
+package feed
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+func TestEstimateReadingTime(t *testing.T) {
+	tests := []struct {
+		words int
+		want  time.Duration
+	}{
+		{0, 0},
+		{1, time.Minute},
+		{200, time.Minute},
+		{201, 2 * time.Minute},
+		{1000, 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		text := strings.TrimSpace(strings.Repeat("word ", tt.words))
+		if got := EstimateReadingTime(text); got != tt.want {
+			t.Errorf("EstimateReadingTime(%d words) = %v, want %v", tt.words, got, tt.want)
+		}
+	}
+	if got := EstimateReadingTime(" \n\t "); got != 0 {
+		t.Errorf("EstimateReadingTime(whitespace) = %v, want 0", got)
+	}
+}
+
+func TestGenerateAtomFeed_ReadingTime(t *testing.T) {
+	items := []feedtypes.FeedItem{
+		minimalFeedItem{
+			title:   "Long read",
+			link:    "https://example.com/long",
+			content: "<p>" + strings.Repeat("word ", 450) + "</p>",
+		},
+		minimalFeedItem{title: "Link only", link: "https://example.com/short"},
+	}
+
+	content, err := GenerateAtomFeedWithEmbeddedTemplate(items, "hackernews-atom", Config{Title: "Reading"}, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	entries := strings.Split(content, "<entry>")
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 2", len(entries)-1)
+	}
+	if !strings.Contains(entries[1], "<em>3 min read</em>") {
+		t.Errorf("long entry missing reading time:\n%s", entries[1])
+	}
+	if strings.Contains(entries[2], "min read") {
+		t.Errorf("entry without content has a reading time:\n%s", entries[2])
+	}
+}
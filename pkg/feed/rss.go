@@ -0,0 +1,105 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedmeta"
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language,omitempty"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Generator     string    `xml:"generator"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Comments    string   `xml:"comments,omitempty"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate,omitempty"`
+	Categories  []string `xml:"category"`
+	Description string   `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// GenerateRSSFeed renders items as an RSS 2.0 document. Items go through
+// createGenericFeedData like Atom entries, so the per-item options apply;
+// dates keep the Timezone and DateFormat zone in RFC 1123 form, which RSS
+// requires. MaxFeedSize is enforced as for Atom.
+func GenerateRSSFeed(items []feedtypes.FeedItem, config Config) ([]byte, error) {
+	out, err := renderWithinMaxFeedSize(items, func(items []feedtypes.FeedItem) (string, error) {
+		return renderRSSFeed(items, config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out), nil
+}
+
+func renderRSSFeed(items []feedtypes.FeedItem, config Config) (string, error) {
+	data := createGenericFeedData(items, config, nil)
+	channel := rssChannel{
+		Title:         config.Title,
+		Link:          config.Link,
+		Description:   data.FeedDescription,
+		Language:      config.Language,
+		LastBuildDate: rssDate(data.Updated),
+		Generator:     feedmeta.VersionString(),
+		Items:         make([]rssItem, len(data.Items)),
+	}
+	if config.HTMLURL != "" {
+		channel.Link = config.HTMLURL
+	}
+
+	for i, item := range data.Items {
+		entry := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			GUID:        rssGUID{Value: item.ID},
+			PubDate:     rssDate(item.Published),
+			Categories:  item.Categories,
+			Description: item.Content,
+		}
+		if data.NoCategories {
+			entry.Categories = nil
+		}
+		if item.CommentsLink != "" && item.CommentsLink != item.Link {
+			entry.Comments = item.CommentsLink
+		}
+		channel.Items[i] = entry
+	}
+
+	out, err := xml.MarshalIndent(rssDocument{Version: "2.0", Channel: channel}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encode RSS feed: %w", err)
+	}
+	return xml.Header + string(out), nil
+}
+
+// rssDate converts a timestamp formatted by formatFeedTime to the RFC 1123
+// form RSS uses, keeping its zone. Unparseable input gives "".
+func rssDate(feedTime string) string {
+	t, err := time.Parse(time.RFC3339Nano, feedTime)
+	if err != nil {
+		return ""
+	}
+	return t.Format(time.RFC1123Z)
+}
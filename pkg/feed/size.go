@@ -0,0 +1,66 @@
+package feed
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+// ErrFeedTooLarge is returned when a generated feed exceeds Options.MaxFeedSize.
+var ErrFeedTooLarge = errors.New("feed exceeds maximum size")
+
+// renderWithinMaxFeedSize renders items and, when the result exceeds
+// Options.MaxFeedSize, applies enforceMaxFeedSize to it.
+func renderWithinMaxFeedSize(items []feedtypes.FeedItem, render func([]feedtypes.FeedItem) (string, error)) (string, error) {
+	result, err := render(items)
+	if err != nil {
+		return "", err
+	}
+	if options.MaxFeedSize > 0 && len(result) > options.MaxFeedSize {
+		return enforceMaxFeedSize(result, items, render)
+	}
+	return result, nil
+}
+
+// enforceMaxFeedSize applies the configured MaxFeedSizeAction to an oversized
+// feed. Trimming drops the oldest items one at a time and re-renders until the
+// output fits.
+func enforceMaxFeedSize(content string, items []feedtypes.FeedItem, render func([]feedtypes.FeedItem) (string, error)) (string, error) {
+	if options.MaxFeedSizeAction != MaxFeedSizeTrim {
+		return "", fmt.Errorf("%w: %d bytes > %d", ErrFeedTooLarge, len(content), options.MaxFeedSize)
+	}
+
+	kept := slices.Clone(items)
+	for len(content) > options.MaxFeedSize {
+		if len(kept) == 0 {
+			return "", fmt.Errorf("%w: empty feed is %d bytes > %d", ErrFeedTooLarge, len(content), options.MaxFeedSize)
+		}
+		kept = dropOldest(kept)
+
+		var err error
+		content, err = render(kept)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	slog.Warn("Trimmed feed to fit maximum size",
+		"maxSize", options.MaxFeedSize,
+		"kept", len(kept),
+		"dropped", len(items)-len(kept))
+	return content, nil
+}
+
+// dropOldest removes the item with the earliest CreatedAt, preserving order.
+func dropOldest(items []feedtypes.FeedItem) []feedtypes.FeedItem {
+	oldest := 0
+	for i, item := range items {
+		if item.CreatedAt().Before(items[oldest].CreatedAt()) {
+			oldest = i
+		}
+	}
+	return slices.Delete(items, oldest, oldest+1)
+}
@@ -0,0 +1,90 @@
+package feed
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+func largeFeedItems(n int) []feedtypes.FeedItem {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := make([]feedtypes.FeedItem, n)
+	for i := range items {
+		items[i] = minimalFeedItem{
+			title:        fmt.Sprintf("Item %02d", i),
+			link:         fmt.Sprintf("https://example.com/%d", i),
+			commentsLink: fmt.Sprintf("https://example.com/%d", i),
+			createdAt:    base.Add(time.Duration(i) * time.Hour),
+			content:      strings.Repeat("x", 1024),
+		}
+	}
+	return items
+}
+
+func withOptions(t *testing.T, o Options) {
+	t.Helper()
+	previous := GetOptions()
+	t.Cleanup(func() { SetOptions(previous) })
+	SetOptions(o)
+}
+
+func TestGenerateAtomFeed_MaxFeedSizeError(t *testing.T) {
+	withOptions(t, Options{MaxFeedSize: 8 * 1024})
+
+	_, err := GenerateAtomFeedWithEmbeddedTemplate(largeFeedItems(40), "feissarimokat-atom", Config{Title: "Big"}, nil)
+	if !errors.Is(err, ErrFeedTooLarge) {
+		t.Fatalf("error = %v, want ErrFeedTooLarge", err)
+	}
+}
+
+func TestGenerateAtomFeed_MaxFeedSizeTrimDropsOldest(t *testing.T) {
+	const maxSize = 8 * 1024
+	withOptions(t, Options{MaxFeedSize: maxSize, MaxFeedSizeAction: MaxFeedSizeTrim})
+
+	content, err := GenerateAtomFeedWithEmbeddedTemplate(largeFeedItems(40), "feissarimokat-atom", Config{Title: "Big"}, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	if len(content) > maxSize {
+		t.Fatalf("feed size = %d, want <= %d", len(content), maxSize)
+	}
+	if !strings.Contains(content, "Item 39") {
+		t.Fatal("newest item was trimmed, want it kept")
+	}
+	if strings.Contains(content, "Item 00") {
+		t.Fatal("oldest item was kept, want it trimmed")
+	}
+}
+
+func TestGenerateRSSAndJSONFeed_MaxFeedSize(t *testing.T) {
+	const maxSize = 8 * 1024
+	generators := map[string]func([]feedtypes.FeedItem, Config) ([]byte, error){
+		"rss":  GenerateRSSFeed,
+		"json": GenerateJSONFeed,
+	}
+
+	for name, generate := range generators {
+		t.Run(name, func(t *testing.T) {
+			withOptions(t, Options{MaxFeedSize: maxSize})
+			if _, err := generate(largeFeedItems(40), Config{Title: "Big"}); !errors.Is(err, ErrFeedTooLarge) {
+				t.Fatalf("error = %v, want ErrFeedTooLarge", err)
+			}
+
+			withOptions(t, Options{MaxFeedSize: maxSize, MaxFeedSizeAction: MaxFeedSizeTrim})
+			out, err := generate(largeFeedItems(40), Config{Title: "Big"})
+			if err != nil {
+				t.Fatalf("generate() error = %v", err)
+			}
+			if len(out) > maxSize {
+				t.Fatalf("feed size = %d, want <= %d", len(out), maxSize)
+			}
+			if !strings.Contains(string(out), "Item 39") || strings.Contains(string(out), "Item 00") {
+				t.Fatalf("trim did not keep the newest items:\n%s", out)
+			}
+		})
+	}
+}
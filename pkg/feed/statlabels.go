@@ -0,0 +1,57 @@
+package feed
+
+import (
+	"strconv"
+
+	"github.com/lepinkainen/feed-forge/pkg/textutils"
+)
+
+// StatLabels are the labels shown with an item's score and comment count in
+// entry content and in the preview list. Empty fields keep each place's own
+// default ("Score:"/"Comments:" in feeds, ↑/💬 in the preview).
+type StatLabels struct {
+	Score    string
+	Comments string
+}
+
+// ScoreOr returns the score label, or def when none is configured.
+func (l StatLabels) ScoreOr(def string) string {
+	if l.Score == "" {
+		return def
+	}
+	return l.Score
+}
+
+// CommentsOr returns the comments label, or def when none is configured.
+func (l StatLabels) CommentsOr(def string) string {
+	if l.Comments == "" {
+		return def
+	}
+	return l.Comments
+}
+
+// FormatStat renders one stat as its label followed by the count. Entry
+// content, the HTML page and the preview list all order stats this way.
+func FormatStat(label, count string) string {
+	return label + " " + count
+}
+
+// scoreStat and commentsStat are the template helpers for entry content: the
+// configured label, HTML-escaped and in bold, or the template's own default,
+// followed by the count.
+func scoreStat(def string, n int) string {
+	return FormatStat("<strong>"+xmlEscape(options.StatLabels.ScoreOr(def))+"</strong>", FormatCount(n))
+}
+
+func commentsStat(def string, n int) string {
+	return FormatStat("<strong>"+xmlEscape(options.StatLabels.CommentsOr(def))+"</strong>", FormatCount(n))
+}
+
+// FormatCount renders a score or comment count for display, abbreviated
+// (12.3k) with the AbbreviateCounts option and in full otherwise.
+func FormatCount(n int) string {
+	if options.AbbreviateCounts {
+		return textutils.AbbreviateCount(n)
+	}
+	return strconv.Itoa(n)
+}
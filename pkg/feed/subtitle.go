@@ -0,0 +1,24 @@
+package feed
+
+import (
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+// dynamicSubtitle returns "Latest: <title>" for the most recently created
+// item, using its rendered title from templateItems (same order as items).
+// Undated items are skipped; ok is false when no item has a date.
+func dynamicSubtitle(items []feedtypes.FeedItem, templateItems []TemplateItem) (subtitle string, ok bool) {
+	newest := -1
+	var newestAt time.Time
+	for i, item := range items {
+		if created := item.CreatedAt(); !created.IsZero() && (newest < 0 || created.After(newestAt)) {
+			newest, newestAt = i, created
+		}
+	}
+	if newest < 0 || templateItems[newest].Title == "" {
+		return "", false
+	}
+	return "Latest: " + templateItems[newest].Title, true
+}
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/lepinkainen/feed-forge/pkg/opengraph"
 )
@@ -24,24 +25,49 @@ var (
 type TemplateGenerator struct {
 	templates map[string]*template.Template
 	funcMap   template.FuncMap
+
+	// enhancedContent renders item content when an override is loaded
+	enhancedContent *template.Template
 }
 
 // TemplateData represents the data structure passed to feed templates
 type TemplateData struct {
 	// Feed metadata
-	FeedTitle       string
-	FeedLink        string
-	FeedDescription string
-	FeedAuthor      string
-	FeedID          string
-	Updated         string
-	Generator       string
+	FeedTitle        string
+	FeedLink         string
+	FeedDescription  string
+	FeedAuthor       string
+	FeedAuthorEmail  string
+	FeedID           string
+	FeedHTMLLink     string
+	SelfLink         string
+	HubLink          string
+	Updated          string
+	Generator        string
+	GeneratorURI     string
+	GeneratorVersion string
+	NoEnclosures     bool
+	NoCategories     bool // Omit every <category> element
+	Podcast          bool // Items with audio get no image enclosure
+
+	// CategorySchemeURL is the URL template for metadata category schemes,
+	// empty to keep each template's own scheme name
+	CategorySchemeURL string
 
 	// Items
 	Items []TemplateItem
 
 	// OpenGraph data map (URL -> OpenGraph data)
 	OpenGraphData map[string]*opengraph.Data
+
+	// Extra root namespaces contributed by items (prefix -> URI)
+	ExtraNamespaces map[string]string
+
+	// RFC 5005 archive paging, set only for archive pages
+	Archive *ArchiveLinks
+
+	// RFC 5005 paged-feed links, set only for --paginate pages
+	Paging *PagingLinks
 }
 
 // TemplateItem represents a feed item for template rendering
@@ -54,14 +80,47 @@ type TemplateItem struct {
 	Published    string
 	Author       string
 	AuthorURI    string
+	CoAuthors    []string // Authors after the first, each emitted as its own <author>
+	Contributors []string // People credited besides the authors, e.g. boosters or crossposters
 	Categories   []string
 	Score        int
 	Comments     int
 	Content      string
 	Summary      string
 	ImageURL     string
+	AudioURL     string // Linked page's og:audio, emitted as an audio enclosure
+	AudioType    string
 	Subreddit    string // Reddit-specific
 	Domain       string // HN-specific
+	ExtraXML     string // Validated provider-supplied elements inserted inside <entry>
+	Paywalled    bool   // Linked page was detected as paywalled
+	ScoreDelta   int    // Score change since the previous generation
+	CommentDelta int    // Comment count change since the previous generation
+
+	// ReadingTime is the estimated reading time of the content or linked page,
+	// zero when there is no text to estimate from
+	ReadingTime time.Duration
+
+	// TitleDir and ContentDir are "rtl" when the title or content is
+	// predominantly right-to-left text, set only with the AutoDir option
+	TitleDir   string
+	ContentDir string
+
+	// Player is the linked page's twitter:player, emitted as video
+	// media:content; nil when the page has none
+	Player *opengraph.Player
+
+	// DefaultThumbnail is the configured fallback thumbnail, set only when the
+	// item has neither its own image nor an OpenGraph image
+	DefaultThumbnail string
+
+	// Provenance notes where and when the link preview was fetched, set only
+	// with the ShowProvenance option
+	Provenance string
+
+	// EnhancedContent replaces the template's built-in content markup when an
+	// enhanced-content override template is loaded
+	EnhancedContent string
 }
 
 // NewTemplateGenerator creates a new template-based feed generator
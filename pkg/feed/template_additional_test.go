@@ -11,7 +11,7 @@ import (
 	"testing/fstest"
 	"time"
 
-	"github.com/lepinkainen/feed-forge/pkg/providers"
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
 	"github.com/lepinkainen/feed-forge/pkg/testutil"
 )
 
@@ -154,7 +154,7 @@ func TestGenerateAtomFeedWithEmbeddedTemplate_Golden(t *testing.T) {
 	})
 	SetTemplateFallbackFS(fstest.MapFS{})
 
-	items := []providers.FeedItem{
+	items := []feedtypes.FeedItem{
 		&mockFeedItem{
 			title:        "Hello &amp; Goodbye",
 			link:         "https://example.com/posts/1",
@@ -222,3 +222,47 @@ func TestGenerateFromTemplate_MissingTemplate(t *testing.T) {
 		t.Fatalf("GenerateFromTemplate() error = %v, want ErrTemplateNotFound", err)
 	}
 }
+
+func TestFormatDelta(t *testing.T) {
+	if got := formatDelta(12); got != "(+12)" {
+		t.Errorf("formatDelta(12) = %q, want (+12)", got)
+	}
+	if got := formatDelta(-3); got != "(-3)" {
+		t.Errorf("formatDelta(-3) = %q, want (-3)", got)
+	}
+}
+
+func TestGenerateAtomFeed_EnhancedContentOverride(t *testing.T) {
+	withOptions(t, Options{})
+	oldOverride := GetTemplateOverrideFS()
+	t.Cleanup(func() { SetTemplateOverrideFS(oldOverride) })
+
+	SetTemplateOverrideFS(fstest.MapFS{
+		"enhanced-content.tmpl": &fstest.MapFile{Data: []byte(`<section class="custom">{{.Title | xmlEscape}} scored {{.Score}}{{if .OpenGraph}} via {{.OpenGraph.SiteName}}{{end}}</section>`)},
+	})
+
+	items := []feedtypes.FeedItem{
+		minimalFeedItem{title: "Post & more", link: "https://example.com/post", commentsLink: "https://news.example/1", score: 42},
+	}
+	for _, templateName := range []string{"hackernews-atom", "reddit-atom", "fingerpori-atom", "feissarimokat-atom", "oglaf-atom", "tildes-atom", "youtube-atom", "readlater-atom"} {
+		content, err := GenerateAtomFeedWithEmbeddedTemplate(items, templateName, Config{Title: "Custom"}, nil)
+		if err != nil {
+			t.Fatalf("%s: GenerateAtomFeedWithEmbeddedTemplate() error = %v", templateName, err)
+		}
+		if want := `<![CDATA[<section class="custom">Post &amp; more scored 42</section>]]>`; !strings.Contains(content, want) {
+			t.Errorf("%s: feed missing custom content %s", templateName, want)
+		}
+		if strings.Contains(content, "<strong>Score:</strong>") {
+			t.Errorf("%s: built-in content markup still emitted", templateName)
+		}
+	}
+
+	SetTemplateOverrideFS(fstest.MapFS{})
+	content, err := GenerateAtomFeedWithEmbeddedTemplate(items, "reddit-atom", Config{Title: "Built-in"}, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	if !strings.Contains(content, "<strong>Score:</strong> 42") {
+		t.Error("feed without an override should use the built-in content markup")
+	}
+}
@@ -2,6 +2,7 @@ package feed
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 	"text/template"
 	"time"
@@ -10,14 +11,20 @@ import (
 // TemplateFuncs returns a map of template helper functions
 func TemplateFuncs() template.FuncMap {
 	return template.FuncMap{
-		"xmlEscape":   xmlEscape,
-		"formatTime":  formatTime,
-		"formatDate":  formatDate,
-		"formatScore": formatScore,
-		"joinStrings": strings.Join,
-		"contains":    strings.Contains,
-		"hasPrefix":   strings.HasPrefix,
-		"truncate":    truncateText,
+		"xmlEscape":      xmlEscape,
+		"formatTime":     formatTime,
+		"formatDate":     formatDate,
+		"formatScore":    formatScore,
+		"joinStrings":    strings.Join,
+		"contains":       strings.Contains,
+		"hasPrefix":      strings.HasPrefix,
+		"truncate":       truncateText,
+		"formatDelta":    formatDelta,
+		"scoreStat":      scoreStat,
+		"commentsStat":   commentsStat,
+		"formatCount":    FormatCount,
+		"readingTime":    formatReadingTime,
+		"categoryScheme": categoryScheme,
 	}
 }
 
@@ -91,3 +98,22 @@ func truncateText(s string, maxLen int) string {
 	}
 	return s[:maxLen-3] + "..."
 }
+
+// CategorySchemeTerm is replaced with the category value in
+// Config.CategorySchemeURL templates.
+const CategorySchemeTerm = "{term}"
+
+// categoryScheme returns the XML-escaped scheme for a metadata category: the
+// URL template with CategorySchemeTerm filled in from value, or def when no
+// template is configured.
+func categoryScheme(urlTemplate, def string, value any) string {
+	if urlTemplate == "" {
+		return xmlEscape(def)
+	}
+	return xmlEscape(strings.ReplaceAll(urlTemplate, CategorySchemeTerm, url.QueryEscape(fmt.Sprint(value))))
+}
+
+// formatDelta formats a change in count as "(+12)" or "(-3)"
+func formatDelta(delta int) string {
+	return fmt.Sprintf("(%+d)", delta)
+}
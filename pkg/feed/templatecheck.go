@@ -0,0 +1,108 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+	"github.com/lepinkainen/feed-forge/pkg/opengraph"
+)
+
+// sampleTemplateData builds TemplateData from a couple of synthetic items and
+// their OpenGraph previews, so templates can be rendered without network
+// access.
+func sampleTemplateData() *TemplateData {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	items := []feedtypes.FeedItem{
+		&JSONItem{
+			ItemTitle:        "Sample story with a preview",
+			ItemLink:         "https://example.com/articles/sample",
+			ItemCommentsLink: "https://news.example.com/item?id=1",
+			ItemAuthor:       "alice",
+			ItemScore:        321,
+			ItemCommentCount: 42,
+			ItemCreatedAt:    created,
+			ItemCategories:   []string{"example.com", "Hot 200+"},
+			ItemImageURL:     "https://example.com/images/sample.jpg",
+		},
+		&JSONItem{
+			ItemTitle:        "Sample text post",
+			ItemLink:         "https://news.example.com/item?id=2",
+			ItemCommentsLink: "https://news.example.com/item?id=2",
+			ItemAuthor:       "bob",
+			ItemScore:        12,
+			ItemCommentCount: 3,
+			ItemCreatedAt:    created.Add(-time.Hour),
+			ItemCategories:   []string{"Ask"},
+			ItemContent:      "<p>Body of a text post with <em>markup</em>.</p>",
+		},
+	}
+	ogData := map[string]*opengraph.Data{
+		"https://example.com/articles/sample": {
+			URL:         "https://example.com/articles/sample",
+			Title:       "Sample article title",
+			Description: "A description taken from the article's og:description.",
+			Image:       "https://example.com/images/og.jpg",
+			SiteName:    "Example News",
+		},
+	}
+	config := Config{
+		Title:       "Template check",
+		Link:        "https://news.example.com/",
+		Description: "Sample feed rendered by template check",
+		Author:      "feed-forge",
+		ID:          "https://news.example.com/",
+	}
+	return createGenericFeedData(items, config, ogData)
+}
+
+// CheckTemplate loads the named template (override file or embedded) and
+// renders it against synthetic items and OpenGraph data without any network
+// access, writing the output to w. Parse and execution errors are returned,
+// as is output that looks like XML but isn't well-formed; nothing is written
+// to w on error.
+func CheckTemplate(name string, w io.Writer) error {
+	generator := NewTemplateGenerator()
+	if err := generator.LoadTemplateWithFallback(name); err != nil {
+		return err
+	}
+	if err := generator.LoadEnhancedContentTemplate(); err != nil {
+		return err
+	}
+
+	data := sampleTemplateData()
+	if err := generator.renderEnhancedContent(data); err != nil {
+		return err
+	}
+	var out bytes.Buffer
+	if err := generator.GenerateFromTemplate(name, data, &out); err != nil {
+		return err
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(out.Bytes()), []byte("<?xml")) {
+		if err := checkWellFormed(out.Bytes()); err != nil {
+			return fmt.Errorf("%w: template %s renders malformed XML: %w", ErrTemplateInvalid, name, err)
+		}
+	}
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// checkWellFormed reports the first XML syntax error in doc.
+func checkWellFormed(doc []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(doc))
+	for {
+		_, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package feed
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func withTemplateOverride(t *testing.T, files fstest.MapFS) {
+	t.Helper()
+	previous := GetTemplateOverrideFS()
+	SetTemplateOverrideFS(files)
+	t.Cleanup(func() { SetTemplateOverrideFS(previous) })
+}
+
+func TestCheckTemplate_RendersSampleEntries(t *testing.T) {
+	withTemplateOverride(t, fstest.MapFS{})
+
+	var out strings.Builder
+	if err := CheckTemplate("hackernews-atom", &out); err != nil {
+		t.Fatalf("CheckTemplate() error = %v", err)
+	}
+	got := out.String()
+	for _, want := range []string{"<entry>", "Sample story with a preview", "Sample text post", "Sample article title"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rendered template missing %q", want)
+		}
+	}
+}
+
+func TestCheckTemplate_BrokenTemplates(t *testing.T) {
+	withTemplateOverride(t, fstest.MapFS{
+		"unclosed-atom.tmpl":     {Data: []byte(`<?xml version="1.0"?><feed>{{.FeedTitle`)},
+		"bad-field-atom.tmpl":    {Data: []byte(`<?xml version="1.0"?><feed>{{range .Items}}{{.NoSuchField}}{{end}}</feed>`)},
+		"malformed-atom.tmpl":    {Data: []byte(`<?xml version="1.0"?><feed><title>{{.FeedTitle}}</feed>`)},
+		"custom-valid-atom.tmpl": {Data: []byte(`<?xml version="1.0"?><feed>{{range .Items}}<entry>{{.Title | xmlEscape}}</entry>{{end}}</feed>`)},
+	})
+
+	tests := []struct {
+		name    string
+		wantErr string
+		is      error
+	}{
+		{"unclosed-atom", "failed to parse template unclosed-atom", ErrTemplateInvalid},
+		{"bad-field-atom", "NoSuchField", nil},
+		{"malformed-atom", "renders malformed XML", ErrTemplateInvalid},
+		{"missing-atom", "missing-atom", ErrTemplateNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			err := CheckTemplate(tt.name, &out)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("CheckTemplate() error = %v, want one mentioning %q", err, tt.wantErr)
+			}
+			if tt.is != nil && !errors.Is(err, tt.is) {
+				t.Errorf("CheckTemplate() error = %v, want errors.Is %v", err, tt.is)
+			}
+			if out.Len() != 0 {
+				t.Errorf("CheckTemplate() wrote %d bytes on error", out.Len())
+			}
+		})
+	}
+
+	var out strings.Builder
+	if err := CheckTemplate("custom-valid-atom", &out); err != nil {
+		t.Fatalf("CheckTemplate(custom-valid-atom) error = %v", err)
+	}
+	if strings.Count(out.String(), "<entry>") != 2 {
+		t.Errorf("custom template output = %s, want two sample entries", out.String())
+	}
+}
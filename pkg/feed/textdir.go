@@ -0,0 +1,27 @@
+package feed
+
+import (
+	"github.com/lepinkainen/feed-forge/pkg/opengraph"
+	"github.com/lepinkainen/feed-forge/pkg/textutils"
+)
+
+// DirRTL is the dir attribute value of right-to-left text.
+const DirRTL = "rtl"
+
+// itemTextDirs returns DirRTL for the title and content that are
+// predominantly right-to-left, and "" otherwise. Content without text falls
+// back to the linked page's OpenGraph description, which the templates show
+// in its place.
+func itemTextDirs(title, content string, og *opengraph.Data) (titleDir, contentDir string) {
+	if textutils.IsRTL(title) {
+		titleDir = DirRTL
+	}
+	text := htmlText(content)
+	if text == "" && og != nil {
+		text = og.Description
+	}
+	if textutils.IsRTL(text) {
+		contentDir = DirRTL
+	}
+	return titleDir, contentDir
+}
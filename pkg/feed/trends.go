@@ -0,0 +1,74 @@
+package feed
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// StatsNamespace is the XML namespace of the ff:score and ff:comments
+// elements written with the Trends option.
+const (
+	StatsNamespace = "https://github.com/lepinkainen/feed-forge/ns/stats"
+	statsPrefix    = "ff"
+)
+
+// EntryStats are the score and comment count an earlier run recorded for an
+// entry.
+type EntryStats struct {
+	Score    int
+	Comments int
+}
+
+// statsXML returns the ff:score and ff:comments elements for an entry.
+func statsXML(score, comments int) string {
+	return fmt.Sprintf("<%[1]s:score>%[2]d</%[1]s:score><%[1]s:comments>%[3]d</%[1]s:comments>", statsPrefix, score, comments)
+}
+
+// ReadFeedStats reads the ff:score and ff:comments elements of the Atom feed
+// at path, keyed by entry ID. Entries without a score are skipped, and a
+// missing file gives an empty map.
+func ReadFeedStats(path string) (map[string]EntryStats, error) {
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]EntryStats{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read previous feed: %w", err)
+	}
+
+	var parsed struct {
+		Entries []struct {
+			ID       string `xml:"http://www.w3.org/2005/Atom id"`
+			Score    *int   `xml:"https://github.com/lepinkainen/feed-forge/ns/stats score"`
+			Comments int    `xml:"https://github.com/lepinkainen/feed-forge/ns/stats comments"`
+		} `xml:"http://www.w3.org/2005/Atom entry"`
+	}
+	if err := xml.Unmarshal(contents, &parsed); err != nil {
+		return nil, fmt.Errorf("parse previous feed: %w", err)
+	}
+
+	stats := make(map[string]EntryStats, len(parsed.Entries))
+	for _, entry := range parsed.Entries {
+		if entry.ID == "" || entry.Score == nil {
+			continue
+		}
+		stats[entry.ID] = EntryStats{Score: *entry.Score, Comments: entry.Comments}
+	}
+	return stats, nil
+}
+
+// applyTrendDeltas fills the score and comment deltas of entries that have
+// none from their provider, comparing against previous by entry ID.
+func applyTrendDeltas(data *TemplateData, previous map[string]EntryStats) {
+	for i := range data.Items {
+		item := &data.Items[i]
+		prev, ok := previous[item.ID]
+		if !ok || item.ScoreDelta != 0 || item.CommentDelta != 0 {
+			continue
+		}
+		item.ScoreDelta = item.Score - prev.Score
+		item.CommentDelta = item.Comments - prev.Comments
+	}
+}
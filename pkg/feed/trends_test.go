@@ -0,0 +1,77 @@
+package feed
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+func TestTrends_RoundTripDelta(t *testing.T) {
+	withOptions(t, Options{Trends: true})
+	outfile := filepath.Join(t.TempDir(), "reddit.xml")
+	config := Config{Title: "Trends", Link: "https://example.com/", ID: "https://example.com/"}
+	item := func(score, comments int) []feedtypes.FeedItem {
+		return []feedtypes.FeedItem{&JSONItem{
+			ItemTitle:        "Trending post",
+			ItemLink:         "https://example.com/post",
+			ItemCommentsLink: "https://reddit.example/comments/abc",
+			ItemCreatedAt:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			ItemScore:        score,
+			ItemCommentCount: comments,
+		}}
+	}
+
+	if err := SaveAtomFeedToFileWithEmbeddedTemplate(item(10, 4), "reddit-atom", outfile, config, nil); err != nil {
+		t.Fatalf("first save error = %v", err)
+	}
+	first := readFileString(t, outfile)
+	if !strings.Contains(first, `xmlns:ff="`+StatsNamespace+`"`) || !strings.Contains(first, "<ff:score>10</ff:score><ff:comments>4</ff:comments>") {
+		t.Fatalf("first feed missing ff stats:\n%s", first)
+	}
+	if strings.Contains(first, "(+") {
+		t.Fatalf("first feed shows a delta without a previous run:\n%s", first)
+	}
+
+	stats, err := ReadFeedStats(outfile)
+	if err != nil {
+		t.Fatalf("ReadFeedStats() error = %v", err)
+	}
+	if len(stats) != 1 {
+		t.Fatalf("ReadFeedStats() = %v, want one entry", stats)
+	}
+	for _, got := range stats {
+		if got != (EntryStats{Score: 10, Comments: 4}) {
+			t.Fatalf("ReadFeedStats() entry = %+v, want score 10, comments 4", got)
+		}
+	}
+
+	if err := SaveAtomFeedToFileWithEmbeddedTemplate(item(25, 3), "reddit-atom", outfile, config, nil); err != nil {
+		t.Fatalf("second save error = %v", err)
+	}
+	second := readFileString(t, outfile)
+	for _, want := range []string{"(+15)", "(-1)", "<ff:score>25</ff:score>"} {
+		if !strings.Contains(second, want) {
+			t.Errorf("second feed missing %q:\n%s", want, second)
+		}
+	}
+}
+
+func TestReadFeedStats_MissingFile(t *testing.T) {
+	stats, err := ReadFeedStats(filepath.Join(t.TempDir(), "missing.xml"))
+	if err != nil || len(stats) != 0 {
+		t.Fatalf("ReadFeedStats(missing) = %v, %v; want empty map and nil error", stats, err)
+	}
+}
+
+func readFileString(t *testing.T, path string) string {
+	t.Helper()
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile(%s) error = %v", path, err)
+	}
+	return string(contents)
+}
@@ -0,0 +1,195 @@
+package feed
+
+import (
+	"html"
+	"slices"
+	"strings"
+	"unicode"
+
+	nethtml "golang.org/x/net/html"
+)
+
+// truncateContent shortens HTML content whose visible text is longer than
+// maxChars. The text is cut at the last word boundary before the limit and
+// followed by a "(read more)" link to link. Markup is dropped from truncated
+// content, since cutting through it could leave tags unbalanced. Content within
+// the limit, or maxChars <= 0, is returned unchanged.
+func truncateContent(content, link string, maxChars int) string {
+	if maxChars <= 0 {
+		return content
+	}
+
+	text := []rune(htmlText(content))
+	if len(text) <= maxChars {
+		return content
+	}
+
+	cut := text[:maxChars]
+	if !unicode.IsSpace(text[maxChars]) {
+		if i := lastSpace(cut); i > 0 {
+			cut = cut[:i]
+		}
+	}
+	truncated := strings.TrimRightFunc(string(cut), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	})
+
+	return "<p>" + html.EscapeString(truncated) + "… <a href=\"" + html.EscapeString(link) + "\">(read more)</a></p>"
+}
+
+// truncateTitle shortens title to at most maxChars characters, cutting at the
+// last word boundary that leaves room for a trailing "…". Titles within the
+// limit, or maxChars <= 0, are returned unchanged.
+func truncateTitle(title string, maxChars int) string {
+	runes := []rune(title)
+	if maxChars <= 0 || len(runes) <= maxChars {
+		return title
+	}
+
+	cut := runes[:maxChars-1]
+	if !unicode.IsSpace(runes[maxChars-1]) {
+		if i := lastSpace(cut); i > 0 {
+			cut = cut[:i]
+		}
+	}
+	return strings.TrimRightFunc(string(cut), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + "…"
+}
+
+// DefaultContentMaxBytes is the per-entry content cap used when
+// Options.ContentMaxBytes is zero.
+const DefaultContentMaxBytes = 64 * 1024
+
+// capContentBytes enforces a hard size limit on HTML content: content longer
+// than maxBytes is cut at a tag or UTF-8 character boundary, elements left open
+// are closed and a notice linking to link is appended, all within maxBytes.
+// Unlike truncateContent it keeps the markup. maxBytes < 0 disables the cap
+// and zero uses DefaultContentMaxBytes.
+func capContentBytes(content, link string, maxBytes int) string {
+	if maxBytes == 0 {
+		maxBytes = DefaultContentMaxBytes
+	}
+	if maxBytes < 0 || len(content) <= maxBytes {
+		return content
+	}
+
+	notice := "<p>… <a href=\"" + html.EscapeString(link) + "\">(content truncated, read more)</a></p>"
+	if len(notice) > maxBytes {
+		// No room for the notice: cut the content alone.
+		notice = ""
+	}
+	budget := maxBytes - len(notice)
+	var b strings.Builder
+	var open []string
+	closingLen := 0 // bytes needed to close everything in open
+
+	tokenizer := nethtml.NewTokenizer(strings.NewReader(content))
+	for {
+		tt := tokenizer.Next()
+		if tt == nethtml.ErrorToken {
+			break
+		}
+		raw := string(tokenizer.Raw())
+		name, _ := tokenizer.TagName()
+		tag := string(name)
+
+		switch tt {
+		case nethtml.StartTagToken:
+			if voidElements[tag] {
+				break
+			}
+			if b.Len()+len(raw)+closingLen+len(tag)+3 > budget {
+				return closeContent(&b, open, notice)
+			}
+			b.WriteString(raw)
+			open = append(open, tag)
+			closingLen += len(tag) + 3
+			continue
+		case nethtml.EndTagToken:
+			if i := lastIndex(open, tag); i >= 0 {
+				// Closing tags are already budgeted for; close any unclosed children too.
+				for _, child := range slices.Backward(open[i:]) {
+					b.WriteString("</" + child + ">")
+					closingLen -= len(child) + 3
+				}
+				open = open[:i]
+			}
+			continue
+		case nethtml.TextToken:
+			if b.Len()+len(raw)+closingLen > budget {
+				b.WriteString(cutText(string(tokenizer.Text()), budget-b.Len()-closingLen))
+				return closeContent(&b, open, notice)
+			}
+		}
+		if b.Len()+len(raw)+closingLen > budget {
+			return closeContent(&b, open, notice)
+		}
+		b.WriteString(raw)
+	}
+	return closeContent(&b, open, notice)
+}
+
+// closeContent closes the open elements, innermost first, and appends notice.
+func closeContent(b *strings.Builder, open []string, notice string) string {
+	for _, tag := range slices.Backward(open) {
+		b.WriteString("</" + tag + ">")
+	}
+	b.WriteString(notice)
+	return b.String()
+}
+
+// cutText escapes as much of text as fits in maxBytes, stopping at a
+// character boundary.
+func cutText(text string, maxBytes int) string {
+	var b strings.Builder
+	for _, r := range text {
+		escaped := html.EscapeString(string(r))
+		if b.Len()+len(escaped) > maxBytes {
+			break
+		}
+		b.WriteString(escaped)
+	}
+	return b.String()
+}
+
+func lastIndex(tags []string, tag string) int {
+	for i := len(tags) - 1; i >= 0; i-- {
+		if tags[i] == tag {
+			return i
+		}
+	}
+	return -1
+}
+
+// voidElements are HTML elements that never have a closing tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// htmlText returns the visible text of an HTML fragment with whitespace
+// collapsed to single spaces.
+func htmlText(s string) string {
+	var b strings.Builder
+	tokenizer := nethtml.NewTokenizer(strings.NewReader(s))
+	for {
+		switch tokenizer.Next() {
+		case nethtml.ErrorToken:
+			return strings.Join(strings.Fields(b.String()), " ")
+		case nethtml.TextToken:
+			b.Write(tokenizer.Text())
+		case nethtml.StartTagToken, nethtml.EndTagToken, nethtml.SelfClosingTagToken:
+			b.WriteByte(' ')
+		}
+	}
+}
+
+func lastSpace(runes []rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if unicode.IsSpace(runes[i]) {
+			return i
+		}
+	}
+	return -1
+}
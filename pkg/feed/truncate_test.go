@@ -0,0 +1,172 @@
+package feed
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+func TestTruncateContent(t *testing.T) {
+	const link = "https://www.reddit.com/r/golang/comments/abc/post/"
+	content := "<p>The quick <b>brown</b> fox jumps over the lazy dog</p>"
+
+	tests := []struct {
+		name     string
+		maxChars int
+		want     string
+	}{
+		{name: "disabled", maxChars: 0, want: content},
+		{name: "fits", maxChars: 100, want: content},
+		{name: "mid word backs up to boundary", maxChars: 12, want: `<p>The quick… <a href="` + link + `">(read more)</a></p>`},
+		{name: "exact word boundary", maxChars: 15, want: `<p>The quick brown… <a href="` + link + `">(read more)</a></p>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateContent(content, link, tt.maxChars); got != tt.want {
+				t.Fatalf("truncateContent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateContent_EscapesText(t *testing.T) {
+	got := truncateContent("<p>Fish &amp; chips &lt;3 forever and ever</p>", "https://example.com/?a=1&b=2", 15)
+	want := `<p>Fish &amp; chips &lt;3… <a href="https://example.com/?a=1&amp;b=2">(read more)</a></p>`
+	if got != want {
+		t.Fatalf("truncateContent() = %q, want %q", got, want)
+	}
+}
+
+func TestCreateGenericFeedData_ContentMaxChars(t *testing.T) {
+	withOptions(t, Options{ContentMaxChars: 20})
+	item := minimalFeedItem{title: "Long selftext", link: "https://example.com/post", content: strings.Repeat("word ", 100)}
+
+	data := createGenericFeedData([]feedtypes.FeedItem{item}, Config{}, nil)
+	got := data.Items[0].Content
+	if !strings.HasPrefix(got, "<p>word word word word… ") {
+		t.Fatalf("Content = %q, want truncation at a word boundary", got)
+	}
+	if !strings.Contains(got, `<a href="https://example.com/post">(read more)</a>`) {
+		t.Fatalf("Content = %q, want read-more link to the item", got)
+	}
+}
+
+func TestCapContentBytes(t *testing.T) {
+	const link = "https://example.com/post"
+	content := "<div><p>Fish &amp; chips</p><p>Ääkköset <b>bold</b> tail</p><br>" + strings.Repeat("<p>more</p>", 20) + "</div>"
+
+	if got := capContentBytes(content, link, len(content)); got != content {
+		t.Fatalf("capContentBytes() within the cap = %q, want unchanged", got)
+	}
+	if got := capContentBytes(content, link, -1); got != content {
+		t.Fatalf("capContentBytes() disabled = %q, want unchanged", got)
+	}
+
+	notice := `<p>… <a href="` + link + `">(content truncated, read more)</a></p>`
+	got := capContentBytes(content, link, 48+len(notice))
+	want := `<div><p>Fish &amp; chips</p><p>Ääkk</p></div>` + notice
+	if got != want {
+		t.Fatalf("capContentBytes() = %q, want %q", got, want)
+	}
+}
+
+func TestCapContentBytes_NeverExceedsCap(t *testing.T) {
+	const link = "https://example.com/post?a=1&b=2"
+	content := "<div><p>Fish &amp; chips</p><p>Ääkköset <b>bold</b> tail</p><br/><!-- note -->" + strings.Repeat("<p>more <i>text</i></p>", 10) + "</div>"
+
+	for maxBytes := 1; maxBytes < len(content); maxBytes++ {
+		got := capContentBytes(content, link, maxBytes)
+		if len(got) > maxBytes {
+			t.Fatalf("capContentBytes(%d) = %d bytes, want at most %d: %q", maxBytes, len(got), maxBytes, got)
+		}
+		if !utf8.ValidString(got) {
+			t.Fatalf("capContentBytes(%d) = %q, not valid UTF-8", maxBytes, got)
+		}
+		if err := xml.Unmarshal([]byte("<root>"+got+"</root>"), new(any)); err != nil {
+			t.Fatalf("capContentBytes(%d) = %q, not well-formed: %v", maxBytes, got, err)
+		}
+	}
+}
+
+func TestCreateGenericFeedData_CapsHugeContent(t *testing.T) {
+	withOptions(t, Options{})
+	var blob strings.Builder
+	blob.WriteString("<div><p>")
+	for blob.Len() < 100*1024 {
+		blob.WriteString("Ünïcödé <i>text</i> &amp; more ")
+	}
+	blob.WriteString("</p></div>")
+	item := minimalFeedItem{title: "Huge", link: "https://example.com/huge", content: blob.String()}
+
+	got := createGenericFeedData([]feedtypes.FeedItem{item}, Config{}, nil).Items[0].Content
+	if len(got) > DefaultContentMaxBytes {
+		t.Fatalf("len(Content) = %d, want at most %d", len(got), DefaultContentMaxBytes)
+	}
+	if !utf8.ValidString(got) {
+		t.Fatal("Content is not valid UTF-8")
+	}
+	if !strings.HasSuffix(got, `</p></div><p>… <a href="https://example.com/huge">(content truncated, read more)</a></p>`) {
+		t.Fatalf("Content ends %q, want closed elements and truncation notice", got[len(got)-120:])
+	}
+	if err := xml.Unmarshal([]byte("<root>"+got+"</root>"), new(any)); err != nil {
+		t.Fatalf("capped content is not well-formed: %v", err)
+	}
+
+	content, err := GenerateAtomFeedWithEmbeddedTemplate([]feedtypes.FeedItem{item}, "reddit-atom", Config{Title: "Huge"}, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	if err := xml.Unmarshal([]byte(content), new(struct{})); err != nil {
+		t.Fatalf("feed with capped content does not parse: %v", err)
+	}
+}
+
+func TestTruncateTitle(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		max   int
+		want  string
+	}{
+		{"no limit", "A fairly long headline about things", 0, "A fairly long headline about things"},
+		{"short title untouched", "Short title", 20, "Short title"},
+		{"exact length untouched", "Exactly ten", 11, "Exactly ten"},
+		{"cut at word boundary", "A fairly long headline about things", 20, "A fairly long…"},
+		{"boundary at limit", "Hello world again", 12, "Hello world…"},
+		{"trailing punctuation dropped", "First part, second part", 14, "First part…"},
+		{"single long word", "Supercalifragilistic", 10, "Supercali…"},
+		{"multibyte", "Ääkköset ja öljyt ovat hienoja", 15, "Ääkköset ja…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateTitle(tt.title, tt.max)
+			if got != tt.want {
+				t.Errorf("truncateTitle(%q, %d) = %q, want %q", tt.title, tt.max, got, tt.want)
+			}
+			if tt.max > 0 && utf8.RuneCountInString(got) > tt.max {
+				t.Errorf("truncateTitle(%q, %d) has %d characters", tt.title, tt.max, utf8.RuneCountInString(got))
+			}
+		})
+	}
+}
+
+func TestCreateGenericFeedData_MaxTitleLength(t *testing.T) {
+	withOptions(t, Options{MaxTitleLength: 20})
+
+	items := []feedtypes.FeedItem{
+		minimalFeedItem{title: "A fairly long headline about things", link: "https://example.com/1"},
+		minimalFeedItem{title: "Short title", link: "https://example.com/2"},
+	}
+	data := createGenericFeedData(items, Config{}, nil)
+
+	if got := data.Items[0].Title; got != "A fairly long…" {
+		t.Errorf("long Title = %q, want %q", got, "A fairly long…")
+	}
+	if got := data.Items[1].Title; got != "Short title" {
+		t.Errorf("short Title = %q, want unchanged", got)
+	}
+}
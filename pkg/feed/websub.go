@@ -0,0 +1,33 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+
+	"github.com/lepinkainen/feed-forge/pkg/api"
+)
+
+// FeedSelfURL returns the public URL of outfile under baseURL, used as the
+// WebSub topic and the feed's rel="self" link.
+func FeedSelfURL(baseURL, outfile string) (string, error) {
+	selfURL, err := url.JoinPath(baseURL, filepath.Base(outfile))
+	if err != nil {
+		return "", fmt.Errorf("build feed URL from %q: %w", baseURL, err)
+	}
+	return selfURL, nil
+}
+
+// NotifyHub tells a WebSub hub that topicURL has new content, so subscribers
+// are pushed the update instead of waiting for their next poll.
+func NotifyHub(ctx context.Context, client *api.EnhancedClient, hubURL, topicURL string) error {
+	form := url.Values{
+		"hub.mode": {"publish"},
+		"hub.url":  {topicURL},
+	}
+	if err := client.PostForm(ctx, hubURL, form, nil); err != nil {
+		return fmt.Errorf("notify hub %s: %w", hubURL, err)
+	}
+	return nil
+}
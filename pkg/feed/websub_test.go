@@ -0,0 +1,95 @@
+package feed
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/api"
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+func TestNotifyHub_PostsPublishForm(t *testing.T) {
+	var attempts int
+	var gotMode, gotURL, gotContentType string
+	hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		gotContentType = r.Header.Get("Content-Type")
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm() error = %v", err)
+		}
+		gotMode = r.PostForm.Get("hub.mode")
+		gotURL = r.PostForm.Get("hub.url")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer hub.Close()
+
+	client := api.NewEnhancedClient(&api.EnhancedClientConfig{
+		RetryPolicy: &api.RetryPolicy{
+			MaxAttempts:       2,
+			InitialBackoff:    time.Millisecond,
+			MaxBackoff:        time.Millisecond,
+			BackoffMultiplier: 1,
+			RetryableErrors:   []int{http.StatusServiceUnavailable},
+		},
+	})
+
+	if err := NotifyHub(context.Background(), client, hub.URL, "https://feeds.example/hackernews.xml"); err != nil {
+		t.Fatalf("NotifyHub() error = %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("hub saw %d attempts, want 2 (one retry)", attempts)
+	}
+	if gotContentType != "application/x-www-form-urlencoded" {
+		t.Errorf("Content-Type = %q", gotContentType)
+	}
+	if gotMode != "publish" || gotURL != "https://feeds.example/hackernews.xml" {
+		t.Errorf("form = hub.mode %q hub.url %q", gotMode, gotURL)
+	}
+}
+
+func TestFeedSelfURL(t *testing.T) {
+	got, err := FeedSelfURL("https://feeds.example/rss/", "/srv/out/hackernews.xml")
+	if err != nil {
+		t.Fatalf("FeedSelfURL() error = %v", err)
+	}
+	if got != "https://feeds.example/rss/hackernews.xml" {
+		t.Fatalf("FeedSelfURL() = %q", got)
+	}
+}
+
+func TestGenerateAtomFeed_HubLinks(t *testing.T) {
+	withOptions(t, Options{Hub: "https://hub.example/"})
+	items := []feedtypes.FeedItem{minimalFeedItem{title: "Post", link: "https://example.com/post"}}
+
+	content, err := GenerateAtomFeedWithEmbeddedTemplate(items, "hackernews-atom", Config{SelfURL: "https://feeds.example/hn.xml"}, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	for _, want := range []string{
+		`<link rel="self" type="application/atom+xml" href="https://feeds.example/hn.xml"/>`,
+		`<link rel="hub" href="https://hub.example/"/>`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("feed missing %s", want)
+		}
+	}
+
+	content, err = GenerateAtomFeedWithEmbeddedTemplate(items, "hackernews-atom", Config{}, nil)
+	if err != nil {
+		t.Fatalf("GenerateAtomFeedWithEmbeddedTemplate() error = %v", err)
+	}
+	if strings.Contains(content, `rel="hub"`) {
+		t.Error("hub link emitted without a self link")
+	}
+}
@@ -0,0 +1,22 @@
+package feed
+
+import "strings"
+
+const (
+	xmlDeclaration           = `<?xml version="1.0" encoding="UTF-8"?>`
+	xmlStandaloneDeclaration = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`
+	utf8BOM                  = "\uFEFF"
+)
+
+// finalizeFeedOutput applies the save-time XML options to a rendered feed:
+// Options.XMLStandalone marks the declaration standalone="yes" and
+// Options.XMLBOM prefixes a UTF-8 byte order mark. Both default to off.
+func finalizeFeedOutput(content string) []byte {
+	if options.XMLStandalone && strings.HasPrefix(content, xmlDeclaration) {
+		content = xmlStandaloneDeclaration + strings.TrimPrefix(content, xmlDeclaration)
+	}
+	if options.XMLBOM {
+		content = utf8BOM + content
+	}
+	return []byte(content)
+}
@@ -0,0 +1,46 @@
+package feed
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func saveTestFeed(t *testing.T) string {
+	t.Helper()
+	outputPath := filepath.Join(t.TempDir(), "feed.xml")
+	if err := SaveAtomFeedToFileWithEmbeddedTemplate(largeFeedItems(1), "feissarimokat-atom", outputPath, Config{Title: "Decl"}, nil); err != nil {
+		t.Fatalf("SaveAtomFeedToFileWithEmbeddedTemplate() error = %v", err)
+	}
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("read feed: %v", err)
+	}
+	return string(content)
+}
+
+func TestSaveAtomFeed_DefaultDeclaration(t *testing.T) {
+	withOptions(t, Options{})
+
+	content := saveTestFeed(t)
+	if !strings.HasPrefix(content, xmlDeclaration) {
+		t.Fatalf("feed starts with %q, want plain declaration", content[:60])
+	}
+	if strings.Contains(content, "standalone") {
+		t.Fatal("feed declares standalone without --xml-standalone")
+	}
+}
+
+func TestSaveAtomFeed_StandaloneAndBOM(t *testing.T) {
+	withOptions(t, Options{XMLStandalone: true})
+	if content := saveTestFeed(t); !strings.HasPrefix(content, xmlStandaloneDeclaration) {
+		t.Fatalf("feed starts with %q, want standalone declaration", content[:60])
+	}
+
+	withOptions(t, Options{XMLBOM: true})
+	content := saveTestFeed(t)
+	if !strings.HasPrefix(content, "\xEF\xBB\xBF"+xmlDeclaration) {
+		t.Fatalf("feed starts with %q, want UTF-8 BOM then declaration", content[:60])
+	}
+}
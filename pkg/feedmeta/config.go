@@ -6,7 +6,20 @@ type Config struct {
 	Link        string
 	Description string
 	Author      string
+	AuthorEmail string // Optional feed author email, emitted as <email> when set
 	ID          string
+	SelfURL     string // Optional public URL of the feed itself, emitted as rel="self"
+	HTMLURL     string // Optional human-readable source page, emitted as rel="alternate" type="text/html"
 	ProxyURL    string // Optional proxy URL for fetching OG data from blocked domains
 	ProxySecret string // Shared secret for proxy authentication
+	Language    string // Optional feed locale (e.g. "fi"), used for OpenGraph Accept-Language
+
+	// CategorySchemeURL optionally replaces the provider's metadata <category>
+	// scheme with a URL; "{term}" is replaced with the query-escaped value
+	CategorySchemeURL string
+
+	// OpenGraphMaxFetches caps how many linked pages get OpenGraph lookups,
+	// taking them in feed order; items past the cap render without a link
+	// preview. Zero means no cap
+	OpenGraphMaxFetches int
 }
@@ -0,0 +1,16 @@
+package feedmeta
+
+// Version is the feed-forge release version, overridden at build time with
+// -ldflags "-X github.com/lepinkainen/feed-forge/pkg/feedmeta.Version=X.Y.Z".
+var Version = "dev"
+
+// Generator identity emitted in the Atom <generator> element.
+const (
+	GeneratorName = "Feed Forge"
+	GeneratorURI  = "https://github.com/lepinkainen/feed-forge"
+)
+
+// VersionString returns the human-readable generator name and version.
+func VersionString() string {
+	return GeneratorName + " " + Version
+}
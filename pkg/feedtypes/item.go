@@ -0,0 +1,82 @@
+// Package feedtypes defines the feed item contract shared by providers, feed
+// generation and preview. It has no dependencies on other feed-forge packages,
+// so new providers and renderers can depend on it alone.
+package feedtypes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FeedItem defines the essential fields for any feed entry.
+type FeedItem interface {
+	Title() string
+	Link() string
+	CommentsLink() string
+	Author() string
+	Score() int
+	CommentCount() int
+	CreatedAt() time.Time
+	Categories() []string
+	ImageURL() string
+	Content() string
+}
+
+// GUIDItem is implemented by feed items that provide their own stable entry ID.
+type GUIDItem interface {
+	GUID() string
+}
+
+// TagIDItem is implemented by feed items with a stable source ID usable as
+// the specific part of an RFC 4151 tag URI, e.g. "item/12345".
+type TagIDItem interface {
+	TagID() string
+}
+
+// TagURI builds an RFC 4151 tag URI from the host that minted the ID, a date
+// it held that host and the item's stable ID:
+// tag:news.ycombinator.com,2024:item/12345. Only the year of date is used,
+// and a leading "www." is dropped from host.
+func TagURI(host string, date time.Time, specific string) string {
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+	return "tag:" + host + "," + strconv.Itoa(date.UTC().Year()) + ":" + specific
+}
+
+// ItemTagURI returns the tag URI of an item implementing TagIDItem, built
+// from the host of its comments link (or link) and the year it was created.
+// ok is false when the item has no tag ID, host or creation time.
+func ItemTagURI(item FeedItem) (uri string, ok bool) {
+	t, isTagItem := item.(TagIDItem)
+	if !isTagItem || t.TagID() == "" || item.CreatedAt().IsZero() {
+		return "", false
+	}
+	source := item.CommentsLink()
+	if source == "" {
+		source = item.Link()
+	}
+	u, err := url.Parse(source)
+	if err != nil || u.Hostname() == "" {
+		return "", false
+	}
+	return TagURI(u.Hostname(), item.CreatedAt(), t.TagID()), true
+}
+
+// ItemGUID returns a stable entry ID for item. Items implementing GUIDItem
+// win; otherwise the comments link is used, and items without one get an ID
+// derived from the namespace (usually the feed ID), link and creation time.
+func ItemGUID(item FeedItem, namespace string) string {
+	if g, ok := item.(GUIDItem); ok {
+		if guid := g.GUID(); guid != "" {
+			return guid
+		}
+	}
+	if commentsLink := item.CommentsLink(); commentsLink != "" {
+		return commentsLink
+	}
+	sum := sha256.Sum256([]byte(namespace + "\n" + item.Link() + "\n" + item.CreatedAt().UTC().Format(time.RFC3339)))
+	return "urn:feed-forge:" + hex.EncodeToString(sum[:16])
+}
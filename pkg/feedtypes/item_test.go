@@ -0,0 +1,97 @@
+package feedtypes
+
+import (
+	"testing"
+	"time"
+)
+
+type testItem struct {
+	link         string
+	commentsLink string
+	createdAt    time.Time
+}
+
+func (i testItem) Title() string        { return "" }
+func (i testItem) Link() string         { return i.link }
+func (i testItem) CommentsLink() string { return i.commentsLink }
+func (i testItem) Author() string       { return "" }
+func (i testItem) Score() int           { return 0 }
+func (i testItem) CommentCount() int    { return 0 }
+func (i testItem) CreatedAt() time.Time { return i.createdAt }
+func (i testItem) Categories() []string { return nil }
+func (i testItem) ImageURL() string     { return "" }
+func (i testItem) Content() string      { return "" }
+
+type guidItem struct {
+	testItem
+	guid string
+}
+
+func (g guidItem) GUID() string { return g.guid }
+
+func TestItemGUID(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	noComments := testItem{link: "https://example.com/comic/1", createdAt: created}
+
+	first := ItemGUID(noComments, "https://example.com/feed")
+	if first == "" {
+		t.Fatal("ItemGUID() = empty, want derived id for item without comments link")
+	}
+	if again := ItemGUID(testItem{link: "https://example.com/comic/1", createdAt: created}, "https://example.com/feed"); again != first {
+		t.Fatalf("ItemGUID() not stable: %q != %q", again, first)
+	}
+	if other := ItemGUID(noComments, "https://other.example/feed"); other == first {
+		t.Fatalf("ItemGUID() = %q for different namespace, want distinct id", other)
+	}
+
+	withComments := testItem{link: "https://example.com/post", commentsLink: "https://example.com/comments/1"}
+	if got := ItemGUID(withComments, "ns"); got != "https://example.com/comments/1" {
+		t.Fatalf("ItemGUID() = %q, want comments link", got)
+	}
+
+	custom := guidItem{testItem: withComments, guid: "custom-id"}
+	if got := ItemGUID(custom, "ns"); got != "custom-id" {
+		t.Fatalf("ItemGUID() = %q, want custom GUID", got)
+	}
+}
+
+type tagItem struct {
+	testItem
+	tagID string
+}
+
+func (g tagItem) TagID() string { return g.tagID }
+
+func TestTagURI(t *testing.T) {
+	date := time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC)
+	if got := TagURI("WWW.Reddit.com", date, "comments/abc123"); got != "tag:reddit.com,2024:comments/abc123" {
+		t.Fatalf("TagURI() = %q", got)
+	}
+}
+
+func TestItemTagURI(t *testing.T) {
+	created := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	item := tagItem{
+		testItem: testItem{link: "https://example.com/story", commentsLink: "https://news.ycombinator.com/item?id=12345", createdAt: created},
+		tagID:    "item/12345",
+	}
+	got, ok := ItemTagURI(item)
+	if !ok || got != "tag:news.ycombinator.com,2024:item/12345" {
+		t.Fatalf("ItemTagURI() = %q, %v", got, ok)
+	}
+
+	noComments := item
+	noComments.commentsLink = ""
+	if got, _ := ItemTagURI(noComments); got != "tag:example.com,2024:item/12345" {
+		t.Fatalf("ItemTagURI() without comments link = %q, want link host", got)
+	}
+
+	if _, ok := ItemTagURI(item.testItem); ok {
+		t.Fatal("ItemTagURI() ok for item without TagID")
+	}
+	undated := item
+	undated.createdAt = time.Time{}
+	if _, ok := ItemTagURI(undated); ok {
+		t.Fatal("ItemTagURI() ok for item without creation time")
+	}
+}
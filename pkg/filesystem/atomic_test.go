@@ -0,0 +1,83 @@
+package filesystem
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomic_ReplacesAndKeepsMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feed.xml")
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFileAtomic(path, []byte("<feed/>"), 0o600); err != nil {
+		t.Fatalf("WriteFileAtomic() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "<feed/>" {
+		t.Errorf("content = %q, want %q", got, "<feed/>")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o644 {
+		t.Errorf("mode = %o, want existing 644 kept", info.Mode().Perm())
+	}
+}
+
+func TestWriteFileAtomic_NewFileUsesPerm(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feed.xml")
+	if err := WriteFileAtomic(path, []byte("<feed/>"), 0o640); err != nil {
+		t.Fatalf("WriteFileAtomic() error = %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o640 {
+		t.Errorf("mode = %o, want 640", info.Mode().Perm())
+	}
+}
+
+func TestWriteFileAtomic_InterruptedWriteKeepsOldFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feed.xml")
+	if err := os.WriteFile(path, []byte("<feed>old</feed>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Write half of the new content, then fail as if the process died.
+	original := writeTempContent
+	writeTempContent = func(f *os.File, data []byte) error {
+		_, _ = f.Write(data[:len(data)/2])
+		return errors.New("killed")
+	}
+	t.Cleanup(func() { writeTempContent = original })
+
+	if err := WriteFileAtomic(path, []byte("<feed>new content</feed>"), 0o600); err == nil {
+		t.Fatal("WriteFileAtomic() error = nil, want the write failure")
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "<feed>old</feed>" {
+		t.Errorf("content = %q, want the old feed untouched", got)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only feed.xml (temp file left behind)", len(entries))
+	}
+}
@@ -19,6 +19,18 @@ import (
 // ErrNotModified signals that upstream returned HTTP 304 Not Modified.
 var ErrNotModified = errors.New("upstream not modified")
 
+// ignoreValidators makes conditional GETs send no stored validators, so
+// upstream always returns a full body. Set via SetIgnoreValidators.
+var ignoreValidators bool
+
+// SetIgnoreValidators makes CachedGet and CachedGetWithStale skip the stored
+// ETag/Last-Modified validators, forcing a full fetch instead of a possible
+// 304 that would keep the previously generated feed. Responses still update
+// the store.
+func SetIgnoreValidators(ignore bool) {
+	ignoreValidators = ignore
+}
+
 // CachedGet performs a conditional GET using validators from store.
 func CachedGet(ctx context.Context, client *api.EnhancedClient, store *Store, url string, headers map[string]string) ([]byte, error) {
 	if client == nil {
@@ -26,7 +38,7 @@ func CachedGet(ctx context.Context, client *api.EnhancedClient, store *Store, ur
 	}
 
 	var prev api.CacheValidators
-	if store != nil {
+	if store != nil && !ignoreValidators {
 		if validators, ok := store.GetContext(ctx, url); ok {
 			prev = validators
 		}
@@ -65,7 +77,7 @@ func CachedGetWithStale(ctx context.Context, client *api.EnhancedClient, store *
 	}
 
 	var prev api.CacheValidators
-	if store != nil {
+	if store != nil && !ignoreValidators {
 		if validators, ok := store.GetContext(ctx, url); ok {
 			prev = validators
 		}
@@ -381,3 +381,41 @@ func TestStoreConcurrentAccess(t *testing.T) {
 		}
 	}
 }
+
+func TestCachedGetIgnoreValidatorsForcesFullFetch(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "http_cache.db"))
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte("fresh"))
+	}))
+	defer server.Close()
+
+	client := api.NewEnhancedClient(&api.EnhancedClientConfig{
+		RetryPolicy: &api.RetryPolicy{MaxAttempts: 1, RetryableErrors: []int{}},
+		RateLimiter: api.NewNoOpRateLimiter(),
+	})
+
+	if _, err := CachedGet(t.Context(), client, store, server.URL, nil); err != nil {
+		t.Fatalf("CachedGet(first) error = %v", err)
+	}
+
+	SetIgnoreValidators(true)
+	t.Cleanup(func() { SetIgnoreValidators(false) })
+
+	body, err := CachedGet(t.Context(), client, store, server.URL, nil)
+	if err != nil {
+		t.Fatalf("CachedGet(ignoring validators) error = %v, want a full fetch", err)
+	}
+	if string(body) != "fresh" {
+		t.Fatalf("body = %q, want fresh", body)
+	}
+}
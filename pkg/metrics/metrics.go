@@ -0,0 +1,149 @@
+// Package metrics keeps in-process counters and gauges and exposes them in
+// the Prometheus text exposition format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ContentType is the media type of the text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Registry holds metric families keyed by name.
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]*Vec
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*Vec)}
+}
+
+// Vec is a counter or gauge family with a single label.
+type Vec struct {
+	registry *Registry
+	name     string
+	help     string
+	kind     string
+	label    string
+	values   map[string]float64
+}
+
+// Counter registers a counter family labelled by label.
+func (r *Registry) Counter(name, help, label string) *Vec {
+	return r.register(name, help, "counter", label)
+}
+
+// Gauge registers a gauge family labelled by label.
+func (r *Registry) Gauge(name, help, label string) *Vec {
+	return r.register(name, help, "gauge", label)
+}
+
+func (r *Registry) register(name, help, kind, label string) *Vec {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if v, ok := r.families[name]; ok {
+		return v
+	}
+	v := &Vec{registry: r, name: name, help: help, kind: kind, label: label, values: make(map[string]float64)}
+	r.families[name] = v
+	return v
+}
+
+// Inc adds one to the series for labelValue.
+func (v *Vec) Inc(labelValue string) {
+	v.Add(labelValue, 1)
+}
+
+// Add adds delta to the series for labelValue.
+func (v *Vec) Add(labelValue string, delta float64) {
+	v.registry.mu.Lock()
+	defer v.registry.mu.Unlock()
+	v.values[labelValue] += delta
+}
+
+// Set sets the series for labelValue to value.
+func (v *Vec) Set(labelValue string, value float64) {
+	v.registry.mu.Lock()
+	defer v.registry.mu.Unlock()
+	v.values[labelValue] = value
+}
+
+// Value returns the current value of the series for labelValue.
+func (v *Vec) Value(labelValue string) float64 {
+	v.registry.mu.Lock()
+	defer v.registry.mu.Unlock()
+	return v.values[labelValue]
+}
+
+// WriteText writes every family in the text exposition format, sorted by
+// metric name and label value. Families without series still get their HELP
+// and TYPE lines so scrapers see them from the start.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bw := bufio.NewWriter(w)
+	for _, name := range names {
+		v := r.families[name]
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s %s\n", name, v.help, name, v.kind)
+
+		labelValues := make([]string, 0, len(v.values))
+		for labelValue := range v.values {
+			labelValues = append(labelValues, labelValue)
+		}
+		sort.Strings(labelValues)
+		for _, labelValue := range labelValues {
+			fmt.Fprintf(bw, "%s{%s=\"%s\"} %s\n", name, v.label, escapeLabel(labelValue),
+				strconv.FormatFloat(v.values[labelValue], 'g', -1, 64))
+		}
+	}
+	return bw.Flush()
+}
+
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// Handler serves the registry's metrics for Prometheus to scrape.
+func Handler(r *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		if err := r.WriteText(w); err != nil {
+			slog.Warn("Failed to write metrics", "error", err)
+		}
+	})
+}
+
+// Default is the registry the serve and generation paths report to.
+var Default = NewRegistry()
+
+// Metrics recorded in Default, labelled by the feed's provider name.
+var (
+	Requests          = Default.Counter("feed_forge_requests_total", "Feed requests served.", "feed")
+	CacheHits         = Default.Counter("feed_forge_cache_hits_total", "Feed requests answered with 304 Not Modified.", "feed")
+	CacheMisses       = Default.Counter("feed_forge_cache_misses_total", "Feed requests that rendered the feed.", "feed")
+	UpstreamErrors    = Default.Counter("feed_forge_upstream_errors_total", "Failed fetches of feed items from upstream.", "feed")
+	GenerationSeconds = Default.Gauge("feed_forge_last_generation_duration_seconds", "Duration of the most recent feed generation.", "feed")
+)
+
+// ObserveGeneration records how long the latest generation of feed took.
+func ObserveGeneration(feed string, d time.Duration) {
+	GenerationSeconds.Set(feed, d.Seconds())
+}
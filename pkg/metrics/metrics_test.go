@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegistry_WriteText(t *testing.T) {
+	r := NewRegistry()
+	hits := r.Counter("test_hits_total", "Hits.", "feed")
+	last := r.Gauge("test_last_seconds", "Last duration.", "feed")
+	r.Counter("test_empty_total", "Never incremented.", "feed")
+
+	hits.Inc("b")
+	hits.Add("a", 2)
+	hits.Inc(`quoted "name"`)
+	last.Set("a", 1.5)
+	last.Set("a", 0.25)
+
+	var sb strings.Builder
+	if err := r.WriteText(&sb); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	want := `# HELP test_empty_total Never incremented.
+# TYPE test_empty_total counter
+# HELP test_hits_total Hits.
+# TYPE test_hits_total counter
+test_hits_total{feed="a"} 2
+test_hits_total{feed="b"} 1
+test_hits_total{feed="quoted \"name\""} 1
+# HELP test_last_seconds Last duration.
+# TYPE test_last_seconds gauge
+test_last_seconds{feed="a"} 0.25
+`
+	if got := sb.String(); got != want {
+		t.Errorf("WriteText() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRegistry_RegisterReturnsExistingFamily(t *testing.T) {
+	r := NewRegistry()
+	r.Counter("dup_total", "First.", "feed").Inc("x")
+	if got := r.Counter("dup_total", "Second.", "feed").Value("x"); got != 1 {
+		t.Errorf("Value() = %v, want 1 from the existing family", got)
+	}
+}
@@ -5,6 +5,15 @@ import (
 	"time"
 )
 
+// memoryCached returns data already resolved for targetURL by this fetcher.
+func (f *Fetcher) memoryCached(targetURL string) *Data {
+	return f.cache.get(targetURL)
+}
+
+func (f *Fetcher) storeMemory(targetURL string, data *Data) {
+	f.cache.add(targetURL, data, f.MemoryCacheEntries)
+}
+
 func (f *Fetcher) lookupCachedData(targetURL string) (cached *Data, expired *Data, skip bool) {
 	if f.db == nil {
 		return nil, nil, false
@@ -2,8 +2,10 @@ package opengraph
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"sync"
+	"time"
 )
 
 // FetchConcurrent fetches OpenGraph data for multiple URLs concurrently.
@@ -24,13 +26,16 @@ func (f *Fetcher) FetchConcurrentWithContext(ctx context.Context, urls []string)
 
 	results := make(chan result, len(urls))
 	var wg sync.WaitGroup
+	start := time.Now()
 
 	slog.Debug("Starting concurrent OpenGraph fetch", "total_urls", len(urls))
 
+	total := 0
 	for _, targetURL := range urls {
 		if targetURL == "" {
 			continue
 		}
+		total++
 
 		wg.Add(1)
 		go func(url string) {
@@ -59,12 +64,21 @@ func (f *Fetcher) FetchConcurrentWithContext(ctx context.Context, urls []string)
 	}()
 
 	dataMap := make(map[string]*Data)
+	completed := 0
 	for res := range results {
+		completed++
 		if res.data != nil {
 			dataMap[res.url] = res.data
 		}
+		if f.ProgressInterval > 0 && completed%f.ProgressInterval == 0 && completed < total {
+			slog.Info("OpenGraph fetch progress", "progress", fmt.Sprintf("fetched %d/%d", completed, total))
+		}
 	}
 
-	slog.Debug("Completed concurrent OpenGraph fetch", "successful_fetches", len(dataMap))
+	slog.Info("Completed concurrent OpenGraph fetch",
+		"total", total,
+		"successful", len(dataMap),
+		"empty", total-len(dataMap),
+		"elapsed", time.Since(start).Round(time.Millisecond))
 	return dataMap
 }
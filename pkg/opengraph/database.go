@@ -9,6 +9,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/lepinkainen/feed-forge/pkg/database"
 	"github.com/lepinkainen/feed-forge/pkg/dbinterfaces"
 	"github.com/lepinkainen/feed-forge/pkg/filesystem"
 	_ "modernc.org/sqlite" // SQLite driver
@@ -111,24 +112,41 @@ func (db *Database) createSchema() error {
 		description TEXT DEFAULT '',
 		image TEXT DEFAULT '',
 		site_name TEXT DEFAULT '',
+		audio TEXT DEFAULT '',
+		audio_type TEXT DEFAULT '',
+		player_url TEXT DEFAULT '',
+		player_width INTEGER DEFAULT 0,
+		player_height INTEGER DEFAULT 0,
 		etag TEXT DEFAULT '',
 		last_modified TEXT DEFAULT '',
 		fetched_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 		expires_at TIMESTAMP NOT NULL,
-		fetch_success BOOLEAN DEFAULT 0
+		fetch_success BOOLEAN DEFAULT 0,
+		paywalled BOOLEAN DEFAULT 0,
+		final_url TEXT DEFAULT ''
 	);
 	
 	CREATE INDEX IF NOT EXISTS idx_opengraph_url ON opengraph_cache(url);
 	CREATE INDEX IF NOT EXISTS idx_opengraph_expires ON opengraph_cache(expires_at);
 	`
 
-	if _, err := db.db.Exec(schema); err != nil {
+	if err := database.RetryOnBusy("create opengraph schema", func() error {
+		_, err := db.db.Exec(schema)
+		return err
+	}); err != nil {
 		return err
 	}
 
 	for _, migration := range []string{
 		`ALTER TABLE opengraph_cache ADD COLUMN etag TEXT DEFAULT ''`,
 		`ALTER TABLE opengraph_cache ADD COLUMN last_modified TEXT DEFAULT ''`,
+		`ALTER TABLE opengraph_cache ADD COLUMN paywalled BOOLEAN DEFAULT 0`,
+		`ALTER TABLE opengraph_cache ADD COLUMN audio TEXT DEFAULT ''`,
+		`ALTER TABLE opengraph_cache ADD COLUMN audio_type TEXT DEFAULT ''`,
+		`ALTER TABLE opengraph_cache ADD COLUMN player_url TEXT DEFAULT ''`,
+		`ALTER TABLE opengraph_cache ADD COLUMN player_width INTEGER DEFAULT 0`,
+		`ALTER TABLE opengraph_cache ADD COLUMN player_height INTEGER DEFAULT 0`,
+		`ALTER TABLE opengraph_cache ADD COLUMN final_url TEXT DEFAULT ''`,
 	} {
 		if _, err := db.db.Exec(migration); err != nil && !strings.Contains(strings.ToLower(err.Error()), "duplicate column") {
 			return err
@@ -155,7 +173,7 @@ func (db *Database) GetCachedData(url string) (*Data, error) {
 	defer db.mu.RUnlock()
 
 	query := `
-	SELECT url, title, description, image, site_name, etag, last_modified, fetched_at, expires_at, fetch_success
+	SELECT url, title, description, image, site_name, audio, audio_type, player_url, player_width, player_height, etag, last_modified, fetched_at, expires_at, paywalled, final_url, fetch_success
 	FROM opengraph_cache 
 	WHERE url = ? AND expires_at > CURRENT_TIMESTAMP AND fetch_success = 1
 	`
@@ -163,18 +181,27 @@ func (db *Database) GetCachedData(url string) (*Data, error) {
 	var data Data
 	var fetchSuccess bool
 
-	err := db.db.QueryRow(query, url).Scan(
-		&data.URL,
-		&data.Title,
-		&data.Description,
-		&data.Image,
-		&data.SiteName,
-		&data.ETag,
-		&data.LastModified,
-		&data.FetchedAt,
-		&data.ExpiresAt,
-		&fetchSuccess,
-	)
+	err := database.RetryOnBusy("get cached opengraph data", func() error {
+		return db.db.QueryRow(query, url).Scan(
+			&data.URL,
+			&data.Title,
+			&data.Description,
+			&data.Image,
+			&data.SiteName,
+			&data.Audio,
+			&data.AudioType,
+			&data.Player.URL,
+			&data.Player.Width,
+			&data.Player.Height,
+			&data.ETag,
+			&data.LastModified,
+			&data.FetchedAt,
+			&data.ExpiresAt,
+			&data.Paywalled,
+			&data.FinalURL,
+			&fetchSuccess,
+		)
+	})
 
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil // No cached data found
@@ -197,22 +224,32 @@ func (db *Database) SaveCachedData(data *Data, fetchSuccess bool) error {
 
 	query := `
 	INSERT OR REPLACE INTO opengraph_cache 
-	(url, title, description, image, site_name, etag, last_modified, fetched_at, expires_at, fetch_success)
-	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	(url, title, description, image, site_name, audio, audio_type, player_url, player_width, player_height, etag, last_modified, fetched_at, expires_at, paywalled, final_url, fetch_success)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err := db.db.Exec(query,
-		data.URL,
-		data.Title,
-		data.Description,
-		data.Image,
-		data.SiteName,
-		data.ETag,
-		data.LastModified,
-		data.FetchedAt,
-		data.ExpiresAt,
-		fetchSuccess,
-	)
+	err := database.RetryOnBusy("save cached opengraph data", func() error {
+		_, err := db.db.Exec(query,
+			data.URL,
+			data.Title,
+			data.Description,
+			data.Image,
+			data.SiteName,
+			data.Audio,
+			data.AudioType,
+			data.Player.URL,
+			data.Player.Width,
+			data.Player.Height,
+			data.ETag,
+			data.LastModified,
+			data.FetchedAt,
+			data.ExpiresAt,
+			data.Paywalled,
+			data.FinalURL,
+			fetchSuccess,
+		)
+		return err
+	})
 
 	if err != nil {
 		return fmt.Errorf("failed to save cached data: %w", err)
@@ -236,23 +273,32 @@ func (db *Database) GetExpiredData(url string) (*Data, error) {
 	defer db.mu.RUnlock()
 
 	query := `
-	SELECT url, title, description, image, site_name, etag, last_modified, fetched_at, expires_at
+	SELECT url, title, description, image, site_name, audio, audio_type, player_url, player_width, player_height, etag, last_modified, fetched_at, expires_at, paywalled, final_url
 	FROM opengraph_cache
 	WHERE url = ? AND expires_at <= CURRENT_TIMESTAMP AND fetch_success = 1
 	`
 
 	var data Data
-	err := db.db.QueryRow(query, url).Scan(
-		&data.URL,
-		&data.Title,
-		&data.Description,
-		&data.Image,
-		&data.SiteName,
-		&data.ETag,
-		&data.LastModified,
-		&data.FetchedAt,
-		&data.ExpiresAt,
-	)
+	err := database.RetryOnBusy("get expired opengraph data", func() error {
+		return db.db.QueryRow(query, url).Scan(
+			&data.URL,
+			&data.Title,
+			&data.Description,
+			&data.Image,
+			&data.SiteName,
+			&data.Audio,
+			&data.AudioType,
+			&data.Player.URL,
+			&data.Player.Width,
+			&data.Player.Height,
+			&data.ETag,
+			&data.LastModified,
+			&data.FetchedAt,
+			&data.ExpiresAt,
+			&data.Paywalled,
+			&data.FinalURL,
+		)
+	})
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
@@ -268,7 +314,12 @@ func (db *Database) CleanupExpired() error {
 	defer db.mu.Unlock()
 
 	query := `DELETE FROM opengraph_cache WHERE expires_at < CURRENT_TIMESTAMP AND etag = '' AND last_modified = ''`
-	result, err := db.db.Exec(query)
+	var result sql.Result
+	err := database.RetryOnBusy("cleanup expired opengraph data", func() error {
+		var err error
+		result, err = db.db.Exec(query)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to cleanup expired entries: %w", err)
 	}
@@ -326,7 +377,9 @@ func (db *Database) HasRecentFailure(url string) (bool, error) {
 	`
 
 	var count int
-	err := db.db.QueryRow(query, url).Scan(&count)
+	err := database.RetryOnBusy("check recent opengraph failure", func() error {
+		return db.db.QueryRow(query, url).Scan(&count)
+	})
 	if err != nil {
 		return false, fmt.Errorf("failed to check recent failure: %w", err)
 	}
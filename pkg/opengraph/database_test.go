@@ -26,6 +26,10 @@ func TestDatabaseCacheLifecycle(t *testing.T) {
 		Description: "Example description",
 		Image:       "https://example.com/image.jpg",
 		SiteName:    "Example",
+		Audio:       "https://example.com/episode.mp3",
+		AudioType:   "audio/mpeg",
+		Player:      Player{URL: "https://example.com/embed/1", Width: 640, Height: 360},
+		FinalURL:    "https://www.example.com/article",
 		FetchedAt:   now,
 		ExpiresAt:   now.Add(24 * time.Hour),
 	}
@@ -37,10 +41,26 @@ func TestDatabaseCacheLifecycle(t *testing.T) {
 	if err != nil {
 		t.Fatalf("GetCachedData(success) error = %v", err)
 	}
-	if cached == nil || cached.Title != success.Title || cached.Image != success.Image {
+	if cached == nil || cached.Title != success.Title || cached.Image != success.Image ||
+		cached.Audio != success.Audio || cached.AudioType != success.AudioType || cached.Player != success.Player ||
+		cached.FinalURL != success.FinalURL {
 		t.Fatalf("GetCachedData(success) = %#v", cached)
 	}
 
+	expired := *success
+	expired.URL = "https://example.com/expired"
+	expired.ExpiresAt = now.Add(-time.Hour)
+	if err := db.SaveCachedData(&expired, true); err != nil {
+		t.Fatalf("SaveCachedData(expired) error = %v", err)
+	}
+	stale, err := db.GetExpiredData(expired.URL)
+	if err != nil {
+		t.Fatalf("GetExpiredData() error = %v", err)
+	}
+	if stale == nil || stale.FinalURL != success.FinalURL {
+		t.Fatalf("GetExpiredData() = %#v, want FinalURL %q", stale, success.FinalURL)
+	}
+
 	failed := &Data{
 		URL:       "https://example.com/failed",
 		FetchedAt: now,
@@ -0,0 +1,50 @@
+package opengraph
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// dumpFileBase returns the dump file path without extension for targetURL.
+func dumpFileBase(dir, targetURL string) string {
+	sum := sha256.Sum256([]byte(targetURL))
+	return filepath.Join(dir, hex.EncodeToString(sum[:16]))
+}
+
+// dumpFetch writes the parsed HTML and extracted data of a fetch to DumpDir
+// as <hash>.html and <hash>.json. Failures are logged and never fail the fetch.
+func (f *Fetcher) dumpFetch(targetURL, htmlContent string, data *Data) {
+	if f.DumpDir == "" {
+		return
+	}
+	if err := writeDump(f.DumpDir, targetURL, htmlContent, data); err != nil {
+		slog.Warn("Failed to dump OpenGraph fetch", "url", targetURL, "dir", f.DumpDir, "error", err)
+	}
+}
+
+func writeDump(dir, targetURL, htmlContent string, data *Data) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("create dump directory: %w", err)
+	}
+
+	base := dumpFileBase(dir, targetURL)
+	if err := os.WriteFile(base+".html", []byte(htmlContent), 0o600); err != nil {
+		return fmt.Errorf("write html dump: %w", err)
+	}
+
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode data dump: %w", err)
+	}
+	if err := os.WriteFile(base+".json", encoded, 0o600); err != nil {
+		return fmt.Errorf("write data dump: %w", err)
+	}
+
+	slog.Debug("Dumped OpenGraph fetch", "url", targetURL, "file", base+".html")
+	return nil
+}
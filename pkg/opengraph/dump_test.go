@@ -0,0 +1,56 @@
+package opengraph
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/lepinkainen/feed-forge/pkg/testutil"
+)
+
+func TestFetchFreshData_DumpsHTMLAndData(t *testing.T) {
+	const page = `<html><head><meta property="og:title" content="Dumped"><meta property="og:description" content="Body"></head></html>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(newTestOGDB(t))
+	fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}}
+	fetcher.client.Transport = rewriteHostTransport(server)
+	fetcher.DumpDir = t.TempDir()
+
+	targetURL := "http://dump.example.invalid/article"
+	if _, err := fetcher.fetchFreshData(context.Background(), targetURL); err != nil {
+		t.Fatalf("fetchFreshData() error = %v", err)
+	}
+
+	base := dumpFileBase(fetcher.DumpDir, targetURL)
+	html, err := os.ReadFile(base + ".html")
+	if err != nil {
+		t.Fatalf("read html dump: %v", err)
+	}
+	if !strings.Contains(string(html), `content="Dumped"`) {
+		t.Fatalf("html dump = %q, want fetched page", html)
+	}
+
+	raw, err := os.ReadFile(base + ".json")
+	if err != nil {
+		t.Fatalf("read data dump: %v", err)
+	}
+	var dumped Data
+	if err := json.Unmarshal(raw, &dumped); err != nil {
+		t.Fatalf("decode data dump: %v", err)
+	}
+	if dumped.URL != targetURL || dumped.Title != "Dumped" {
+		t.Fatalf("data dump = %+v, want extracted data", dumped)
+	}
+}
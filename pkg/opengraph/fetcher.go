@@ -1,21 +1,29 @@
 package opengraph
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/lepinkainen/feed-forge/pkg/api"
 	"github.com/lepinkainen/feed-forge/pkg/urlutils"
 	"golang.org/x/sync/singleflight"
 )
 
 var errNotModified = errors.New("opengraph not modified")
 
+// errNotSent wraps context errors from waiting for a request slot or the
+// domain rate limit: the page was never asked for, so it isn't a failure of
+// the page to cache.
+var errNotSent = errors.New("request not sent")
+
 // ProxyConfig configures a proxy for fetching URLs from blocked domains
 type ProxyConfig struct {
 	URL    string // Proxy endpoint URL
@@ -30,8 +38,64 @@ type Fetcher struct {
 	proxy       *ProxyConfig
 	domainMutex sync.Mutex
 	lastFetch   map[string]time.Time
+	robots      map[string]robotsRules // by host, guarded by domainMutex
 	semaphore   chan struct{}
 	fetchGroup  singleflight.Group
+	cache       *memoryCache // first-level LRU of resolved lookups
+
+	// ProgressInterval controls how often FetchConcurrent logs progress, in
+	// completed URLs. Zero disables progress lines.
+	ProgressInterval int
+
+	// ForceRefresh skips the cache and recent-failure short-circuits so every
+	// URL is fetched fresh; the result still overwrites the cache entry.
+	ForceRefresh bool
+
+	// PaywallDomains lists hosts (and their subdomains) whose pages are always
+	// flagged as paywalled.
+	PaywallDomains []string
+
+	// RespectRobots makes fetches honor each host's robots.txt: disallowed
+	// paths are skipped and Crawl-delay replaces the one-second minimum
+	// between requests to the host.
+	RespectRobots bool
+
+	// Outbound, when set, is a request slot shared with other phases (e.g.
+	// provider stats refreshes) that each fetch holds on top of the
+	// fetcher's own concurrency limit.
+	Outbound api.Semaphore
+
+	// SiteNames adds host -> name entries, checked before DefaultSiteNames,
+	// for pages without og:site_name.
+	SiteNames map[string]string
+
+	// MaxRedirects caps how many redirects a single fetch follows.
+	MaxRedirects int
+
+	// Soft404Phrases are title/description fragments that mark a page as a
+	// "not found" page served with 200; such fetches count as failures.
+	Soft404Phrases []string
+
+	// MinBodyBytes, when positive, makes pages smaller than this without any
+	// og:* tags count as failed fetches (e.g. redirect stubs, error pages).
+	MinBodyBytes int
+
+	// DumpDir, when set, receives the UTF-8 HTML and extracted Data of every
+	// fetched page for debugging. Empty disables dumping.
+	DumpDir string
+
+	// AcceptLanguage is the Accept-Language header sent with page fetches.
+	AcceptLanguage string
+
+	// RequestTimeout bounds each page fetch from connecting through following
+	// redirects and reading the body. Time spent queued for a request slot or
+	// the domain rate limit doesn't count. It is the only fetch timeout; the
+	// HTTP client has none of its own. Zero uses DefaultRequestTimeout.
+	RequestTimeout time.Duration
+
+	// MemoryCacheEntries caps the in-memory cache of resolved URLs; the least
+	// recently used entries are evicted beyond it. Zero leaves it unbounded.
+	MemoryCacheEntries int
 }
 
 // NewFetcher creates a new OpenGraph fetcher
@@ -48,17 +112,49 @@ func newFetcher(db *Database, proxy *ProxyConfig) *Fetcher {
 	resolver := net.DefaultResolver
 	transport := newSafeFetchTransport(resolver, allowedDialHosts(proxy), nil)
 
-	return &Fetcher{
+	f := &Fetcher{
 		client: &http.Client{
-			Timeout:   10 * time.Second,
 			Transport: transport,
 		},
 		resolver:  resolver,
 		db:        db,
 		proxy:     proxy,
 		lastFetch: make(map[string]time.Time),
+		robots:    make(map[string]robotsRules),
+		cache:     newMemoryCache(),
 		semaphore: make(chan struct{}, 5), // Max 5 concurrent fetches
+
+		ProgressInterval: DefaultProgressInterval,
+		PaywallDomains:   DefaultPaywallDomains,
+		MaxRedirects:     DefaultMaxRedirects,
+		AcceptLanguage:   DefaultAcceptLanguage,
+		Soft404Phrases:   DefaultSoft404Phrases,
+		RequestTimeout:   DefaultRequestTimeout,
+
+		MemoryCacheEntries: DefaultMemoryCacheEntries,
 	}
+	f.client.CheckRedirect = f.checkRedirect
+	return f
+}
+
+// requestTimeout returns RequestTimeout, or DefaultRequestTimeout when unset.
+func (f *Fetcher) requestTimeout() time.Duration {
+	return cmp.Or(f.RequestTimeout, DefaultRequestTimeout)
+}
+
+// checkRedirect logs each redirect hop and enforces MaxRedirects.
+func (f *Fetcher) checkRedirect(req *http.Request, via []*http.Request) error {
+	slog.Debug("OpenGraph redirect", "hop", len(via), "from", via[len(via)-1].URL.String(), "to", req.URL.String())
+	if len(via) > f.MaxRedirects {
+		chain := make([]string, 0, len(via)+1)
+		for _, r := range via {
+			chain = append(chain, r.URL.String())
+		}
+		chain = append(chain, req.URL.String())
+		slog.Debug("OpenGraph redirect limit reached", "max", f.MaxRedirects, "chain", strings.Join(chain, " -> "))
+		return fmt.Errorf("stopped after %d redirects", f.MaxRedirects)
+	}
+	return nil
 }
 
 // FetchData fetches OpenGraph data from a URL with caching.
@@ -68,6 +164,10 @@ func (f *Fetcher) FetchData(targetURL string) (*Data, error) {
 
 // FetchDataWithContext fetches OpenGraph data from a URL with caching.
 func (f *Fetcher) FetchDataWithContext(ctx context.Context, targetURL string) (*Data, error) {
+	return f.fetchData(ctx, targetURL)
+}
+
+func (f *Fetcher) fetchData(ctx context.Context, targetURL string) (*Data, error) {
 	if !urlutils.IsFetchableURLWithContext(ctx, f.resolver, targetURL) {
 		return nil, fmt.Errorf("invalid or disallowed fetch URL: %s", targetURL)
 	}
@@ -76,40 +176,65 @@ func (f *Fetcher) FetchDataWithContext(ctx context.Context, targetURL string) (*
 		return nil, nil
 	}
 
-	cached, expired, skip := f.lookupCachedData(targetURL)
-	if cached != nil {
+	// Equivalent URLs share one cache entry; the request still goes to targetURL.
+	cacheKey := urlutils.CanonicalURL(targetURL)
+	if cached := f.memoryCached(cacheKey); cached != nil {
 		return cached, nil
 	}
-	if skip {
-		return nil, nil
+
+	var expired *Data
+	if !f.ForceRefresh {
+		cached, expiredData, skip := f.lookupCachedData(cacheKey)
+		if cached != nil {
+			f.markPaywalledDomain(cached, targetURL)
+			f.storeMemory(cacheKey, cached)
+			return cached, nil
+		}
+		if skip {
+			return nil, nil
+		}
+		expired = expiredData
 	}
 
-	fetchCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
-	defer cancel()
+	if f.RespectRobots && !(f.proxy != nil && isProxiableRedditURL(targetURL)) && !f.robotsAllowed(ctx, targetURL) {
+		slog.Debug("Skipping URL disallowed by robots.txt", "url", targetURL)
+		return nil, nil
+	}
 
-	data, err := f.fetchWithExpiredHint(fetchCtx, targetURL, expired)
+	data, err := f.fetchWithExpiredHint(ctx, targetURL, expired)
 	if errors.Is(err, errNotModified) && expired != nil {
-		return f.refreshExpired(expired, targetURL), nil
+		f.markPaywalledDomain(expired, targetURL)
+		refreshed := f.refreshExpired(expired, targetURL)
+		f.storeMemory(cacheKey, refreshed)
+		return refreshed, nil
+	}
+
+	if errors.Is(err, api.ErrBudgetExhausted) || errors.Is(err, errNotSent) {
+		// Nothing was fetched, so don't record a failure to back off from.
+		return nil, err
 	}
 
 	fetchSuccess := err == nil && data != nil
 	if err != nil {
 		slog.Debug("Failed to fetch OpenGraph data", "url", targetURL, "error", err)
 		if data == nil {
-			data = newFailurePlaceholder(targetURL)
+			data = newFailurePlaceholder(cacheKey)
 		}
 	} else if data != nil {
 		cleanupData(data, targetURL)
+		f.markPaywalledDomain(data, targetURL)
 		slog.Debug("Successfully fetched OpenGraph data", "url", targetURL, "title", data.Title)
 	}
 
 	if f.db != nil && data != nil {
+		data.URL = cacheKey
 		if cacheErr := f.db.SaveCachedData(data, fetchSuccess); cacheErr != nil {
 			slog.Warn("Failed to cache OpenGraph data", "url", targetURL, "error", cacheErr)
 		}
 	}
 
 	if fetchSuccess {
+		f.storeMemory(cacheKey, data)
 		return data, nil
 	}
 	return nil, err
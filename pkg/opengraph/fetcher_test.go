@@ -5,6 +5,8 @@ import (
 	"compress/gzip"
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -15,7 +17,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/lepinkainen/feed-forge/pkg/api"
 	"github.com/lepinkainen/feed-forge/pkg/testutil"
+	"github.com/lepinkainen/feed-forge/pkg/urlutils"
 	"golang.org/x/net/html"
 )
 
@@ -231,6 +235,48 @@ func TestFetchData_CachesFailures(t *testing.T) {
 	}
 }
 
+func TestExtractOpenGraphTags_Audio(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<!doctype html><html><head>
+		<meta property="og:title" content="Episode 12">
+		<meta property="og:audio" content="/media/ep12.ogg">
+		<meta property="og:audio:secure_url" content="https://cdn.example.com/ep12-secure.ogg">
+		<meta property="og:audio:type" content=" audio/ogg ">
+	</head></html>`))
+	if err != nil {
+		t.Fatalf("html.Parse() error = %v", err)
+	}
+
+	data := &Data{}
+	extractOpenGraphTags(doc, data)
+	cleanupData(data, "https://podcast.example.com/episodes/12")
+	if data.Audio != "https://podcast.example.com/media/ep12.ogg" {
+		t.Errorf("Audio = %q, want the first og:audio resolved against the page", data.Audio)
+	}
+	if data.AudioType != "audio/ogg" {
+		t.Errorf("AudioType = %q, want audio/ogg", data.AudioType)
+	}
+}
+
+func TestExtractOpenGraphTags_TwitterPlayer(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<!doctype html><html><head>
+		<meta name="twitter:card" content="player">
+		<meta name="twitter:player" content="/embed/clip">
+		<meta name="twitter:player:width" content="1280">
+		<meta property="twitter:player:height" content=" 720 ">
+	</head></html>`))
+	if err != nil {
+		t.Fatalf("html.Parse() error = %v", err)
+	}
+
+	data := &Data{}
+	extractOpenGraphTags(doc, data)
+	cleanupData(data, "https://video.example.com/watch/clip")
+	want := Player{URL: "https://video.example.com/embed/clip", Width: 1280, Height: 720}
+	if data.Player != want {
+		t.Errorf("Player = %+v, want %+v", data.Player, want)
+	}
+}
+
 func TestExtractOpenGraphTagsAndProcessMetaTag(t *testing.T) {
 	doc, err := html.Parse(strings.NewReader(`<!doctype html><html><head>
 		<title> Page Title </title>
@@ -529,6 +575,9 @@ func TestFetcher_FetchGroupReleasesAfterCompletion(t *testing.T) {
 
 	targetURL := "http://example.invalid/sequential"
 	for i := 0; i < 3; i++ {
+		// Drop the in-memory cache so each call reaches the fetch group.
+		fetcher.cache = newMemoryCache()
+
 		data, err := fetcher.FetchData(targetURL)
 		if err != nil {
 			t.Fatalf("FetchData iter %d error = %v", i, err)
@@ -541,3 +590,422 @@ func TestFetcher_FetchGroupReleasesAfterCompletion(t *testing.T) {
 		t.Fatalf("server hits = %d, want 3 (singleflight should not cache across sequential calls)", got)
 	}
 }
+
+func TestFetchConcurrent_LogsProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<!doctype html><html><head><title>` + r.Host + `</title></head></html>`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	fetcher := NewFetcher(nil)
+	fetcher.ProgressInterval = 2
+	fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}}
+	fetcher.client.Transport = rewriteHostTransport(server)
+
+	// Distinct hosts avoid the per-domain rate limit.
+	urls := []string{
+		"http://one.example.invalid/",
+		"http://two.example.invalid/",
+		"http://three.example.invalid/",
+		"http://four.example.invalid/",
+	}
+	results := fetcher.FetchConcurrent(urls)
+	if len(results) != len(urls) {
+		t.Fatalf("len(FetchConcurrent()) = %d, want %d", len(results), len(urls))
+	}
+
+	output := logs.String()
+	if !strings.Contains(output, "fetched 2/4") {
+		t.Fatalf("progress line missing from logs:\n%s", output)
+	}
+	if !strings.Contains(output, "Completed concurrent OpenGraph fetch") || !strings.Contains(output, "successful=4") {
+		t.Fatalf("summary line missing from logs:\n%s", output)
+	}
+}
+
+func TestFetchData_ForceRefreshBypassesCache(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<!doctype html><html><head><meta property="og:title" content="New Title"></head></html>`))
+	}))
+	defer server.Close()
+
+	targetURL := "http://example.invalid/updated"
+	db := newTestOGDB(t)
+	now := time.Now()
+	if err := db.SaveCachedData(&Data{URL: targetURL, Title: "Old Title", FetchedAt: now, ExpiresAt: now.Add(time.Hour)}, true); err != nil {
+		t.Fatalf("SaveCachedData() error = %v", err)
+	}
+
+	fetcher := NewFetcher(db)
+	fetcher.ForceRefresh = true
+	fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}}
+	fetcher.client.Transport = rewriteHostTransport(server)
+
+	data, err := fetcher.FetchData(targetURL)
+	if err != nil {
+		t.Fatalf("FetchData() error = %v", err)
+	}
+	if data == nil || data.Title != "New Title" {
+		t.Fatalf("FetchData() = %#v, want refetched title", data)
+	}
+	if hits.Load() != 1 {
+		t.Fatalf("server hits = %d, want 1", hits.Load())
+	}
+
+	cached, err := db.GetCachedData(targetURL)
+	if err != nil {
+		t.Fatalf("GetCachedData() error = %v", err)
+	}
+	if cached == nil || cached.Title != "New Title" {
+		t.Fatalf("GetCachedData() = %#v, want updated cache entry", cached)
+	}
+}
+
+func TestFetchData_MemoryCacheServesDuplicateURLs(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<!doctype html><html><head><title>Once</title></head></html>`))
+	}))
+	defer server.Close()
+
+	db := newTestOGDB(t)
+	fetcher := NewFetcher(db)
+	fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}}
+	fetcher.client.Transport = rewriteHostTransport(server)
+
+	targetURL := "http://example.invalid/duplicate"
+	if _, err := fetcher.FetchData(targetURL); err != nil {
+		t.Fatalf("FetchData(first) error = %v", err)
+	}
+
+	// Remove the DB row so a second lookup can only be served from memory.
+	if _, err := db.db.Exec(`DELETE FROM opengraph_cache WHERE url = ?`, targetURL); err != nil {
+		t.Fatalf("delete cache row: %v", err)
+	}
+
+	data, err := fetcher.FetchData(targetURL)
+	if err != nil {
+		t.Fatalf("FetchData(duplicate) error = %v", err)
+	}
+	if data == nil || data.Title != "Once" {
+		t.Fatalf("FetchData(duplicate) = %#v", data)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Fatalf("server hits = %d, want 1", got)
+	}
+}
+
+func TestFetchData_RedirectChain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var hop int
+		if _, err := fmt.Sscanf(r.URL.Path, "/hop/%d", &hop); err == nil && hop < 3 {
+			http.Redirect(w, r, fmt.Sprintf("/hop/%d", hop+1), http.StatusFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<!doctype html><html><head><title>Landed</title></head></html>`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	db := newTestOGDB(t)
+	newRedirectFetcher := func(maxRedirects int) *Fetcher {
+		fetcher := NewFetcher(db)
+		fetcher.MaxRedirects = maxRedirects
+		fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+			return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+		}}
+		fetcher.client.Transport = rewriteHostTransport(server)
+		return fetcher
+	}
+
+	data, err := newRedirectFetcher(5).FetchData("http://a.example.invalid/hop/0")
+	if err != nil {
+		t.Fatalf("FetchData(within cap) error = %v", err)
+	}
+	if data == nil || data.FinalURL != "http://a.example.invalid/hop/3" {
+		t.Fatalf("FinalURL = %#v, want http://a.example.invalid/hop/3", data)
+	}
+	cached, err := db.GetCachedData(urlutils.CanonicalURL("http://a.example.invalid/hop/0"))
+	if err != nil {
+		t.Fatalf("GetCachedData() error = %v", err)
+	}
+	if cached == nil || cached.FinalURL != "http://a.example.invalid/hop/3" {
+		t.Fatalf("cached FinalURL = %#v, want http://a.example.invalid/hop/3", cached)
+	}
+	if got := strings.Count(logs.String(), `msg="OpenGraph redirect"`); got != 3 {
+		t.Fatalf("logged redirect hops = %d, want 3\n%s", got, logs.String())
+	}
+
+	if _, err := newRedirectFetcher(2).fetchFreshData(context.Background(), "http://b.example.invalid/hop/0"); err == nil {
+		t.Fatal("fetchFreshData(over cap) error = nil, want redirect limit error")
+	}
+	if !strings.Contains(logs.String(), "OpenGraph redirect limit reached") {
+		t.Fatalf("redirect limit not logged\n%s", logs.String())
+	}
+}
+
+func TestFetchFreshData_SendsConfiguredAcceptLanguage(t *testing.T) {
+	var gotLanguage atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotLanguage.Store(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><meta property="og:title" content="Otsikko"></head></html>`))
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(newTestOGDB(t))
+	fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}}
+	fetcher.client.Transport = rewriteHostTransport(server)
+	fetcher.AcceptLanguage = "fi-FI,fi;q=0.9"
+
+	if _, err := fetcher.fetchFreshData(context.Background(), "http://lang.example.invalid/sarjakuva"); err != nil {
+		t.Fatalf("fetchFreshData() error = %v", err)
+	}
+	if got, _ := gotLanguage.Load().(string); got != "fi-FI,fi;q=0.9" {
+		t.Fatalf("Accept-Language = %q, want fi-FI,fi;q=0.9", got)
+	}
+}
+
+func TestFetchData_EquivalentURLsShareCacheRow(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<!doctype html><html><head><meta property="og:title" content="Shared"></head></html>`))
+	}))
+	defer server.Close()
+
+	db := newTestOGDB(t)
+	fetcher := NewFetcher(db)
+	fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}}
+	fetcher.client.Transport = rewriteHostTransport(server)
+
+	if _, err := fetcher.FetchData("http://canonical.example/a/"); err != nil {
+		t.Fatalf("FetchData(trailing slash) error = %v", err)
+	}
+	if len(requested) != 1 || requested[0] != "/a/" {
+		t.Fatalf("requested paths = %v, want the original /a/", requested)
+	}
+
+	// A fresh fetcher has an empty memory cache, so this can only hit the DB row.
+	second := NewFetcher(db)
+	second.resolver = fetcher.resolver
+	second.client.Transport = rewriteHostTransport(server)
+	data, err := second.FetchData("http://canonical.example:80/a")
+	if err != nil {
+		t.Fatalf("FetchData(no slash) error = %v", err)
+	}
+	if data == nil || data.Title != "Shared" {
+		t.Fatalf("FetchData(no slash) = %#v, want cached data", data)
+	}
+	if len(requested) != 1 {
+		t.Fatalf("server hits = %d, want 1", len(requested))
+	}
+
+	var rows int
+	if err := db.db.QueryRow(`SELECT COUNT(*) FROM opengraph_cache`).Scan(&rows); err != nil {
+		t.Fatalf("count rows: %v", err)
+	}
+	if rows != 1 {
+		t.Fatalf("cache rows = %d, want 1", rows)
+	}
+	if data.URL != "http://canonical.example/a" {
+		t.Fatalf("stored URL = %q, want canonical form", data.URL)
+	}
+}
+
+func TestFetchData_RequestTimeoutCancelsSlowPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(20 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(newTestOGDB(t))
+	fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}}
+	fetcher.client.Transport = rewriteHostTransport(server)
+	fetcher.RequestTimeout = 3 * time.Second
+
+	start := time.Now()
+	data, err := fetcher.FetchData("http://example.invalid/slow")
+	elapsed := time.Since(start)
+
+	if err == nil || data != nil {
+		t.Fatalf("FetchData() = %#v, %v; want timeout error", data, err)
+	}
+	if elapsed < 3*time.Second || elapsed > 5*time.Second {
+		t.Fatalf("FetchData() gave up after %v, want about 3s", elapsed)
+	}
+}
+
+// inFlightTransport counts concurrent round trips and records the peak.
+type inFlightTransport struct {
+	next     http.RoundTripper
+	current  atomic.Int32
+	peak     atomic.Int32
+	requests atomic.Int32
+}
+
+func (c *inFlightTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	n := c.current.Add(1)
+	defer c.current.Add(-1)
+	c.requests.Add(1)
+	for {
+		peak := c.peak.Load()
+		if n <= peak || c.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	return c.next.RoundTrip(req)
+}
+
+func TestFetcher_OutboundSemaphoreCapsCombinedConcurrency(t *testing.T) {
+	const limit = 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`<html><head><meta property="og:title" content="Page"></head></html>`))
+	}))
+	defer server.Close()
+
+	transport := &inFlightTransport{next: rewriteHostTransport(server)}
+	outbound := api.NewSemaphore(limit)
+
+	fetcher := NewFetcher(newTestOGDB(t))
+	fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}}
+	fetcher.client.Transport = transport
+	fetcher.Outbound = outbound
+
+	var urls []string
+	for i := range 8 {
+		urls = append(urls, fmt.Sprintf("http://site%d.invalid/page", i))
+	}
+
+	// A stats refresh running alongside the crawl, holding the same slots.
+	statsClient := &http.Client{Transport: transport}
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			if err := outbound.Acquire(context.Background()); err != nil {
+				t.Error(err)
+				return
+			}
+			defer outbound.Release()
+			resp, err := statsClient.Get(server.URL + "/stats")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			_ = resp.Body.Close()
+		})
+	}
+	results := fetcher.FetchConcurrent(urls)
+	wg.Wait()
+
+	if len(results) != len(urls) {
+		t.Fatalf("FetchConcurrent() returned %d results, want %d", len(results), len(urls))
+	}
+	if got := transport.requests.Load(); got != 16 {
+		t.Fatalf("requests = %d, want 16", got)
+	}
+	if peak := transport.peak.Load(); peak > limit {
+		t.Fatalf("peak in-flight requests = %d, want at most %d", peak, limit)
+	}
+}
+
+func TestFetchData_RequestTimeoutExcludesQueueTime(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><head><meta property="og:title" content="Queued"></head></html>`))
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(newTestOGDB(t))
+	fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}}
+	fetcher.client.Transport = rewriteHostTransport(server)
+	fetcher.RequestTimeout = 100 * time.Millisecond
+	fetcher.Outbound = api.NewSemaphore(1)
+	if err := fetcher.Outbound.Acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		fetcher.Outbound.Release()
+	}()
+
+	data, err := fetcher.FetchData("http://example.invalid/queued")
+	if err != nil {
+		t.Fatalf("FetchData() error = %v, want the queue wait outside RequestTimeout", err)
+	}
+	if data == nil || data.Title != "Queued" {
+		t.Fatalf("FetchData() = %#v, want page data", data)
+	}
+}
+
+func TestFetchData_DoesNotCacheRequestsNeverSent(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		_, _ = w.Write([]byte(`<html><head><meta property="og:title" content="Page"></head></html>`))
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(newTestOGDB(t))
+	fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}}
+	fetcher.client.Transport = rewriteHostTransport(server)
+	fetcher.Outbound = api.NewSemaphore(1)
+	if err := fetcher.Outbound.Acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	const targetURL = "http://example.invalid/never-sent"
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := fetcher.FetchDataWithContext(ctx, targetURL); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("FetchDataWithContext() error = %v, want deadline exceeded", err)
+	}
+	if hits.Load() != 0 {
+		t.Fatalf("page hits = %d, want 0", hits.Load())
+	}
+	failed, err := fetcher.db.HasRecentFailure(urlutils.CanonicalURL(targetURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed {
+		t.Fatal("HasRecentFailure() = true, want no failure cached for a request that was never sent")
+	}
+}
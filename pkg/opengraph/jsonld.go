@@ -0,0 +1,116 @@
+package opengraph
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// jsonLDArticleTypes are the schema.org types whose JSON-LD fills in missing
+// OpenGraph fields.
+var jsonLDArticleTypes = []string{"Article", "NewsArticle", "BlogPosting"}
+
+// applyJSONLD fills the title, description and image still empty after the
+// meta tags from the page's first Article or NewsArticle JSON-LD object,
+// looking inside top-level arrays and @graph lists.
+func applyJSONLD(doc *html.Node, data *Data) {
+	if data.Title != "" && data.Description != "" && data.Image != "" {
+		return
+	}
+	for _, script := range jsonLDScripts(doc) {
+		var parsed any
+		if err := json.Unmarshal([]byte(script), &parsed); err != nil {
+			continue
+		}
+		article := findJSONLDArticle(parsed)
+		if article == nil {
+			continue
+		}
+		if data.Title == "" {
+			data.Title = jsonLDString(article["headline"])
+		}
+		if data.Description == "" {
+			data.Description = jsonLDString(article["description"])
+		}
+		if data.Image == "" {
+			data.Image = jsonLDImage(article["image"])
+		}
+		return
+	}
+}
+
+// jsonLDScripts returns the contents of the document's
+// <script type="application/ld+json"> elements in document order.
+func jsonLDScripts(n *html.Node) []string {
+	var scripts []string
+	if n.Type == html.ElementNode && n.Data == "script" && n.FirstChild != nil {
+		for _, attr := range n.Attr {
+			if attr.Key == "type" && strings.EqualFold(strings.TrimSpace(attr.Val), "application/ld+json") {
+				scripts = append(scripts, n.FirstChild.Data)
+				break
+			}
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		scripts = append(scripts, jsonLDScripts(c)...)
+	}
+	return scripts
+}
+
+// findJSONLDArticle returns the first object of an article type in v,
+// searching arrays and @graph lists depth-first.
+func findJSONLDArticle(v any) map[string]any {
+	switch node := v.(type) {
+	case []any:
+		for _, child := range node {
+			if article := findJSONLDArticle(child); article != nil {
+				return article
+			}
+		}
+	case map[string]any:
+		if isJSONLDArticle(node["@type"]) {
+			return node
+		}
+		if graph, ok := node["@graph"]; ok {
+			return findJSONLDArticle(graph)
+		}
+	}
+	return nil
+}
+
+// isJSONLDArticle reports whether an @type value, a string or a list of
+// strings, names an article type.
+func isJSONLDArticle(t any) bool {
+	switch t := t.(type) {
+	case string:
+		return slices.Contains(jsonLDArticleTypes, t)
+	case []any:
+		return slices.ContainsFunc(t, isJSONLDArticle)
+	}
+	return false
+}
+
+func jsonLDString(v any) string {
+	s, _ := v.(string)
+	return strings.TrimSpace(s)
+}
+
+// jsonLDImage returns the URL of an image value: a URL string, an
+// ImageObject with a url, or a list of either (the first usable one wins).
+func jsonLDImage(v any) string {
+	switch image := v.(type) {
+	case string:
+		return strings.TrimSpace(image)
+	case map[string]any:
+		return jsonLDString(image["url"])
+	case []any:
+		for _, candidate := range image {
+			if url := jsonLDImage(candidate); url != "" {
+				return url
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+package opengraph
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lepinkainen/feed-forge/pkg/testutil"
+	"golang.org/x/net/html"
+)
+
+func TestApplyJSONLD(t *testing.T) {
+	tests := []struct {
+		name   string
+		jsonLD string
+		want   Data
+	}{
+		{
+			name:   "article object",
+			jsonLD: `{"@context":"https://schema.org","@type":"Article","headline":" Headline ","description":"Summary","image":"https://cdn.example.com/a.jpg"}`,
+			want:   Data{Title: "Headline", Description: "Summary", Image: "https://cdn.example.com/a.jpg"},
+		},
+		{
+			name: "array with image object list",
+			jsonLD: `[{"@type":"BreadcrumbList"},{"@type":["NewsArticle","Thing"],"headline":"News",
+				"image":[{"@type":"ImageObject","url":"https://cdn.example.com/n.jpg"},"https://cdn.example.com/other.jpg"]}]`,
+			want: Data{Title: "News", Image: "https://cdn.example.com/n.jpg"},
+		},
+		{
+			name: "nested graph",
+			jsonLD: `{"@context":"https://schema.org","@graph":[{"@type":"WebSite","name":"Site"},
+				{"@type":"NewsArticle","headline":"Graph headline","description":"From the graph","image":{"url":"https://cdn.example.com/g.jpg"}}]}`,
+			want: Data{Title: "Graph headline", Description: "From the graph", Image: "https://cdn.example.com/g.jpg"},
+		},
+		{
+			name:   "no article",
+			jsonLD: `{"@type":"Organization","name":"Example"}`,
+			want:   Data{},
+		},
+		{
+			name:   "invalid json",
+			jsonLD: `{"@type":"Article",`,
+			want:   Data{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader(`<html><head><script type="application/ld+json">` + tt.jsonLD + `</script></head></html>`))
+			if err != nil {
+				t.Fatalf("html.Parse() error = %v", err)
+			}
+			data := &Data{}
+			applyJSONLD(doc, data)
+			if *data != tt.want {
+				t.Fatalf("applyJSONLD() = %+v, want %+v", *data, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyJSONLD_MetaTagsWin(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><head>
+		<meta property="og:title" content="OG title">
+		<script type="application/ld+json">{"@type":"Article","headline":"LD title","description":"LD description"}</script>
+	</head></html>`))
+	if err != nil {
+		t.Fatalf("html.Parse() error = %v", err)
+	}
+	data := &Data{}
+	extractOpenGraphTags(doc, data)
+	applyJSONLD(doc, data)
+	if data.Title != "OG title" || data.Description != "LD description" {
+		t.Fatalf("Title, Description = %q, %q; want og:title kept and JSON-LD description filled", data.Title, data.Description)
+	}
+}
+
+func TestFetchFreshData_JSONLDOnlyPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><script type="application/ld+json">
+			{"@context":"https://schema.org","@graph":[{"@type":"NewsArticle","headline":"Structured headline",
+			"description":"Structured description","image":["/images/lead.jpg"]}]}
+		</script></head><body>` + strings.Repeat("<p>Story text</p>", 50) + `</body></html>`))
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(newTestOGDB(t))
+	fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}}
+	fetcher.client.Transport = rewriteHostTransport(server)
+
+	data, err := fetcher.FetchData("http://jsonld.example.invalid/story")
+	if err != nil || data == nil {
+		t.Fatalf("FetchData() = (%v, %v), want data", data, err)
+	}
+	if data.Title != "Structured headline" || data.Description != "Structured description" || data.Image != "http://jsonld.example.invalid/images/lead.jpg" {
+		t.Fatalf("FetchData() = title %q, description %q, image %q", data.Title, data.Description, data.Image)
+	}
+}
@@ -0,0 +1,61 @@
+package opengraph
+
+import (
+	"container/list"
+	"sync"
+)
+
+// memoryCache is a size-bounded LRU of resolved OpenGraph data. It keeps
+// long-running processes from holding every URL ever seen; the database stays
+// the durable cache.
+type memoryCache struct {
+	mu      sync.Mutex
+	order   *list.List // front = most recently used
+	entries map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key  string
+	data *Data
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// get returns the data stored for key and marks it most recently used.
+func (c *memoryCache) get(key string) *Data {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*memoryCacheEntry).data
+}
+
+// add stores data for key, evicting the least recently used entries beyond
+// maxEntries. maxEntries <= 0 leaves the cache unbounded.
+func (c *memoryCache) add(key string, data *Data, maxEntries int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*memoryCacheEntry).data = data
+		c.order.MoveToFront(elem)
+	} else {
+		c.entries[key] = c.order.PushFront(&memoryCacheEntry{key: key, data: data})
+	}
+	for maxEntries > 0 && c.order.Len() > maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// len returns the number of cached entries.
+func (c *memoryCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
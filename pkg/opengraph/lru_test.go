@@ -0,0 +1,51 @@
+package opengraph
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newMemoryCache()
+	for i := range 5 {
+		key := fmt.Sprintf("https://example.com/%d", i)
+		cache.add(key, &Data{URL: key}, 3)
+		if i == 2 {
+			// Touch the first entry so it outlives 1 and 2.
+			cache.get("https://example.com/0")
+		}
+	}
+
+	if got := cache.len(); got != 3 {
+		t.Fatalf("len() = %d, want 3", got)
+	}
+	for _, evicted := range []string{"https://example.com/1", "https://example.com/2"} {
+		if cache.get(evicted) != nil {
+			t.Errorf("get(%s) still cached, want evicted", evicted)
+		}
+	}
+	for _, kept := range []string{"https://example.com/0", "https://example.com/3", "https://example.com/4"} {
+		if data := cache.get(kept); data == nil || data.URL != kept {
+			t.Errorf("get(%s) = %v, want cached", kept, data)
+		}
+	}
+}
+
+func TestFetcher_MemoryCacheBounded(t *testing.T) {
+	fetcher := NewFetcher(nil)
+	fetcher.MemoryCacheEntries = 2
+	for i := range 4 {
+		key := fmt.Sprintf("https://example.com/%d", i)
+		fetcher.storeMemory(key, &Data{URL: key})
+	}
+
+	if got := fetcher.cache.len(); got != 2 {
+		t.Fatalf("cache holds %d entries, want 2", got)
+	}
+	if fetcher.memoryCached("https://example.com/0") != nil {
+		t.Error("oldest entry still cached")
+	}
+	if fetcher.memoryCached("https://example.com/3") == nil {
+		t.Error("newest entry evicted")
+	}
+}
@@ -2,9 +2,11 @@ package opengraph
 
 import (
 	"bytes"
+	"cmp"
 	"fmt"
 	"io"
 	"log/slog"
+	"strconv"
 	"strings"
 
 	"github.com/lepinkainen/feed-forge/pkg/urlutils"
@@ -29,10 +31,26 @@ func extractOpenGraphTags(n *html.Node, data *Data) {
 	}
 }
 
+// hasOpenGraphTags reports whether the document has any <meta property="og:*">.
+func hasOpenGraphTags(n *html.Node) bool {
+	if n.Type == html.ElementNode && n.Data == "meta" {
+		if property, _, _ := metaTagAttrs(n); strings.HasPrefix(property, "og:") {
+			return true
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if hasOpenGraphTags(c) {
+			return true
+		}
+	}
+	return false
+}
+
 func processMetaTag(n *html.Node, data *Data) {
 	property, content, name := metaTagAttrs(n)
 	applyOpenGraphProperty(data, property, content)
 	applyMetaFallback(data, name, content)
+	applyTwitterPlayer(data, cmp.Or(name, property), content)
 }
 
 func metaTagAttrs(n *html.Node) (property, content, name string) {
@@ -67,6 +85,14 @@ func applyOpenGraphProperty(data *Data, property, content string) {
 		if data.SiteName == "" {
 			data.SiteName = content
 		}
+	case "og:audio", "og:audio:url", "og:audio:secure_url":
+		if data.Audio == "" {
+			data.Audio = content
+		}
+	case "og:audio:type":
+		if data.AudioType == "" {
+			data.AudioType = content
+		}
 	}
 }
 
@@ -85,6 +111,25 @@ func applyMetaFallback(data *Data, name, content string) {
 	}
 }
 
+// applyTwitterPlayer records twitter:player tags, which pages publish with
+// either name= or property=.
+func applyTwitterPlayer(data *Data, key, content string) {
+	switch key {
+	case "twitter:player":
+		if data.Player.URL == "" {
+			data.Player.URL = content
+		}
+	case "twitter:player:width":
+		if data.Player.Width == 0 {
+			data.Player.Width, _ = strconv.Atoi(strings.TrimSpace(content))
+		}
+	case "twitter:player:height":
+		if data.Player.Height == 0 {
+			data.Player.Height, _ = strconv.Atoi(strings.TrimSpace(content))
+		}
+	}
+}
+
 func cleanupData(data *Data, baseURL string) {
 	if len(data.Description) > 500 {
 		data.Description = data.Description[:497] + "..."
@@ -107,9 +152,31 @@ func cleanupData(data *Data, baseURL string) {
 		}
 	}
 
+	if data.Audio != "" {
+		resolvedURL, err := urlutils.ResolveURL(baseURL, data.Audio)
+		if err != nil || !urlutils.IsValidURL(resolvedURL) {
+			slog.Warn("Invalid audio URL, clearing", "original", data.Audio, "error", err)
+			data.Audio = ""
+			data.AudioType = ""
+		} else {
+			data.Audio = resolvedURL
+		}
+	}
+
+	if data.Player.URL != "" {
+		resolvedURL, err := urlutils.ResolveURL(baseURL, data.Player.URL)
+		if err != nil || !urlutils.IsValidURL(resolvedURL) {
+			slog.Warn("Invalid player URL, clearing", "original", data.Player.URL, "error", err)
+			data.Player = Player{}
+		} else {
+			data.Player.URL = resolvedURL
+		}
+	}
+
 	data.Title = strings.TrimSpace(data.Title)
 	data.Description = strings.TrimSpace(data.Description)
 	data.SiteName = strings.TrimSpace(data.SiteName)
+	data.AudioType = strings.TrimSpace(data.AudioType)
 
 	data.Title = strings.ReplaceAll(data.Title, "\x00", "")
 	data.Description = strings.ReplaceAll(data.Description, "\x00", "")
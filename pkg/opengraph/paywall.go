@@ -0,0 +1,102 @@
+package opengraph
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// DefaultPaywallDomains are news sites known to paywall most articles.
+var DefaultPaywallDomains = []string{
+	"bloomberg.com",
+	"economist.com",
+	"ft.com",
+	"hs.fi",
+	"newyorker.com",
+	"nytimes.com",
+	"theatlantic.com",
+	"washingtonpost.com",
+	"wsj.com",
+}
+
+// markPaywalledDomain flags data as paywalled when targetURL is on one of
+// PaywallDomains. Data is shared once it is cached, so this runs before
+// storeMemory and SaveCachedData, never after.
+func (f *Fetcher) markPaywalledDomain(data *Data, targetURL string) {
+	if isPaywalledDomain(targetURL, f.PaywallDomains) {
+		data.Paywalled = true
+	}
+}
+
+// isPaywalledDomain reports whether targetURL's host is one of domains or a
+// subdomain of one.
+func isPaywalledDomain(targetURL string, domains []string) bool {
+	parsed, err := url.Parse(targetURL)
+	if err != nil {
+		return false
+	}
+	return hostInDomains(parsed.Hostname(), domains)
+}
+
+// hostInDomains reports whether host is one of domains or a subdomain of one.
+func hostInDomains(host string, domains []string) bool {
+	host = strings.ToLower(host)
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if domain == "" {
+			continue
+		}
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPaywallMarkers reports whether an article page declares restricted
+// access, either via article:content_tier or schema.org isAccessibleForFree.
+func hasPaywallMarkers(doc *html.Node) bool {
+	var isArticle, hasMarker bool
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "meta":
+				property, content, _ := metaTagAttrs(n)
+				switch {
+				case property == "og:type" && strings.EqualFold(content, "article"):
+					isArticle = true
+				case property == "article:content_tier" && strings.EqualFold(content, "locked"):
+					hasMarker = true
+				}
+			case "script":
+				if isLDJSONScript(n) && n.FirstChild != nil && declaresNotFree(n.FirstChild.Data) {
+					hasMarker = true
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return isArticle && hasMarker
+}
+
+func isLDJSONScript(n *html.Node) bool {
+	for _, attr := range n.Attr {
+		if attr.Key == "type" && strings.EqualFold(attr.Val, "application/ld+json") {
+			return true
+		}
+	}
+	return false
+}
+
+func declaresNotFree(jsonLD string) bool {
+	compact := strings.Join(strings.Fields(strings.ToLower(jsonLD)), "")
+	return strings.Contains(compact, `"isaccessibleforfree":false`) ||
+		strings.Contains(compact, `"isaccessibleforfree":"false"`)
+}
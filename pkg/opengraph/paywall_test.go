@@ -0,0 +1,141 @@
+package opengraph
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/testutil"
+	"github.com/lepinkainen/feed-forge/pkg/urlutils"
+	"golang.org/x/net/html"
+)
+
+func TestIsPaywalledDomain(t *testing.T) {
+	domains := []string{"paywalled.example", " News.Example "}
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://paywalled.example/story", true},
+		{"https://www.paywalled.example/story", true},
+		{"https://news.example/a", true},
+		{"https://notpaywalled.example/story", false},
+		{"https://example.com/", false},
+		{"://bad", false},
+	}
+	for _, tt := range tests {
+		if got := isPaywalledDomain(tt.url, domains); got != tt.want {
+			t.Errorf("isPaywalledDomain(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestHasPaywallMarkers(t *testing.T) {
+	tests := []struct {
+		name string
+		page string
+		want bool
+	}{
+		{
+			name: "locked content tier",
+			page: `<meta property="og:type" content="article"><meta property="article:content_tier" content="locked">`,
+			want: true,
+		},
+		{
+			name: "json-ld not free",
+			page: `<meta property="og:type" content="article"><script type="application/ld+json">{"@type":"NewsArticle", "isAccessibleForFree": false}</script>`,
+			want: true,
+		},
+		{
+			name: "marker without article type",
+			page: `<meta property="og:type" content="website"><meta property="article:content_tier" content="locked">`,
+			want: false,
+		},
+		{
+			name: "free article",
+			page: `<meta property="og:type" content="article"><meta property="article:content_tier" content="free">`,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader("<html><head>" + tt.page + "</head></html>"))
+			if err != nil {
+				t.Fatalf("html.Parse() error = %v", err)
+			}
+			if got := hasPaywallMarkers(doc); got != tt.want {
+				t.Fatalf("hasPaywallMarkers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchData_FlagsPaywalledDomain(t *testing.T) {
+	db := newTestOGDB(t)
+	fetcher := NewFetcher(db)
+	fetcher.PaywallDomains = []string{"example.com"}
+
+	fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}}
+
+	targetURL := "https://example.com/cached"
+	now := time.Now().UTC()
+	if err := db.SaveCachedData(&Data{URL: targetURL, Title: "Cached", FetchedAt: now, ExpiresAt: now.Add(time.Hour)}, true); err != nil {
+		t.Fatalf("SaveCachedData() error = %v", err)
+	}
+
+	data, err := fetcher.FetchData(targetURL)
+	if err != nil {
+		t.Fatalf("FetchData() error = %v", err)
+	}
+	if data == nil || !data.Paywalled {
+		t.Fatalf("FetchData() = %#v, want Paywalled", data)
+	}
+}
+
+func TestFetchData_PaywallFlagSetBeforeCaching(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><head><meta property="og:title" content="Story"></head></html>`))
+	}))
+	defer server.Close()
+
+	db := newTestOGDB(t)
+	fetcher := NewFetcher(db)
+	fetcher.PaywallDomains = []string{"example.com"}
+	fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}}
+	fetcher.client.Transport = rewriteHostTransport(server)
+
+	const callers = 8
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := fetcher.FetchData("http://example.com/story")
+			if err != nil || data == nil || !data.Paywalled {
+				t.Errorf("FetchData() = %#v, %v, want Paywalled data", data, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if memory := fetcher.memoryCached(urlutils.CanonicalURL("http://example.com/story")); memory == nil || !memory.Paywalled {
+		t.Fatalf("memory cache entry = %#v, want Paywalled", memory)
+	}
+	stored, err := db.GetCachedData(urlutils.CanonicalURL("http://example.com/story"))
+	if err != nil || stored == nil || !stored.Paywalled {
+		t.Fatalf("GetCachedData() = %#v, %v, want Paywalled", stored, err)
+	}
+}
@@ -18,8 +18,8 @@ func (f *Fetcher) applyDomainRateLimit(ctx context.Context, targetURL string) er
 	f.domainMutex.Lock()
 	if lastFetch, exists := f.lastFetch[domain]; exists {
 		timeSinceLastFetch := time.Since(lastFetch)
-		if timeSinceLastFetch < time.Second {
-			sleepTime := time.Second - timeSinceLastFetch
+		if interval := f.domainInterval(domain); timeSinceLastFetch < interval {
+			sleepTime := interval - timeSinceLastFetch
 			f.domainMutex.Unlock()
 			slog.Debug("Rate limiting domain", "domain", domain, "sleep", sleepTime)
 			select {
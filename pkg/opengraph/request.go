@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lepinkainen/feed-forge/pkg/api"
 	"golang.org/x/net/html"
 )
 
@@ -30,16 +31,20 @@ func (f *Fetcher) fetchFreshData(ctx context.Context, targetURL string) (*Data,
 }
 
 func (f *Fetcher) fetchFreshDataConditional(ctx context.Context, targetURL, etag, lastModified string) (*Data, error) {
-	v, err, _ := f.fetchGroup.Do(targetURL, func() (any, error) {
+	v, err, shared := f.fetchGroup.Do(targetURL, func() (any, error) {
 		return f.doFetchConditional(ctx, targetURL, etag, lastModified)
 	})
 	if err != nil {
 		return nil, err
 	}
-	if v == nil {
-		return nil, nil
+	data, _ := v.(*Data)
+	if data != nil && shared {
+		// Callers clean up and flag their result before caching it, so each
+		// gets its own copy.
+		copied := *data
+		data = &copied
 	}
-	return v.(*Data), nil
+	return data, nil
 }
 
 func (f *Fetcher) doFetchConditional(ctx context.Context, targetURL, etag, lastModified string) (*Data, error) {
@@ -47,12 +52,20 @@ func (f *Fetcher) doFetchConditional(ctx context.Context, targetURL, etag, lastM
 	case f.semaphore <- struct{}{}:
 		defer func() { <-f.semaphore }()
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return nil, fmt.Errorf("%w: %w", errNotSent, ctx.Err())
 	}
 
 	if err := f.applyDomainRateLimit(ctx, targetURL); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", errNotSent, err)
+	}
+	if err := f.Outbound.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("%w: %w", errNotSent, err)
 	}
+	defer f.Outbound.Release()
+
+	// The timeout covers the HTTP exchange only, not the waits above.
+	ctx, cancel := context.WithTimeout(ctx, f.requestTimeout())
+	defer cancel()
 
 	req, useProxy, err := f.buildFetchRequest(ctx, targetURL, etag, lastModified)
 	if err != nil {
@@ -64,6 +77,9 @@ func (f *Fetcher) doFetchConditional(ctx context.Context, targetURL, etag, lastM
 		slog.Debug("Fetching OpenGraph data", "url", targetURL)
 	}
 
+	if err := api.SpendRequest(); err != nil {
+		return nil, err
+	}
 	resp, err := f.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
@@ -96,19 +112,40 @@ func (f *Fetcher) doFetchConditional(ctx context.Context, targetURL, etag, lastM
 		return nil, fmt.Errorf("failed to parse HTML: %w", err)
 	}
 
+	finalURL := targetURL
+	if !useProxy && resp.Request != nil {
+		finalURL = resp.Request.URL.String()
+	}
+
 	now := time.Now()
 	data := &Data{
 		URL:          targetURL,
 		ETag:         resp.Header.Get("ETag"),
 		LastModified: resp.Header.Get("Last-Modified"),
+		FinalURL:     finalURL,
 		FetchedAt:    now,
 		ExpiresAt:    now.Add(time.Duration(DefaultCacheHours) * time.Hour),
 	}
 	extractOpenGraphTags(doc, data)
+	applyJSONLD(doc, data)
+	applySiteNameFallback(data, finalURL, f.SiteNames)
+	f.dumpFetch(targetURL, htmlContent, data)
+	if f.MinBodyBytes > 0 && len(htmlContent) < f.MinBodyBytes && !hasOpenGraphTags(doc) {
+		slog.Debug("Page too small for OpenGraph data", "url", targetURL, "bytes", len(htmlContent), "min", f.MinBodyBytes)
+		return nil, errBodyTooSmall
+	}
+	if isSoft404(data, len(htmlContent), f.Soft404Phrases) {
+		slog.Debug("Detected soft 404 page", "url", targetURL, "title", data.Title)
+		return nil, errSoft404
+	}
+	data.Paywalled = hasPaywallMarkers(doc)
 	slog.Debug("Extracted OpenGraph data", "url", targetURL, "title", data.Title, "hasDescription", data.Description != "")
 	return data, nil
 }
 
+// fetchUserAgent is sent with page and robots.txt requests.
+const fetchUserAgent = "Mozilla/5.0 (compatible; FeedForge/1.0; OpenGraph fetcher)"
+
 func (f *Fetcher) buildFetchRequest(ctx context.Context, targetURL, etag, lastModified string) (*http.Request, bool, error) {
 	requestURL := targetURL
 	useProxy := f.proxy != nil && isProxiableRedditURL(targetURL)
@@ -120,9 +157,13 @@ func (f *Fetcher) buildFetchRequest(ctx context.Context, targetURL, etag, lastMo
 	if err != nil {
 		return nil, useProxy, fmt.Errorf("failed to create request: %w", err)
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; FeedForge/1.0; OpenGraph fetcher)")
+	req.Header.Set("User-Agent", fetchUserAgent)
 	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.5")
+	acceptLanguage := f.AcceptLanguage
+	if acceptLanguage == "" {
+		acceptLanguage = DefaultAcceptLanguage
+	}
+	req.Header.Set("Accept-Language", acceptLanguage)
 	req.Header.Set("Accept-Encoding", "gzip")
 	req.Header.Set("Connection", "keep-alive")
 	if etag != "" {
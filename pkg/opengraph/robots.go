@@ -0,0 +1,251 @@
+package opengraph
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/api"
+)
+
+const (
+	// DefaultRobotsTTL is how long a domain's parsed robots.txt is reused.
+	DefaultRobotsTTL = 24 * time.Hour
+
+	// robotsAgent is the product token matched against User-agent lines.
+	robotsAgent = "feedforge"
+
+	maxRobotsSize = 512 * 1024
+)
+
+// defaultDomainInterval is the minimum time between fetches from one domain
+// when robots.txt sets no Crawl-delay.
+const defaultDomainInterval = time.Second
+
+// robotsRules are the robots.txt directives that apply to the fetcher.
+type robotsRules struct {
+	allow      []string
+	disallow   []string
+	crawlDelay time.Duration
+	fetchedAt  time.Time
+}
+
+// parseRobots returns the rules of the group for agent, falling back to the
+// "*" group. A group applies when its User-agent product token equals agent,
+// ignoring case and any /version suffix; it wins over "*" even when it comes
+// later.
+func parseRobots(r io.Reader, agent string) robotsRules {
+	var specific, wildcard robotsRules
+	var haveSpecific bool
+	var groupAgents []string
+	inRules := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if key == "user-agent" {
+			// A User-agent after rules starts a new group.
+			if inRules {
+				groupAgents, inRules = nil, false
+			}
+			token, _, _ := strings.Cut(value, "/")
+			groupAgents = append(groupAgents, strings.TrimSpace(token))
+			continue
+		}
+		inRules = true
+
+		for _, groupAgent := range groupAgents {
+			var target *robotsRules
+			switch {
+			case groupAgent != "*" && strings.EqualFold(groupAgent, agent):
+				target, haveSpecific = &specific, true
+			case groupAgent == "*":
+				target = &wildcard
+			default:
+				continue
+			}
+			switch key {
+			case "allow":
+				if value != "" {
+					target.allow = append(target.allow, value)
+				}
+			case "disallow":
+				if value != "" {
+					target.disallow = append(target.disallow, value)
+				}
+			case "crawl-delay":
+				if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+					target.crawlDelay = time.Duration(seconds * float64(time.Second))
+				}
+			}
+		}
+	}
+
+	if haveSpecific {
+		return specific
+	}
+	return wildcard
+}
+
+// allowed reports whether path (with any query) may be fetched: the longest
+// matching Allow or Disallow rule decides, Allow winning ties.
+func (r robotsRules) allowed(path string) bool {
+	bestAllow, bestDisallow := -1, -1
+	for _, pattern := range r.allow {
+		if robotsMatch(pattern, path) {
+			bestAllow = max(bestAllow, len(pattern))
+		}
+	}
+	for _, pattern := range r.disallow {
+		if robotsMatch(pattern, path) {
+			bestDisallow = max(bestDisallow, len(pattern))
+		}
+	}
+	return bestDisallow < 0 || bestAllow >= bestDisallow
+}
+
+// robotsMatch matches a robots.txt path pattern against path. Patterns match
+// path prefixes; * matches any run of characters and a trailing $ anchors the
+// pattern to the end of path.
+func robotsMatch(pattern, path string) bool {
+	if anchored, ok := strings.CutSuffix(pattern, "$"); ok {
+		return globMatch(anchored, path)
+	}
+	return globMatch(pattern+"*", path)
+}
+
+// globMatch reports whether all of s matches pattern, where * matches any
+// run of characters.
+func globMatch(pattern, s string) bool {
+	star, resume := -1, 0
+	p, i := 0, 0
+	for i < len(s) {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			star, resume = p, i
+			p++
+		case p < len(pattern) && pattern[p] == s[i]:
+			p++
+			i++
+		case star >= 0:
+			resume++
+			p, i = star+1, resume
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// robotsAllowed reports whether robots.txt of targetURL's host lets the
+// fetcher request it. Rules are fetched once per host and cached for
+// DefaultRobotsTTL; a missing or unreadable robots.txt allows everything.
+func (f *Fetcher) robotsAllowed(ctx context.Context, targetURL string) bool {
+	parsed, err := url.Parse(targetURL)
+	if err != nil {
+		return true
+	}
+	rules := f.robotsFor(ctx, parsed)
+	path := parsed.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if parsed.RawQuery != "" {
+		path += "?" + parsed.RawQuery
+	}
+	return rules.allowed(path)
+}
+
+// robotsFor returns the cached rules for u's host, fetching them when absent
+// or older than DefaultRobotsTTL.
+func (f *Fetcher) robotsFor(ctx context.Context, u *url.URL) robotsRules {
+	f.domainMutex.Lock()
+	rules, ok := f.robots[u.Host]
+	f.domainMutex.Unlock()
+	if ok && time.Since(rules.fetchedAt) < DefaultRobotsTTL {
+		return rules
+	}
+
+	v, _, _ := f.fetchGroup.Do("robots:"+u.Host, func() (any, error) {
+		rules, err := f.fetchRobots(ctx, u.Scheme+"://"+u.Host+"/robots.txt")
+		if err != nil {
+			slog.Debug("Ignoring unreadable robots.txt", "host", u.Host, "error", err)
+		}
+		if ctx.Err() != nil {
+			// Cancelled mid-fetch: allow this request but try again next time.
+			return rules, nil
+		}
+		f.domainMutex.Lock()
+		f.robots[u.Host] = rules
+		f.domainMutex.Unlock()
+		return rules, nil
+	})
+	return v.(robotsRules)
+}
+
+// fetchRobots downloads and parses robots.txt. Like page fetches it waits for
+// the domain's rate limit and an Outbound slot, and only the request itself
+// is bounded by RequestTimeout. Any error gives rules that
+// allow everything, still stamped so the host isn't retried until they expire.
+func (f *Fetcher) fetchRobots(ctx context.Context, robotsURL string) (robotsRules, error) {
+	rules := robotsRules{fetchedAt: time.Now()}
+	if err := f.applyDomainRateLimit(ctx, robotsURL); err != nil {
+		return rules, err
+	}
+	if err := f.Outbound.Acquire(ctx); err != nil {
+		return rules, err
+	}
+	defer f.Outbound.Release()
+	if err := api.SpendRequest(); err != nil {
+		return rules, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, f.requestTimeout())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL, http.NoBody)
+	if err != nil {
+		return rules, err
+	}
+	req.Header.Set("User-Agent", fetchUserAgent)
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return rules, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return rules, fmt.Errorf("robots.txt status %d", resp.StatusCode)
+	}
+
+	parsed := parseRobots(io.LimitReader(resp.Body, maxRobotsSize), robotsAgent)
+	parsed.fetchedAt = rules.fetchedAt
+	return parsed, nil
+}
+
+// domainInterval returns the minimum time between fetches from domain: its
+// robots.txt Crawl-delay with RespectRobots, otherwise defaultDomainInterval.
+// Callers hold domainMutex.
+func (f *Fetcher) domainInterval(domain string) time.Duration {
+	if f.RespectRobots {
+		if rules, ok := f.robots[domain]; ok && rules.crawlDelay > 0 {
+			return rules.crawlDelay
+		}
+	}
+	return defaultDomainInterval
+}
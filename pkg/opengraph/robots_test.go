@@ -0,0 +1,224 @@
+package opengraph
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/api"
+	"github.com/lepinkainen/feed-forge/pkg/testutil"
+)
+
+func TestParseRobots(t *testing.T) {
+	robots := `# comment
+User-agent: otherbot
+Disallow: /
+
+User-agent: *
+Disallow: /private
+Allow: /private/open
+Disallow: /*.pdf$
+Crawl-delay: 2.5
+`
+	rules := parseRobots(strings.NewReader(robots), robotsAgent)
+	if rules.crawlDelay != 2500*time.Millisecond {
+		t.Fatalf("crawlDelay = %v, want 2.5s", rules.crawlDelay)
+	}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/", true},
+		{"/public/page", true},
+		{"/private", false},
+		{"/private/secret", false},
+		{"/private/open/page", true},
+		{"/docs/paper.pdf", false},
+		{"/docs/paper.pdf?x=1", true},
+	}
+	for _, tt := range tests {
+		if got := rules.allowed(tt.path); got != tt.want {
+			t.Errorf("allowed(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestParseRobots_SpecificAgentGroupWins(t *testing.T) {
+	robots := `User-agent: *
+Disallow: /
+
+User-agent: FeedForge
+Disallow: /admin
+`
+	rules := parseRobots(strings.NewReader(robots), robotsAgent)
+	if !rules.allowed("/article") {
+		t.Fatal("allowed(/article) = false, want the feedforge group to override *")
+	}
+	if rules.allowed("/admin/users") {
+		t.Fatal("allowed(/admin/users) = true, want false")
+	}
+}
+
+func TestParseRobots_AgentMatchesWholeProductToken(t *testing.T) {
+	tests := []struct {
+		groupAgent string
+		applies    bool
+	}{
+		{"FeedForge", true},
+		{"feedforge/2.0", true},
+		{"f", false},
+		{"feed", false},
+		{"feedforgebot", false},
+	}
+	for _, tt := range tests {
+		robots := "User-agent: *\nDisallow: /wild\n\nUser-agent: " + tt.groupAgent + "\nDisallow: /specific\n"
+		rules := parseRobots(strings.NewReader(robots), robotsAgent)
+		if got := !rules.allowed("/specific"); got != tt.applies {
+			t.Errorf("User-agent %q applies = %v, want %v", tt.groupAgent, got, tt.applies)
+		}
+	}
+}
+
+func newRobotsTestFetcher(t *testing.T, robots string) (*Fetcher, *atomic.Int32, *atomic.Int32) {
+	t.Helper()
+	var robotsHits, pageHits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			robotsHits.Add(1)
+			_, _ = w.Write([]byte(robots))
+			return
+		}
+		pageHits.Add(1)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><head><meta property="og:title" content="Page"></head></html>`))
+	}))
+	t.Cleanup(server.Close)
+
+	fetcher := NewFetcher(newTestOGDB(t))
+	fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}}
+	fetcher.client.Transport = rewriteHostTransport(server)
+	return fetcher, &robotsHits, &pageHits
+}
+
+func TestFetchData_RespectRobots(t *testing.T) {
+	fetcher, robotsHits, pageHits := newRobotsTestFetcher(t, "User-agent: *\nDisallow: /private\nCrawl-delay: 1.5\n")
+	fetcher.RespectRobots = true
+
+	data, err := fetcher.FetchData("http://example.invalid/private/post")
+	if err != nil {
+		t.Fatalf("FetchData(disallowed) error = %v", err)
+	}
+	if data != nil {
+		t.Fatalf("FetchData(disallowed) = %#v, want nil", data)
+	}
+	if pageHits.Load() != 0 {
+		t.Fatalf("page hits = %d, want 0 for a disallowed path", pageHits.Load())
+	}
+
+	data, err = fetcher.FetchData("http://example.invalid/public/post")
+	if err != nil {
+		t.Fatalf("FetchData(allowed) error = %v", err)
+	}
+	if data == nil || data.Title != "Page" {
+		t.Fatalf("FetchData(allowed) = %#v, want page data", data)
+	}
+	if robotsHits.Load() != 1 {
+		t.Fatalf("robots.txt hits = %d, want 1 (cached per host)", robotsHits.Load())
+	}
+
+	fetcher.domainMutex.Lock()
+	interval := fetcher.domainInterval("example.invalid")
+	fetcher.domainMutex.Unlock()
+	if interval != 1500*time.Millisecond {
+		t.Fatalf("domainInterval() = %v, want Crawl-delay 1.5s", interval)
+	}
+}
+
+func TestFetchData_IgnoresRobotsByDefault(t *testing.T) {
+	fetcher, robotsHits, pageHits := newRobotsTestFetcher(t, "User-agent: *\nDisallow: /\nCrawl-delay: 30\n")
+
+	data, err := fetcher.FetchData("http://example.invalid/private/post")
+	if err != nil {
+		t.Fatalf("FetchData() error = %v", err)
+	}
+	if data == nil || pageHits.Load() == 0 {
+		t.Fatalf("FetchData() = %#v with %d page hits, want the page fetched", data, pageHits.Load())
+	}
+	if robotsHits.Load() != 0 {
+		t.Fatalf("robots.txt hits = %d, want 0 without RespectRobots", robotsHits.Load())
+	}
+	if interval := fetcher.domainInterval("example.invalid"); interval != defaultDomainInterval {
+		t.Fatalf("domainInterval() = %v, want %v", interval, defaultDomainInterval)
+	}
+}
+
+func TestRobotsFor_ExpiredRulesAreRefetched(t *testing.T) {
+	fetcher, robotsHits, _ := newRobotsTestFetcher(t, "User-agent: *\nDisallow: /private\n")
+	fetcher.RespectRobots = true
+	fetcher.robots["example.invalid"] = robotsRules{fetchedAt: time.Now().Add(-DefaultRobotsTTL - time.Minute)}
+
+	if fetcher.robotsAllowed(context.Background(), "http://example.invalid/private") {
+		t.Fatal("robotsAllowed() = true, want expired rules replaced by a fresh robots.txt")
+	}
+	if robotsHits.Load() != 1 {
+		t.Fatalf("robots.txt hits = %d, want 1", robotsHits.Load())
+	}
+}
+
+func TestFetchRobots_WaitsForRateLimitAndOutbound(t *testing.T) {
+	fetcher, robotsHits, _ := newRobotsTestFetcher(t, "User-agent: *\nDisallow: /private\n")
+	fetcher.RespectRobots = true
+
+	fetcher.domainMutex.Lock()
+	fetcher.lastFetch["example.invalid"] = time.Now()
+	fetcher.domainMutex.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := fetcher.fetchRobots(ctx, "http://example.invalid/robots.txt"); err == nil {
+		t.Fatal("fetchRobots() error = nil, want the domain rate limit to outlast the context")
+	}
+
+	fetcher.lastFetch = make(map[string]time.Time)
+	fetcher.Outbound = api.NewSemaphore(1)
+	if err := fetcher.Outbound.Acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := fetcher.fetchRobots(ctx, "http://example.invalid/robots.txt"); err == nil {
+		t.Fatal("fetchRobots() error = nil, want it to wait for an Outbound slot")
+	}
+
+	if robotsHits.Load() != 0 {
+		t.Fatalf("robots.txt hits = %d, want 0 while rate limited or out of slots", robotsHits.Load())
+	}
+}
+
+func TestFetchData_CrawlDelayLongerThanRequestTimeout(t *testing.T) {
+	fetcher, robotsHits, pageHits := newRobotsTestFetcher(t, "User-agent: *\nCrawl-delay: 0.5\n")
+	fetcher.RespectRobots = true
+	fetcher.RequestTimeout = 200 * time.Millisecond
+
+	start := time.Now()
+	data, err := fetcher.FetchData("http://example.invalid/first")
+	if err != nil {
+		t.Fatalf("FetchData() error = %v, want the crawl delay waited out before the request timeout starts", err)
+	}
+	if data == nil || data.Title != "Page" {
+		t.Fatalf("FetchData() = %#v, want page data", data)
+	}
+	if robotsHits.Load() != 1 || pageHits.Load() != 1 {
+		t.Fatalf("robots.txt hits = %d, page hits = %d, want 1 each", robotsHits.Load(), pageHits.Load())
+	}
+	if elapsed := time.Since(start); elapsed < 500*time.Millisecond {
+		t.Fatalf("FetchData() took %v, want the page fetched after the 0.5s crawl delay", elapsed)
+	}
+}
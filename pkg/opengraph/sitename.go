@@ -0,0 +1,73 @@
+package opengraph
+
+import (
+	"net/url"
+	"strings"
+)
+
+// DefaultSiteNames maps hosts to the publication names used when a page has no
+// og:site_name. Subdomains of a listed host match it too.
+var DefaultSiteNames = map[string]string{
+	"youtube.com":           "YouTube",
+	"youtu.be":              "YouTube",
+	"github.com":            "GitHub",
+	"gitlab.com":            "GitLab",
+	"reddit.com":            "Reddit",
+	"news.ycombinator.com":  "Hacker News",
+	"twitter.com":           "Twitter",
+	"x.com":                 "X",
+	"medium.com":            "Medium",
+	"substack.com":          "Substack",
+	"wikipedia.org":         "Wikipedia",
+	"arxiv.org":             "arXiv",
+	"stackoverflow.com":     "Stack Overflow",
+	"nytimes.com":           "The New York Times",
+	"theguardian.com":       "The Guardian",
+	"bbc.co.uk":             "BBC",
+	"bbc.com":               "BBC",
+	"arstechnica.com":       "Ars Technica",
+	"theverge.com":          "The Verge",
+	"bloomberg.com":         "Bloomberg",
+	"washingtonpost.com":    "The Washington Post",
+	"techcrunch.com":        "TechCrunch",
+	"yle.fi":                "Yle",
+	"hs.fi":                 "Helsingin Sanomat",
+	"developer.mozilla.org": "MDN Web Docs",
+}
+
+// siteHostPrefixes are stripped from hosts before the fallback is applied.
+var siteHostPrefixes = []string{"www.", "m.", "mobile."}
+
+// applySiteNameFallback fills an empty SiteName from the host of pageURL:
+// the name from names or DefaultSiteNames for the host or a parent domain,
+// otherwise the host without a www., m. or mobile. prefix.
+func applySiteNameFallback(data *Data, pageURL string, names map[string]string) {
+	if data.SiteName != "" {
+		return
+	}
+	parsed, err := url.Parse(pageURL)
+	if err != nil || parsed.Hostname() == "" {
+		return
+	}
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	for _, prefix := range siteHostPrefixes {
+		host = strings.TrimPrefix(host, prefix)
+	}
+
+	for domain := host; domain != ""; {
+		if name, ok := names[domain]; ok {
+			data.SiteName = name
+			return
+		}
+		if name, ok := DefaultSiteNames[domain]; ok {
+			data.SiteName = name
+			return
+		}
+		_, parent, found := strings.Cut(domain, ".")
+		if !found || !strings.Contains(parent, ".") {
+			break
+		}
+		domain = parent
+	}
+	data.SiteName = host
+}
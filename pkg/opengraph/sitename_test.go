@@ -0,0 +1,36 @@
+package opengraph
+
+import "testing"
+
+func TestApplySiteNameFallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		pageURL string
+		names   map[string]string
+		want    string
+	}{
+		{"mobile YouTube", "https://m.youtube.com/watch?v=abc", nil, "YouTube"},
+		{"GitHub", "https://github.com/lepinkainen/feed-forge", nil, "GitHub"},
+		{"subdomain of mapped host", "https://old.reddit.com/r/golang", nil, "Reddit"},
+		{"unmapped host stripped", "https://www.example.co.uk/story", nil, "example.co.uk"},
+		{"configured host", "https://blog.example.org/post", map[string]string{"example.org": "Example Blog"}, "Example Blog"},
+		{"configured overrides built-in", "https://github.com/x", map[string]string{"github.com": "GH"}, "GH"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &Data{}
+			applySiteNameFallback(data, tt.pageURL, tt.names)
+			if data.SiteName != tt.want {
+				t.Errorf("SiteName = %q, want %q", data.SiteName, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplySiteNameFallback_KeepsOGSiteName(t *testing.T) {
+	data := &Data{SiteName: "Own Name"}
+	applySiteNameFallback(data, "https://m.youtube.com/watch", nil)
+	if data.SiteName != "Own Name" {
+		t.Errorf("SiteName = %q, want og:site_name kept", data.SiteName)
+	}
+}
@@ -0,0 +1,49 @@
+package opengraph
+
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultSoft404Phrases are title/description fragments that mark a 200
+// response as a "not found" page in disguise.
+var DefaultSoft404Phrases = []string{
+	"page not found",
+	"404 not found",
+	"error 404",
+	"404 error",
+	"page cannot be found",
+	"page could not be found",
+	"page doesn't exist",
+	"page does not exist",
+	"no longer available",
+	"sivua ei löytynyt",
+}
+
+// soft404MaxBodyBytes is the body size below which a page without any
+// title, description or image is treated as an empty error page.
+const soft404MaxBodyBytes = 512
+
+// errBodyTooSmall is returned for pages below Fetcher.MinBodyBytes without og:* tags.
+var errBodyTooSmall = errors.New("page too small for OpenGraph data")
+
+// errSoft404 is returned for pages that answered 200 but look like a missing page.
+var errSoft404 = errors.New("soft 404 page")
+
+// isSoft404 reports whether a fetched page is a "not found" page served with a
+// 200 status: its title (or description, for untitled pages) matches one of
+// phrases, or the body is tiny and carries no metadata at all.
+func isSoft404(data *Data, bodyLen int, phrases []string) bool {
+	text := data.Title
+	if text == "" {
+		text = data.Description
+	}
+	text = strings.ToLower(text)
+	for _, phrase := range phrases {
+		phrase = strings.ToLower(strings.TrimSpace(phrase))
+		if phrase != "" && strings.Contains(text, phrase) {
+			return true
+		}
+	}
+	return bodyLen < soft404MaxBodyBytes && data.Title == "" && data.Description == "" && data.Image == ""
+}
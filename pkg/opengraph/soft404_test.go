@@ -0,0 +1,103 @@
+package opengraph
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lepinkainen/feed-forge/pkg/testutil"
+)
+
+func TestIsSoft404(t *testing.T) {
+	longBody := 4096
+	tests := []struct {
+		name    string
+		data    Data
+		bodyLen int
+		want    bool
+	}{
+		{name: "not found title", data: Data{Title: "Page Not Found | Example News"}, bodyLen: longBody, want: true},
+		{name: "finnish title", data: Data{Title: "Sivua ei löytynyt"}, bodyLen: longBody, want: true},
+		{name: "untitled with not found description", data: Data{Description: "Error 404: the page you requested"}, bodyLen: longBody, want: true},
+		{name: "article mentioning phrase in description", data: Data{Title: "Lost dog returns home", Description: "The page does not exist anymore, says owner"}, bodyLen: longBody, want: false},
+		{name: "tiny empty page", data: Data{}, bodyLen: 120, want: true},
+		{name: "tiny page with title", data: Data{Title: "Short but real"}, bodyLen: 120, want: false},
+		{name: "regular article", data: Data{Title: "Go 1.27 released", Description: "What's new"}, bodyLen: longBody, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSoft404(&tt.data, tt.bodyLen, DefaultSoft404Phrases); got != tt.want {
+				t.Fatalf("isSoft404() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchFreshData_Soft404ReturnsNoData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><title>Oops! Page not found</title>` +
+			`<meta property="og:description" content="Try searching instead"></head><body>` +
+			strings.Repeat("<p>Popular stories</p>", 50) + `</body></html>`))
+	}))
+	defer server.Close()
+
+	db := newTestOGDB(t)
+	fetcher := NewFetcher(db)
+	fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}}
+	fetcher.client.Transport = rewriteHostTransport(server)
+
+	targetURL := "http://soft404.example.invalid/missing"
+	if data, _ := fetcher.fetchFreshData(context.Background(), targetURL); data != nil {
+		t.Fatalf("fetchFreshData() = %#v, want nil for soft 404", data)
+	}
+
+	fetcher.Soft404Phrases = []string{"something else"}
+	data, err := fetcher.fetchFreshData(context.Background(), targetURL)
+	if err != nil || data == nil {
+		t.Fatalf("fetchFreshData() with custom phrases = (%v, %v), want data", data, err)
+	}
+}
+
+func TestFetchData_MinBodyBytesCachesTinyPagesAsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/stub":
+			_, _ = w.Write([]byte(`<html><head><title>Redirecting</title></head><body>Moved</body></html>`))
+		default:
+			_, _ = w.Write([]byte(`<html><head><meta property="og:title" content="Small but tagged"></head></html>`))
+		}
+	}))
+	defer server.Close()
+
+	db := newTestOGDB(t)
+	fetcher := NewFetcher(db)
+	fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}}
+	fetcher.client.Transport = rewriteHostTransport(server)
+	fetcher.MinBodyBytes = 1024
+
+	stubURL := "http://tiny.example.invalid/stub"
+	if data, _ := fetcher.FetchData(stubURL); data != nil {
+		t.Fatalf("FetchData() = %#v, want nil for a tiny page without og tags", data)
+	}
+	if cached, err := db.GetCachedData(stubURL); err != nil || cached != nil {
+		t.Fatalf("GetCachedData() = (%#v, %v), want no successful cache row", cached, err)
+	}
+	if failed, err := db.HasRecentFailure(stubURL); err != nil || !failed {
+		t.Fatalf("HasRecentFailure() = (%v, %v), want recorded failure", failed, err)
+	}
+
+	data, err := fetcher.FetchData("http://tiny.example.invalid/tagged")
+	if err != nil || data == nil || data.Title != "Small but tagged" {
+		t.Fatalf("FetchData() for tiny tagged page = (%#v, %v), want data", data, err)
+	}
+}
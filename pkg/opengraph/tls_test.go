@@ -0,0 +1,69 @@
+package opengraph
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lepinkainen/feed-forge/pkg/testutil"
+)
+
+// newSelfSignedFetcher returns a fetcher whose connections all go to server,
+// keeping the fetcher's own TLS settings.
+func newSelfSignedFetcher(t *testing.T, server *httptest.Server, insecure bool, domains []string) *Fetcher {
+	t.Helper()
+	fetcher := NewFetcher(nil)
+	fetcher.resolver = testutil.StubResolver{Lookup: func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}}
+	if insecure {
+		if err := fetcher.EnableInsecureTLS(domains); err != nil {
+			t.Fatalf("EnableInsecureTLS() error = %v", err)
+		}
+	}
+	transport := fetcher.client.Transport.(*http.Transport)
+	serverAddr := server.Listener.Addr().String()
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, network, serverAddr)
+	}
+	return fetcher
+}
+
+func TestEnableInsecureTLS_SelfSignedServer(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<!doctype html><html><head><meta property="og:title" content="Internal"></head></html>`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		insecure bool
+		domains  []string
+		wantOK   bool
+	}{
+		{name: "strict by default", insecure: false, wantOK: false},
+		{name: "insecure for all", insecure: true, wantOK: true},
+		{name: "insecure for listed domain", insecure: true, domains: []string{"selfsigned.internal"}, wantOK: true},
+		{name: "other domain still verified", insecure: true, domains: []string{"other.internal"}, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := newSelfSignedFetcher(t, server, tt.insecure, tt.domains)
+			data, err := fetcher.FetchData("https://app.selfsigned.internal/page")
+			if tt.wantOK {
+				if err != nil || data == nil || data.Title != "Internal" {
+					t.Fatalf("FetchData() = %#v, %v; want fetched data", data, err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("FetchData() succeeded against a self-signed certificate, want verification error")
+			}
+		})
+	}
+}
@@ -8,15 +8,44 @@ type Data struct {
 	Title        string    `json:"title"`
 	Description  string    `json:"description"`
 	Image        string    `json:"image"`
+	Audio        string    `json:"audio,omitempty"`      // og:audio URL
+	AudioType    string    `json:"audio_type,omitempty"` // og:audio:type MIME type
+	Player       Player    `json:"player,omitzero"`      // twitter:player embed
 	SiteName     string    `json:"site_name"`
 	ETag         string    `json:"etag"`
 	LastModified string    `json:"last_modified"`
 	FetchedAt    time.Time `json:"fetched_at"`
 	ExpiresAt    time.Time `json:"expires_at"`
+	Paywalled    bool      `json:"paywalled"`
+	FinalURL     string    `json:"final_url,omitempty"` // URL after following redirects
+}
+
+// Player is a Twitter Card player: an embeddable HTML page playing the
+// linked media. Width and Height are zero when the page omits them.
+type Player struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
 }
 
 // Constants for OpenGraph caching
 const (
 	DefaultCacheHours = 24
 	DefaultDBFile     = "opengraph.db"
+
+	// DefaultProgressInterval is how many completed URLs FetchConcurrent
+	// processes between progress log lines.
+	DefaultProgressInterval = 25
+
+	// DefaultMaxRedirects matches net/http's own redirect limit.
+	DefaultMaxRedirects = 10
+
+	// DefaultMemoryCacheEntries bounds the fetcher's in-memory cache.
+	DefaultMemoryCacheEntries = 1000
+
+	// DefaultAcceptLanguage is sent when no language is configured.
+	DefaultAcceptLanguage = "en-US,en;q=0.5"
+
+	// DefaultRequestTimeout bounds a single page fetch.
+	DefaultRequestTimeout = 10 * time.Second
 )
@@ -3,12 +3,16 @@ package preview
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/lepinkainen/feed-forge/pkg/feed"
-	"github.com/lepinkainen/feed-forge/pkg/providers"
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+	"github.com/lepinkainen/feed-forge/pkg/textutils"
 )
 
 // wrapText wraps text to the specified width, breaking at word boundaries when possible
@@ -51,25 +55,137 @@ func wrapText(text string, width int) string {
 	return result.String()
 }
 
-// FormatCompactListItem formats a single feed item in compact list format
-// Example: "1. [1234↑ 56💬] 2025-10-21T13:33:58+03:00 - Post Title"
-func FormatCompactListItem(index int, item providers.FeedItem) string {
-	score := item.Score()
-	comments := item.CommentCount()
+// List view columns accepted by SetListColumns.
+const (
+	ColumnScore      = "score"
+	ColumnComments   = "comments"
+	ColumnDate       = "date"
+	ColumnTitle      = "title"
+	ColumnAuthor     = "author"
+	ColumnDomain     = "domain"
+	ColumnCategories = "categories"
+)
+
+// DefaultListColumns is the list view layout used unless SetListColumns
+// changes it.
+var DefaultListColumns = []string{ColumnScore, ColumnComments, ColumnDate, ColumnTitle}
+
+// listColumns is the active list view layout. Set via SetListColumns.
+var listColumns = DefaultListColumns
+
+// SetListColumns selects the fields FormatCompactListItem shows, in order.
+// An empty list restores DefaultListColumns.
+func SetListColumns(columns []string) error {
+	if len(columns) == 0 {
+		listColumns = DefaultListColumns
+		return nil
+	}
+
+	normalized := make([]string, 0, len(columns))
+	for _, column := range columns {
+		column = strings.ToLower(strings.TrimSpace(column))
+		switch column {
+		case ColumnScore, ColumnComments, ColumnDate, ColumnTitle, ColumnAuthor, ColumnDomain, ColumnCategories:
+			normalized = append(normalized, column)
+		default:
+			return fmt.Errorf("unknown list column %q", column)
+		}
+	}
+	listColumns = normalized
+	return nil
+}
+
+// FormatCompactListItem formats a single feed item in compact list format,
+// showing the columns chosen with SetListColumns. Score and comments share a
+// bracketed group; empty fields are skipped.
+// Example: "1. [1234↑ 56💬] 2025-10-21T13:33:58+03:00  Post Title"
+func FormatCompactListItem(index int, item feedtypes.FeedItem) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%2d.", index+1)
+
+	sep := " "
+	statsDone := false
+	for _, column := range listColumns {
+		var part string
+		switch column {
+		case ColumnScore, ColumnComments:
+			if statsDone {
+				continue
+			}
+			statsDone = true
+			b.WriteString(sep + compactStats(item))
+			sep = " "
+			continue
+		case ColumnDate:
+			part = item.CreatedAt().Format(time.RFC3339)
+		case ColumnTitle:
+			part = compactTitle(item)
+		case ColumnAuthor:
+			if author := item.Author(); author != "" {
+				part = "by " + author
+			}
+		case ColumnDomain:
+			if domain := itemDomain(item); domain != "" {
+				part = "(" + domain + ")"
+			}
+		case ColumnCategories:
+			part = strings.Join(item.Categories(), ", ")
+		}
+		if part == "" {
+			continue
+		}
+		b.WriteString(sep + part)
+		sep = "  "
+	}
+
+	return b.String()
+}
+
+// compactStats renders the bracketed score/comments group for the columns
+// that are enabled.
+func compactStats(item feedtypes.FeedItem) string {
+	labels := feed.GetOptions().StatLabels
+	var stats []string
+	if slices.Contains(listColumns, ColumnScore) {
+		stats = append(stats, feed.FormatStat(labels.ScoreOr("↑"), fmt.Sprintf("%4s", feed.FormatCount(item.Score()))))
+	}
+	if slices.Contains(listColumns, ColumnComments) {
+		stats = append(stats, feed.FormatStat(labels.CommentsOr("💬"), fmt.Sprintf("%3s", feed.FormatCount(item.CommentCount()))))
+	}
+	return "[" + strings.Join(stats, " ") + "]"
+}
+
+// compactTitle returns the item title, truncated to fit a 120 char line.
+func compactTitle(item feedtypes.FeedItem) string {
 	title := item.Title()
-	dateISO := item.CreatedAt().Format(time.RFC3339)
+	if feed.GetOptions().StripEmoji {
+		title = textutils.StripEmoji(title)
+	}
 
-	// Truncate title if too long (120 char width total)
 	const maxTitleLength = 70
 	if len(title) > maxTitleLength {
 		title = title[:maxTitleLength-3] + "..."
 	}
+	return title
+}
 
-	return fmt.Sprintf("%2d. [%4d↑ %3d💬] %s  %s", index+1, score, comments, dateISO, title)
+// itemDomain returns the item's own domain when it provides one, otherwise
+// the host of its link without a leading "www.".
+func itemDomain(item feedtypes.FeedItem) string {
+	if d, ok := item.(interface{ ItemDomain() string }); ok {
+		if domain := d.ItemDomain(); domain != "" {
+			return domain
+		}
+	}
+	u, err := url.Parse(item.Link())
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(u.Hostname(), "www.")
 }
 
 // FormatDetailedItem formats a single feed item with all metadata
-func FormatDetailedItem(item providers.FeedItem) string {
+func FormatDetailedItem(item feedtypes.FeedItem) string {
 	var b strings.Builder
 
 	b.WriteString("═══════════════════════════════════════════════════════════════════════\n")
@@ -115,9 +231,9 @@ func FormatDetailedItem(item providers.FeedItem) string {
 }
 
 // FormatXMLItem formats a single feed item as an Atom XML entry using the actual feed template
-func FormatXMLItem(item providers.FeedItem, templateName string, config feed.Config) string {
+func FormatXMLItem(item feedtypes.FeedItem, templateName string, config feed.Config) string {
 	// Generate a full feed with just this one item using the real template
-	items := []providers.FeedItem{item}
+	items := []feedtypes.FeedItem{item}
 
 	feedXML, err := feed.GenerateAtomFeedWithEmbeddedTemplate(items, templateName, config, nil)
 	if err != nil {
@@ -135,6 +251,41 @@ func FormatXMLItem(item providers.FeedItem, templateName string, config feed.Con
 	return wrapXMLContent(match, 80)
 }
 
+// FormatXMLFeed renders items as a complete feed using the actual feed
+// template, for inspecting spacing and structure between entries.
+func FormatXMLFeed(items []feedtypes.FeedItem, templateName string, config feed.Config) string {
+	feedXML, err := feed.GenerateAtomFeedWithEmbeddedTemplate(items, templateName, config, nil)
+	if err != nil {
+		return fmt.Sprintf("Error generating feed: %s", err)
+	}
+	return feedXML
+}
+
+// ParseRange parses an "a:b" item range (either bound may be omitted) and
+// clamps it to [0, total]. The returned range is half-open: items[start:end].
+func ParseRange(spec string, total int) (start, end int, err error) {
+	startText, endText, ok := strings.Cut(spec, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid range %q: expected a:b", spec)
+	}
+
+	start, end = 0, total
+	if startText = strings.TrimSpace(startText); startText != "" {
+		if start, err = strconv.Atoi(startText); err != nil {
+			return 0, 0, fmt.Errorf("invalid range start %q: %w", startText, err)
+		}
+	}
+	if endText = strings.TrimSpace(endText); endText != "" {
+		if end, err = strconv.Atoi(endText); err != nil {
+			return 0, 0, fmt.Errorf("invalid range end %q: %w", endText, err)
+		}
+	}
+
+	start = min(max(start, 0), total)
+	end = min(max(end, start), total)
+	return start, end, nil
+}
+
 // wrapXMLContent wraps only the content inside tags, not the tags themselves
 func wrapXMLContent(xml string, width int) string {
 	// Simple approach: just ensure lines don't exceed width by adding newlines
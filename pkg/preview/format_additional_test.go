@@ -4,6 +4,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feed"
 )
 
 func TestFormatDetailedItem_OmitsEmptyOptionalFields(t *testing.T) {
@@ -69,3 +71,121 @@ func TestFormatTimeAgo_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		spec      string
+		total     int
+		wantStart int
+		wantEnd   int
+	}{
+		{spec: "1:3", total: 10, wantStart: 1, wantEnd: 3},
+		{spec: "4:", total: 10, wantStart: 4, wantEnd: 10},
+		{spec: ":2", total: 10, wantStart: 0, wantEnd: 2},
+		{spec: ":", total: 10, wantStart: 0, wantEnd: 10},
+		{spec: "0:50", total: 5, wantStart: 0, wantEnd: 5},
+		{spec: "8:12", total: 5, wantStart: 5, wantEnd: 5},
+		{spec: "-3:2", total: 5, wantStart: 0, wantEnd: 2},
+		{spec: "3:1", total: 5, wantStart: 3, wantEnd: 3},
+	}
+
+	for _, tt := range tests {
+		start, end, err := ParseRange(tt.spec, tt.total)
+		if err != nil {
+			t.Fatalf("ParseRange(%q) error = %v", tt.spec, err)
+		}
+		if start != tt.wantStart || end != tt.wantEnd {
+			t.Errorf("ParseRange(%q, %d) = %d:%d, want %d:%d", tt.spec, tt.total, start, end, tt.wantStart, tt.wantEnd)
+		}
+	}
+}
+
+func TestParseRange_Invalid(t *testing.T) {
+	for _, spec := range []string{"3", "a:2", "1:b"} {
+		if _, _, err := ParseRange(spec, 10); err == nil {
+			t.Errorf("ParseRange(%q) error = nil, want error", spec)
+		}
+	}
+}
+
+func TestFormatCompactListItem_Columns(t *testing.T) {
+	t.Cleanup(func() { _ = SetListColumns(nil) })
+
+	item := mockFeedItem{
+		title:      "Column title",
+		link:       "https://www.example.com/post",
+		author:     "alice",
+		score:      7,
+		comments:   3,
+		categories: []string{"cats", "dogs"},
+		createdAt:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	tests := []struct {
+		columns []string
+		want    string
+	}{
+		{columns: nil, want: " 1. [↑    7 💬   3] 2024-01-02T03:04:05Z  Column title"},
+		{columns: []string{"title", "author", "domain"}, want: " 1. Column title  by alice  (example.com)"},
+		{columns: []string{"comments", "title", "categories"}, want: " 1. [💬   3] Column title  cats, dogs"},
+		{columns: []string{" Score ", "date"}, want: " 1. [↑    7] 2024-01-02T03:04:05Z"},
+	}
+
+	for _, tt := range tests {
+		if err := SetListColumns(tt.columns); err != nil {
+			t.Fatalf("SetListColumns(%v) error = %v", tt.columns, err)
+		}
+		if got := FormatCompactListItem(0, item); got != tt.want {
+			t.Errorf("FormatCompactListItem() with %v = %q, want %q", tt.columns, got, tt.want)
+		}
+	}
+
+	if err := SetListColumns([]string{"title", "votes"}); err == nil {
+		t.Error("SetListColumns() accepted an unknown column")
+	}
+}
+
+func TestStatLabels_FeedContentAndCompactList(t *testing.T) {
+	previous := feed.GetOptions()
+	t.Cleanup(func() { feed.SetOptions(previous) })
+	feed.SetOptions(feed.Options{StatLabels: feed.StatLabels{Score: "⭐", Comments: "🗨"}})
+
+	item := mockFeedItem{
+		title:     "Labelled",
+		link:      "https://example.com/post",
+		score:     7,
+		comments:  3,
+		createdAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	if got, want := FormatCompactListItem(0, item), " 1. [⭐    7 🗨   3] 2024-01-02T03:04:05Z  Labelled"; got != want {
+		t.Errorf("FormatCompactListItem() = %q, want %q", got, want)
+	}
+
+	xml := FormatXMLItem(item, "hackernews-atom", feed.Config{Title: "HN"})
+	for _, want := range []string{"<strong>⭐</strong> 7", "<strong>🗨</strong> 3"} {
+		if !strings.Contains(xml, want) {
+			t.Errorf("entry content missing %q:\n%s", want, xml)
+		}
+	}
+	if strings.Contains(xml, "<strong>Score:</strong>") {
+		t.Errorf("entry content still uses the default score label:\n%s", xml)
+	}
+}
+
+func TestAbbreviateCounts_CompactList(t *testing.T) {
+	previous := feed.GetOptions()
+	t.Cleanup(func() { feed.SetOptions(previous) })
+	feed.SetOptions(feed.Options{AbbreviateCounts: true})
+
+	item := mockFeedItem{
+		title:     "Popular",
+		score:     12345,
+		comments:  1500,
+		createdAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	if got, want := FormatCompactListItem(0, item), " 1. [↑ 12.3k 💬 1.5k] 2024-01-02T03:04:05Z  Popular"; got != want {
+		t.Errorf("FormatCompactListItem() = %q, want %q", got, want)
+	}
+}
@@ -2,13 +2,15 @@ package preview
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"sort"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lepinkainen/feed-forge/pkg/feed"
-	"github.com/lepinkainen/feed-forge/pkg/providers"
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
 )
 
 // ViewMode represents the current view mode
@@ -23,7 +25,7 @@ const (
 
 // Model represents the Bubble Tea model for the preview TUI
 type Model struct {
-	items         []providers.FeedItem
+	items         []feedtypes.FeedItem
 	cursor        int
 	viewMode      ViewMode
 	providerName  string
@@ -35,9 +37,23 @@ type Model struct {
 }
 
 // NewModel creates a new preview model
-func NewModel(items []providers.FeedItem, providerName, templateName string, feedConfig feed.Config) Model {
+func NewModel(items []feedtypes.FeedItem, providerName, templateName string, feedConfig feed.Config) Model {
+	return Model{
+		items:         newestFirst(items),
+		cursor:        0,
+		viewMode:      ListViewMode,
+		providerName:  providerName,
+		templateName:  templateName,
+		feedConfig:    feedConfig,
+		selectedIndex: -1,
+	}
+}
+
+// newestFirst returns items sorted newest first, the order the preview
+// always shows them in.
+func newestFirst(items []feedtypes.FeedItem) []feedtypes.FeedItem {
 	type sortableItem struct {
-		item providers.FeedItem
+		item feedtypes.FeedItem
 		time int64
 	}
 
@@ -51,20 +67,11 @@ func NewModel(items []providers.FeedItem, providerName, templateName string, fee
 		return sortable[i].time > sortable[j].time
 	})
 
-	sortedItems := make([]providers.FeedItem, len(sortable))
+	sortedItems := make([]feedtypes.FeedItem, len(sortable))
 	for i, s := range sortable {
 		sortedItems[i] = s.item
 	}
-
-	return Model{
-		items:         sortedItems,
-		cursor:        0,
-		viewMode:      ListViewMode,
-		providerName:  providerName,
-		templateName:  templateName,
-		feedConfig:    feedConfig,
-		selectedIndex: -1,
-	}
+	return sortedItems
 }
 
 // Init implements tea.Model
@@ -264,14 +271,58 @@ func (m Model) renderXMLView() string {
 	return b.String()
 }
 
-// Run starts the Bubble Tea program
-func Run(items []providers.FeedItem, providerName, templateName string, feedConfig feed.Config) error {
+// noTUI makes Run print the item list instead of starting the TUI. Set via
+// SetNoTUI.
+var noTUI bool
+
+// SetNoTUI makes Run print the item list instead of starting the TUI, even
+// on a terminal.
+func SetNoTUI(disable bool) {
+	noTUI = disable
+}
+
+// Run starts the Bubble Tea program on stdout. When stdout is not a terminal
+// (pipes, CI) or SetNoTUI is on, it prints the item list instead.
+func Run(items []feedtypes.FeedItem, providerName, templateName string, feedConfig feed.Config) error {
+	return RunTo(os.Stdout, items, providerName, templateName, feedConfig)
+}
+
+// RunTo is Run writing to w. Only a terminal gets the TUI; any other writer
+// receives the plain list from PrintList.
+func RunTo(w io.Writer, items []feedtypes.FeedItem, providerName, templateName string, feedConfig feed.Config) error {
 	if len(items) == 0 {
-		fmt.Println("No items to preview")
-		return nil
+		_, err := fmt.Fprintln(w, "No items to preview")
+		return err
+	}
+
+	if noTUI || !isTerminal(w) {
+		return PrintList(w, items, providerName)
 	}
 
-	p := tea.NewProgram(NewModel(items, providerName, templateName, feedConfig), tea.WithAltScreen())
+	p := tea.NewProgram(NewModel(items, providerName, templateName, feedConfig), tea.WithAltScreen(), tea.WithOutput(w))
 	_, err := p.Run()
 	return err
 }
+
+// PrintList writes the list view without styling or key bindings: a header
+// and one FormatCompactListItem line per item, newest first.
+func PrintList(w io.Writer, items []feedtypes.FeedItem, providerName string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Feed Preview - %s (%d items)\n\n", providerName, len(items))
+	for i, item := range newestFirst(items) {
+		b.WriteString(FormatCompactListItem(i, item))
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// isTerminal reports whether w is a character device such as a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -10,7 +10,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/lepinkainen/feed-forge/pkg/feed"
-	"github.com/lepinkainen/feed-forge/pkg/providers"
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
 )
 
 func captureOutput(t *testing.T, fn func()) string {
@@ -37,7 +37,7 @@ func TestNewModelSortsNewestFirstAndInit(t *testing.T) {
 	older := mockFeedItem{title: "older", createdAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
 	newer := mockFeedItem{title: "newer", createdAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}
 
-	model := NewModel([]providers.FeedItem{older, newer}, "Provider", "preview", feed.Config{Title: "Feed"})
+	model := NewModel([]feedtypes.FeedItem{older, newer}, "Provider", "preview", feed.Config{Title: "Feed"})
 	if model.items[0].Title() != "newer" || model.items[1].Title() != "older" {
 		t.Fatalf("NewModel() items not sorted newest-first: %#v", model.items)
 	}
@@ -50,7 +50,7 @@ func TestNewModelSortsNewestFirstAndInit(t *testing.T) {
 }
 
 func TestModelUpdateWindowAndListNavigation(t *testing.T) {
-	model := NewModel([]providers.FeedItem{
+	model := NewModel([]feedtypes.FeedItem{
 		mockFeedItem{title: "one", createdAt: time.Now()},
 		mockFeedItem{title: "two", createdAt: time.Now().Add(time.Second)},
 	}, "Provider", "preview", feed.Config{})
@@ -75,7 +75,7 @@ func TestModelUpdateWindowAndListNavigation(t *testing.T) {
 }
 
 func TestDetailAndXMLViewUpdates(t *testing.T) {
-	model := NewModel([]providers.FeedItem{mockFeedItem{title: "one", createdAt: time.Now()}}, "Provider", "preview", feed.Config{})
+	model := NewModel([]feedtypes.FeedItem{mockFeedItem{title: "one", createdAt: time.Now()}}, "Provider", "preview", feed.Config{})
 	model.selectedIndex = 0
 	model.viewMode = DetailViewMode
 
@@ -111,7 +111,7 @@ func TestViewAndRenderMethods(t *testing.T) {
 	})
 
 	item := mockFeedItem{title: "Preview title", link: "https://example.com", commentsLink: "https://example.com", author: "alice", createdAt: time.Now()}
-	model := NewModel([]providers.FeedItem{item}, "Provider", "preview", feed.Config{Title: "Feed"})
+	model := NewModel([]feedtypes.FeedItem{item}, "Provider", "preview", feed.Config{Title: "Feed"})
 	model.height = 10
 
 	list := model.renderListView()
@@ -143,7 +143,7 @@ func TestViewAndRenderMethods(t *testing.T) {
 }
 
 func TestRenderViewsWithoutSelection(t *testing.T) {
-	model := NewModel([]providers.FeedItem{mockFeedItem{title: "one", createdAt: time.Now()}}, "Provider", "preview", feed.Config{})
+	model := NewModel([]feedtypes.FeedItem{mockFeedItem{title: "one", createdAt: time.Now()}}, "Provider", "preview", feed.Config{})
 	if got := model.renderDetailView(); got != "No item selected" {
 		t.Fatalf("renderDetailView() = %q, want no selection message", got)
 	}
@@ -162,3 +162,20 @@ func TestRunWithEmptyItems(t *testing.T) {
 		t.Fatalf("Run() output = %q", out)
 	}
 }
+
+func TestRunToNonTerminalPrintsList(t *testing.T) {
+	older := mockFeedItem{title: "Older post", score: 5, comments: 1, createdAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	newer := mockFeedItem{title: "Newer post", score: 42, comments: 7, createdAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}
+
+	var out bytes.Buffer
+	if err := RunTo(&out, []feedtypes.FeedItem{older, newer}, "Provider", "preview", feed.Config{}); err != nil {
+		t.Fatalf("RunTo() error = %v", err)
+	}
+
+	want := "Feed Preview - Provider (2 items)\n\n" +
+		FormatCompactListItem(0, newer) + "\n" +
+		FormatCompactListItem(1, older) + "\n"
+	if out.String() != want {
+		t.Fatalf("RunTo() output = %q, want %q", out.String(), want)
+	}
+}
@@ -1,39 +1,83 @@
 package providerfeed
 
 import (
+	"cmp"
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"time"
 
+	"github.com/lepinkainen/feed-forge/pkg/api"
 	"github.com/lepinkainen/feed-forge/pkg/feed"
 	"github.com/lepinkainen/feed-forge/pkg/feedmeta"
 	"github.com/lepinkainen/feed-forge/pkg/filesystem"
 	"github.com/lepinkainen/feed-forge/pkg/httpcache"
+	"github.com/lepinkainen/feed-forge/pkg/metrics"
 	"github.com/lepinkainen/feed-forge/pkg/opengraph"
 	"github.com/lepinkainen/feed-forge/pkg/providers"
+	"github.com/lepinkainen/feed-forge/pkg/seen"
 )
 
 // BuildGenerator creates a shared GenerateFeed implementation for providers.
+// The returned function writes outfile in format (feed.FormatAtom when
+// empty); every format goes through the same item pipeline.
 func BuildGenerator(
 	fetchItems func(limit int) ([]providers.FeedItem, error),
 	preview *providers.PreviewInfo,
 	configFunc func() feedmeta.Config,
 	ogDB *opengraph.Database,
-) func(string) error {
-	return func(outfile string) error {
+) func(outfile, format string) error {
+	return func(outfile, format string) error {
 		if fetchItems == nil {
 			return fmt.Errorf("feed generator is not configured")
 		}
 		if preview == nil {
 			return fmt.Errorf("preview metadata is not configured")
 		}
+		format = cmp.Or(format, feed.FormatAtom)
+		if !feed.ValidFormat(format) {
+			return fmt.Errorf("unknown output format %q", format)
+		}
 
-		feedItems, err := fetchItems(0)
+		// Metrics are labelled by provider name, as in serve.FeedHandler.
+		label := cmp.Or(preview.ProviderName, preview.TemplateName)
+		start := time.Now()
+		opts := feed.GetOptions()
+		feedItems, err := fetchItems(opts.FetchLimit)
 		if err != nil {
+			if !errors.Is(err, httpcache.ErrNotModified) {
+				metrics.UpstreamErrors.Inc(label)
+			}
 			return handleFetchError(outfile, err)
 		}
+		if deduped := feed.DedupeItems(feedItems, opts.DedupeBy); len(deduped) != len(feedItems) {
+			slog.Debug("Dropped duplicate items", "dedupeBy", opts.DedupeBy, "dropped", len(feedItems)-len(deduped))
+			feedItems = deduped
+		}
+		var seenStore *seen.Store
+		if opts.GlobalDedupe {
+			feedItems, seenStore = filterSeen(outfile, feedItems, opts.GlobalDedupeTTL)
+			if seenStore != nil {
+				defer closeSeenStore(seenStore)
+			}
+		}
+		if opts.Rank == feed.RankHotness {
+			feedItems = feed.RankByHotness(feedItems, opts.HotnessGravity, time.Now())
+		}
+		if opts.FeedLimit > 0 && len(feedItems) > opts.FeedLimit {
+			slog.Debug("Applying feed limit", "fetched", len(feedItems), "feedLimit", opts.FeedLimit)
+			feedItems = feedItems[:opts.FeedLimit]
+		}
+
+		if len(feedItems) == 0 {
+			slog.Warn("Provider returned no items", "outfile", outfile)
+			if opts.SkipEmpty && hasExistingEntries(outfile, format) {
+				slog.Warn("Keeping existing feed instead of writing an empty one", "outfile", outfile)
+				return nil
+			}
+		}
 
 		if err := filesystem.EnsureDirectoryExists(outfile); err != nil {
 			return err
@@ -43,16 +87,101 @@ func BuildGenerator(
 		if configFunc != nil {
 			cfg = configFunc()
 		}
+		if opts.OGMaxFetches > 0 {
+			cfg.OpenGraphMaxFetches = opts.OGMaxFetches
+		}
+		if opts.HTMLURL != "" {
+			cfg.HTMLURL = opts.HTMLURL
+		}
+		if opts.SelfURL != "" {
+			cfg.SelfURL = opts.SelfURL
+		}
+		selfURL := cfg.SelfURL
+		if selfURL == "" && opts.Hub != "" && opts.FeedBaseURL != "" {
+			selfURL, err = feed.FeedSelfURL(opts.FeedBaseURL, outfile)
+			if err != nil {
+				return err
+			}
+			cfg.SelfURL = selfURL
+		}
 
-		if err := feed.SaveAtomFeedToFileWithEmbeddedTemplate(feedItems, preview.TemplateName, outfile, cfg, ogDB); err != nil {
+		if opts.Paginate && format == feed.FormatAtom && len(feedItems) > 0 {
+			// Writes outfile too, linked to its pages.
+			paths, err := feed.SavePagedFeedsWithEmbeddedTemplate(feedItems, preview.TemplateName, outfile, opts.PageSize, cfg, ogDB)
+			if err != nil {
+				return err
+			}
+			slog.Info("Paged feeds written", "outfile", outfile, "pages", len(paths))
+		} else if err := feed.SaveFeedToFile(feedItems, format, preview.TemplateName, outfile, cfg, ogDB); err != nil {
 			return err
 		}
 
+		if seenStore != nil {
+			if err := seenStore.Record(outfile, feedItems, time.Now()); err != nil {
+				slog.Warn("Failed to record emitted links for global dedupe", "outfile", outfile, "error", err)
+			}
+		}
+
+		if (opts.Archive || opts.Paginate) && format != feed.FormatAtom {
+			slog.Warn("Archive and paged feeds are only written for Atom output", "outfile", outfile, "format", format)
+		}
+
+		if opts.Archive && format == feed.FormatAtom && len(feedItems) > 0 {
+			paths, err := feed.SaveArchiveFeedsWithEmbeddedTemplate(feedItems, preview.TemplateName, outfile, cfg, ogDB)
+			if err != nil {
+				return err
+			}
+			slog.Info("Archive feeds written", "outfile", outfile, "pages", len(paths))
+		}
+
 		feed.LogFeedGeneration(len(feedItems), outfile)
+		metrics.ObserveGeneration(label, time.Since(start))
+
+		if selfURL != "" && opts.Hub != "" {
+			if err := feed.NotifyHub(context.Background(), api.NewGenericClient(), opts.Hub, selfURL); err != nil {
+				slog.Warn("WebSub hub notification failed", "hub", opts.Hub, "feed", selfURL, "error", err)
+			}
+		}
 		return nil
 	}
 }
 
+// filterSeen drops items whose links another feed emitted within ttl
+// (seen.DefaultTTL when zero). It returns the store for recording the emitted
+// items, or nil with items unchanged when the store can't be used.
+func filterSeen(outfile string, items []providers.FeedItem, ttl time.Duration) ([]providers.FeedItem, *seen.Store) {
+	store, err := seen.NewStore("")
+	if err != nil {
+		slog.Warn("Global dedupe disabled: failed to open seen store", "error", err)
+		return items, nil
+	}
+	filtered, err := store.Filter(outfile, items, cmp.Or(ttl, seen.DefaultTTL), time.Now())
+	if err != nil {
+		slog.Warn("Global dedupe disabled: failed to filter seen links", "error", err)
+		closeSeenStore(store)
+		return items, nil
+	}
+	if len(filtered) != len(items) {
+		slog.Debug("Dropped items already emitted by another feed", "outfile", outfile, "dropped", len(items)-len(filtered))
+	}
+	return filtered, store
+}
+
+func closeSeenStore(store *seen.Store) {
+	if err := store.Close(); err != nil {
+		slog.Warn("Failed to close seen store", "error", err)
+	}
+}
+
+// hasExistingEntries reports whether outfile already holds a feed in format with at least one entry.
+func hasExistingEntries(outfile, format string) bool {
+	contents, err := os.ReadFile(outfile)
+	if err != nil {
+		return false
+	}
+	return feed.HasEntries(contents, format)
+}
+
 func handleFetchError(outfile string, err error) error {
 	if !errors.Is(err, httpcache.ErrNotModified) {
 		return err
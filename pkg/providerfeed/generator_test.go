@@ -1,15 +1,22 @@
 package providerfeed
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/lepinkainen/feed-forge/pkg/feed"
 	"github.com/lepinkainen/feed-forge/pkg/feedmeta"
+	"github.com/lepinkainen/feed-forge/pkg/filesystem"
 	"github.com/lepinkainen/feed-forge/pkg/httpcache"
+	"github.com/lepinkainen/feed-forge/pkg/metrics"
 	"github.com/lepinkainen/feed-forge/pkg/providers"
 )
 
@@ -42,7 +49,7 @@ func validPreview() *providers.PreviewInfo {
 
 func TestBuildGeneratorRequiresFetchFunc(t *testing.T) {
 	gen := BuildGenerator(nil, validPreview(), nil, nil)
-	err := gen(filepath.Join(t.TempDir(), "feed.xml"))
+	err := gen(filepath.Join(t.TempDir(), "feed.xml"), "")
 	if err == nil {
 		t.Fatal("BuildGenerator with nil fetchItems: err = nil, want error")
 	}
@@ -53,7 +60,7 @@ func TestBuildGeneratorRequiresFetchFunc(t *testing.T) {
 
 func TestBuildGeneratorRequiresPreview(t *testing.T) {
 	gen := BuildGenerator(func(int) ([]providers.FeedItem, error) { return nil, nil }, nil, nil, nil)
-	err := gen(filepath.Join(t.TempDir(), "feed.xml"))
+	err := gen(filepath.Join(t.TempDir(), "feed.xml"), "")
 	if err == nil {
 		t.Fatal("BuildGenerator with nil preview: err = nil, want error")
 	}
@@ -70,12 +77,38 @@ func TestBuildGeneratorPropagatesFetchError(t *testing.T) {
 		nil,
 		nil,
 	)
-	err := gen(filepath.Join(t.TempDir(), "feed.xml"))
+	err := gen(filepath.Join(t.TempDir(), "feed.xml"), "")
 	if !errors.Is(err, sentinel) {
 		t.Fatalf("error = %v, want wraps %v", err, sentinel)
 	}
 }
 
+func TestBuildGeneratorLabelsMetricsByProviderName(t *testing.T) {
+	preview := validPreview()
+	preview.ProviderName = "Metrics Stub"
+	dir := t.TempDir()
+
+	failing := BuildGenerator(func(int) ([]providers.FeedItem, error) { return nil, errors.New("down") }, preview, nil, nil)
+	if err := failing(filepath.Join(dir, "broken.xml"), ""); err == nil {
+		t.Fatal("gen() error = nil, want fetch error")
+	}
+	working := BuildGenerator(func(int) ([]providers.FeedItem, error) { return []providers.FeedItem{stubItem{}}, nil }, preview, nil, nil)
+	outfile := filepath.Join(dir, "feed.xml")
+	if err := working(outfile, ""); err != nil {
+		t.Fatalf("gen(%s) error = %v", outfile, err)
+	}
+
+	if got := metrics.UpstreamErrors.Value("Metrics Stub"); got != 1 {
+		t.Errorf("UpstreamErrors[provider] = %v, want 1", got)
+	}
+	if got := metrics.GenerationSeconds.Value("Metrics Stub"); got <= 0 {
+		t.Errorf("GenerationSeconds[provider] = %v, want > 0", got)
+	}
+	if got := metrics.GenerationSeconds.Value(outfile); got != 0 {
+		t.Errorf("GenerationSeconds[outfile] = %v, want no outfile label", got)
+	}
+}
+
 func TestBuildGeneratorWritesFeedAndCreatesDirs(t *testing.T) {
 	preview := validPreview()
 	outfile := filepath.Join(t.TempDir(), "nested", "out", "feed.xml")
@@ -88,7 +121,7 @@ func TestBuildGeneratorWritesFeedAndCreatesDirs(t *testing.T) {
 		nil,
 		nil,
 	)
-	if err := gen(outfile); err != nil {
+	if err := gen(outfile, ""); err != nil {
 		t.Fatalf("gen(%s) error = %v", outfile, err)
 	}
 
@@ -120,7 +153,7 @@ func TestBuildGeneratorConfigFuncOverridesPreview(t *testing.T) {
 		configFunc,
 		nil,
 	)
-	if err := gen(outfile); err != nil {
+	if err := gen(outfile, ""); err != nil {
 		t.Fatalf("gen error = %v", err)
 	}
 	if !called {
@@ -155,7 +188,7 @@ func TestBuildGeneratorNotModifiedBumpsExistingOutfile(t *testing.T) {
 		nil,
 		nil,
 	)
-	if err := gen(outfile); err != nil {
+	if err := gen(outfile, ""); err != nil {
 		t.Fatalf("gen error = %v", err)
 	}
 
@@ -175,8 +208,225 @@ func TestBuildGeneratorNotModifiedMissingOutfileReturnsError(t *testing.T) {
 		nil,
 		nil,
 	)
-	err := gen(filepath.Join(t.TempDir(), "missing.xml"))
+	err := gen(filepath.Join(t.TempDir(), "missing.xml"), "")
 	if !errors.Is(err, httpcache.ErrNotModified) {
 		t.Fatalf("error = %v, want ErrNotModified", err)
 	}
 }
+
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &logs
+}
+
+func emptyFetch(int) ([]providers.FeedItem, error) { return nil, nil }
+
+func TestBuildGeneratorWarnsOnEmptyItems(t *testing.T) {
+	logs := captureLogs(t)
+	outfile := filepath.Join(t.TempDir(), "feed.xml")
+
+	if err := BuildGenerator(emptyFetch, validPreview(), nil, nil)(outfile, ""); err != nil {
+		t.Fatalf("gen error = %v", err)
+	}
+	if !strings.Contains(logs.String(), "Provider returned no items") {
+		t.Fatalf("expected empty-feed warning, got logs:\n%s", logs.String())
+	}
+	if _, err := os.Stat(outfile); err != nil {
+		t.Fatalf("empty feed should still be written without SkipEmpty: %v", err)
+	}
+}
+
+func TestBuildGeneratorSkipEmptyKeepsExistingFeed(t *testing.T) {
+	previous := feed.GetOptions()
+	t.Cleanup(func() { feed.SetOptions(previous) })
+	feed.SetOptions(feed.Options{SkipEmpty: true})
+
+	outfile := filepath.Join(t.TempDir(), "feed.xml")
+	existing := "<feed><entry><title>Keep me</title></entry></feed>"
+	if err := os.WriteFile(outfile, []byte(existing), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := BuildGenerator(emptyFetch, validPreview(), nil, nil)(outfile, ""); err != nil {
+		t.Fatalf("gen error = %v", err)
+	}
+
+	contents, err := os.ReadFile(outfile)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(contents) != existing {
+		t.Fatalf("existing feed was overwritten; got:\n%s", contents)
+	}
+
+	// Without a previous feed there is nothing to protect, so the empty feed is written.
+	fresh := filepath.Join(t.TempDir(), "fresh.xml")
+	if err := BuildGenerator(emptyFetch, validPreview(), nil, nil)(fresh, ""); err != nil {
+		t.Fatalf("gen(fresh) error = %v", err)
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Fatalf("expected empty feed to be written when no feed exists: %v", err)
+	}
+}
+
+type numberedItem struct {
+	stubItem
+	n int
+}
+
+func (i numberedItem) Title() string { return fmt.Sprintf("Item %d", i.n) }
+func (i numberedItem) Link() string  { return fmt.Sprintf("https://example.com/post/%d", i.n) }
+func (i numberedItem) CommentsLink() string {
+	return fmt.Sprintf("https://example.com/post/%d/comments", i.n)
+}
+
+func TestBuildGeneratorSeparatesFetchAndFeedLimits(t *testing.T) {
+	previous := feed.GetOptions()
+	t.Cleanup(func() { feed.SetOptions(previous) })
+	feed.SetOptions(feed.Options{FetchLimit: 50, FeedLimit: 10})
+
+	var requested int
+	gen := BuildGenerator(
+		func(limit int) ([]providers.FeedItem, error) {
+			requested = limit
+			items := make([]providers.FeedItem, limit)
+			for i := range items {
+				items[i] = numberedItem{n: i}
+			}
+			return items, nil
+		},
+		validPreview(),
+		nil,
+		nil,
+	)
+
+	outfile := filepath.Join(t.TempDir(), "feed.xml")
+	if err := gen(outfile, ""); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if requested != 50 {
+		t.Fatalf("fetch limit = %d, want 50", requested)
+	}
+
+	contents, err := os.ReadFile(outfile)
+	if err != nil {
+		t.Fatalf("read feed: %v", err)
+	}
+	if got := strings.Count(string(contents), "<entry>"); got != 10 {
+		t.Fatalf("feed has %d entries, want 10", got)
+	}
+	if !strings.Contains(string(contents), "Item 9") || strings.Contains(string(contents), "Item 10") {
+		t.Fatal("feed limit did not keep the first 10 items")
+	}
+}
+
+func TestBuildGeneratorAppliesPipelineToOtherFormats(t *testing.T) {
+	previous := feed.GetOptions()
+	t.Cleanup(func() { feed.SetOptions(previous) })
+	feed.SetOptions(feed.Options{FetchLimit: 20, FeedLimit: 3, SelfURL: "https://example.com/feed.json"})
+
+	gen := BuildGenerator(
+		func(limit int) ([]providers.FeedItem, error) {
+			items := make([]providers.FeedItem, limit)
+			for i := range items {
+				items[i] = numberedItem{n: i}
+			}
+			return items, nil
+		},
+		validPreview(),
+		nil,
+		nil,
+	)
+
+	outfile := filepath.Join(t.TempDir(), "feed.json")
+	if err := gen(outfile, feed.FormatJSON); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	contents, err := os.ReadFile(outfile)
+	if err != nil {
+		t.Fatalf("read feed: %v", err)
+	}
+	var doc struct {
+		FeedURL string            `json:"feed_url"`
+		Items   []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(contents, &doc); err != nil {
+		t.Fatalf("JSON Feed does not parse: %v\n%s", err, contents)
+	}
+	if len(doc.Items) != 3 {
+		t.Fatalf("JSON Feed has %d items, want FeedLimit 3", len(doc.Items))
+	}
+	if doc.FeedURL != "https://example.com/feed.json" {
+		t.Fatalf("feed_url = %q, want the --self-url override", doc.FeedURL)
+	}
+}
+
+func TestBuildGeneratorSkipEmptyKeepsExistingJSONFeed(t *testing.T) {
+	previous := feed.GetOptions()
+	t.Cleanup(func() { feed.SetOptions(previous) })
+	feed.SetOptions(feed.Options{SkipEmpty: true})
+
+	outfile := filepath.Join(t.TempDir(), "feed.json")
+	existing := `{"version":"https://jsonfeed.org/version/1.1","title":"t","items":[{"id":"1","content_html":""}]}`
+	if err := os.WriteFile(outfile, []byte(existing), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := BuildGenerator(emptyFetch, validPreview(), nil, nil)(outfile, feed.FormatJSON); err != nil {
+		t.Fatalf("gen error = %v", err)
+	}
+	contents, err := os.ReadFile(outfile)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(contents) != existing {
+		t.Fatalf("existing JSON Feed was overwritten; got:\n%s", contents)
+	}
+}
+
+func TestBuildGeneratorRejectsUnknownFormat(t *testing.T) {
+	gen := BuildGenerator(emptyFetch, validPreview(), nil, nil)
+	if err := gen(filepath.Join(t.TempDir(), "feed.xml"), "yaml"); err == nil {
+		t.Fatal("gen(yaml) error = nil, want unknown format")
+	}
+}
+
+func TestBuildGeneratorGlobalDedupeAcrossFeeds(t *testing.T) {
+	filesystem.SetCacheDir(t.TempDir())
+	t.Cleanup(func() { filesystem.SetCacheDir("") })
+	previous := feed.GetOptions()
+	t.Cleanup(func() { feed.SetOptions(previous) })
+	feed.SetOptions(feed.Options{GlobalDedupe: true})
+
+	numbered := func(from, to int) func(int) ([]providers.FeedItem, error) {
+		return func(int) ([]providers.FeedItem, error) {
+			var items []providers.FeedItem
+			for n := from; n <= to; n++ {
+				items = append(items, numberedItem{n: n})
+			}
+			return items, nil
+		}
+	}
+
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.xml")
+	if err := BuildGenerator(numbered(0, 2), validPreview(), nil, nil)(first, ""); err != nil {
+		t.Fatalf("generate first: %v", err)
+	}
+	second := filepath.Join(dir, "second.xml")
+	if err := BuildGenerator(numbered(1, 3), validPreview(), nil, nil)(second, ""); err != nil {
+		t.Fatalf("generate second: %v", err)
+	}
+
+	contents, err := os.ReadFile(second)
+	if err != nil {
+		t.Fatalf("read feed: %v", err)
+	}
+	if got := strings.Count(string(contents), "<entry>"); got != 1 || !strings.Contains(string(contents), "Item 3") {
+		t.Fatalf("second feed should keep only Item 3, got %d entries:\n%s", got, contents)
+	}
+}
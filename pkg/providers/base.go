@@ -18,7 +18,7 @@ type BaseProvider struct {
 	OgDB      *opengraph.Database
 	HTTPCache *httpcache.Store
 
-	generateFeed func(outfile string) error
+	generateFeed func(outfile, format string) error
 }
 
 // DatabaseConfig holds database configuration for providers
@@ -94,16 +94,27 @@ func closeOrLog(name string, c closer) {
 }
 
 // SetGenerateFeedFunc configures the shared GenerateFeed implementation for the provider.
-func (b *BaseProvider) SetGenerateFeedFunc(fn func(outfile string) error) {
+func (b *BaseProvider) SetGenerateFeedFunc(fn func(outfile, format string) error) {
 	b.generateFeed = fn
 }
 
-// GenerateFeed runs the configured shared feed generation logic.
+// GenerateFeed runs the configured shared feed generation logic, writing Atom.
 func (b *BaseProvider) GenerateFeed(outfile string) error {
+	return b.GenerateFeedAs(outfile, "")
+}
+
+// GenerateFeedAs runs the configured shared feed generation logic, writing
+// outfile in format (Atom when empty).
+func (b *BaseProvider) GenerateFeedAs(outfile, format string) error {
 	if b.generateFeed == nil {
 		return fmt.Errorf("generate feed is not configured")
 	}
-	return b.generateFeed(outfile)
+	return b.generateFeed(outfile, format)
+}
+
+// OpenGraphDB returns the provider's OpenGraph cache, nil when it has none.
+func (b *BaseProvider) OpenGraphDB() *opengraph.Database {
+	return b.OgDB
 }
 
 // Close cleans up database connections
@@ -0,0 +1,69 @@
+package providers
+
+import (
+	"log/slog"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// ProviderResult is the outcome of fetching one provider's items.
+type ProviderResult struct {
+	Name  string
+	Items []FeedItem
+	Err   error
+}
+
+// DefaultProviderConcurrency is the provider fetch bound used when none is
+// configured: one fetch per CPU.
+func DefaultProviderConcurrency() int {
+	return max(runtime.NumCPU(), 1)
+}
+
+// RunBounded calls run for every index in [0, n), at most concurrency at once
+// (DefaultProviderConcurrency when <= 0), and returns when all have finished.
+func RunBounded(n, concurrency int, run func(i int)) {
+	if concurrency <= 0 {
+		concurrency = DefaultProviderConcurrency()
+	}
+
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := range n {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			run(i)
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+// FetchItemsConcurrent fetches items from every provider in parallel, running
+// at most concurrency fetches at once (DefaultProviderConcurrency when <= 0).
+// Results are returned sorted by provider name; a failing provider does not
+// stop the others.
+func FetchItemsConcurrent(feedProviders map[string]FeedProvider, limit, concurrency int) []ProviderResult {
+	names := make([]string, 0, len(feedProviders))
+	for name := range feedProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := make([]ProviderResult, len(names))
+	RunBounded(len(names), concurrency, func(i int) {
+		name := names[i]
+		items, err := feedProviders[name].FetchItems(limit)
+		if err != nil {
+			slog.Warn("Provider fetch failed", "provider", name, "error", err)
+		}
+		results[i] = ProviderResult{Name: name, Items: items, Err: err}
+	})
+	return results
+}
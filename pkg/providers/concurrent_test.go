@@ -0,0 +1,92 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchItemsConcurrent_RespectsBound(t *testing.T) {
+	const bound = 2
+	var running, peak atomic.Int32
+
+	feedProviders := make(map[string]FeedProvider)
+	for i := range 6 {
+		feedProviders[fmt.Sprintf("provider-%d", i)] = &mockFeedProvider{
+			fetchItemsFunc: func(int) ([]FeedItem, error) {
+				current := running.Add(1)
+				defer running.Add(-1)
+				for {
+					previous := peak.Load()
+					if current <= previous || peak.CompareAndSwap(previous, current) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				return []FeedItem{&mockFeedItem{title: "item"}}, nil
+			},
+		}
+	}
+
+	results := FetchItemsConcurrent(feedProviders, 0, bound)
+
+	if got := peak.Load(); got > bound {
+		t.Fatalf("peak concurrent fetches = %d, want <= %d", got, bound)
+	}
+	if len(results) != len(feedProviders) {
+		t.Fatalf("got %d results, want %d", len(results), len(feedProviders))
+	}
+	for i, result := range results {
+		if want := fmt.Sprintf("provider-%d", i); result.Name != want {
+			t.Errorf("results[%d].Name = %q, want %q", i, result.Name, want)
+		}
+		if result.Err != nil || len(result.Items) != 1 {
+			t.Errorf("results[%d] = %+v, want one item", i, result)
+		}
+	}
+}
+
+func TestFetchItemsConcurrent_KeepsErrorsPerProvider(t *testing.T) {
+	wantErr := errors.New("upstream down")
+	results := FetchItemsConcurrent(map[string]FeedProvider{
+		"bad":  &mockFeedProvider{fetchItemsFunc: func(int) ([]FeedItem, error) { return nil, wantErr }},
+		"good": &mockFeedProvider{},
+	}, 0, 0)
+
+	if !errors.Is(results[0].Err, wantErr) {
+		t.Fatalf("bad provider error = %v, want %v", results[0].Err, wantErr)
+	}
+	if results[1].Err != nil {
+		t.Fatalf("good provider error = %v, want nil", results[1].Err)
+	}
+}
+
+func TestRunBounded_DefaultsToCPUCount(t *testing.T) {
+	var running, peak atomic.Int32
+	n := DefaultProviderConcurrency() + 3
+	ran := make([]bool, n)
+
+	RunBounded(n, 0, func(i int) {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			previous := peak.Load()
+			if current <= previous || peak.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		ran[i] = true
+	})
+
+	if got, bound := int(peak.Load()), DefaultProviderConcurrency(); got > bound {
+		t.Fatalf("peak concurrent runs = %d, want <= %d", got, bound)
+	}
+	for i, ok := range ran {
+		if !ok {
+			t.Errorf("run(%d) was not called", i)
+		}
+	}
+}
@@ -4,9 +4,9 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
-	"time"
 
 	"github.com/lepinkainen/feed-forge/pkg/feedmeta"
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
 )
 
 // FeedProvider defines the interface for a feed source.
@@ -15,20 +15,16 @@ type FeedProvider interface {
 	FetchItems(limit int) ([]FeedItem, error)
 }
 
-// FeedItem defines the essential fields for any feed entry.
-type FeedItem interface {
-	Title() string
-	Link() string
-	CommentsLink() string
-	Author() string
-	Score() int
-	CommentCount() int
-	CreatedAt() time.Time
-	Categories() []string
-	ImageURL() string
-	Content() string
+// FormatGenerator is implemented by providers that can write their feed in
+// output formats other than Atom (see feed.FormatRSS and friends).
+type FormatGenerator interface {
+	GenerateFeedAs(outfile, format string) error
 }
 
+// FeedItem defines the essential fields for any feed entry. It aliases
+// feedtypes.FeedItem so feed generation doesn't depend on this package.
+type FeedItem = feedtypes.FeedItem
+
 // ProviderFactory creates a new instance of a provider.
 type ProviderFactory func(config any) (FeedProvider, error)
 
@@ -78,6 +74,22 @@ type ProviderInfo struct {
 	Factory       ProviderFactory
 	ConfigFactory func() any
 	Preview       *PreviewInfo
+	// DefaultLimit is the provider's recommended item limit: the default of
+	// its limit option, and what FetchItems uses when no limit is set.
+	// Zero means the provider has no limit option.
+	DefaultLimit int
+}
+
+// ResolveLimit returns the item limit FetchItems should apply: the requested
+// limit, else the provider's configured limit, else its default limit.
+func ResolveLimit(requested, configured, defaultLimit int) int {
+	switch {
+	case requested > 0:
+		return requested
+	case configured > 0:
+		return configured
+	}
+	return defaultLimit
 }
 
 // ProviderRegistry manages registered feed providers.
@@ -132,7 +144,9 @@ func (r *ProviderRegistry) List() []string {
 	return names
 }
 
-// CreateProvider creates a new instance of the specified provider.
+// CreateProvider creates a new instance of the specified provider. The lookup
+// holds the read lock; the factory runs after it is released, so slow
+// factories don't block registration.
 func (r *ProviderRegistry) CreateProvider(name string, config any) (FeedProvider, error) {
 	info, err := r.Get(name)
 	if err != nil {
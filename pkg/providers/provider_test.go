@@ -6,6 +6,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
 )
 
 // Mock implementations for testing
@@ -341,6 +343,58 @@ func TestProviderRegistry_Concurrent(t *testing.T) {
 	}
 }
 
+func TestProviderRegistry_RegisterDuringCreate(t *testing.T) {
+	registry := NewProviderRegistry()
+	if err := registry.Register("base", &ProviderInfo{
+		Name: "base",
+		Factory: func(config any) (FeedProvider, error) {
+			return &mockFeedProvider{}, nil
+		},
+	}); err != nil {
+		t.Fatalf("Register(base) error = %v", err)
+	}
+
+	const numWriters = 8
+	const numReaders = 8
+	const perGoroutine = 200
+
+	var wg sync.WaitGroup
+	for i := range numWriters {
+		wg.Go(func() {
+			for j := range perGoroutine {
+				name := fmt.Sprintf("writer-%d-%d", i, j)
+				if err := registry.Register(name, &ProviderInfo{
+					Name: name,
+					Factory: func(config any) (FeedProvider, error) {
+						return &mockFeedProvider{}, nil
+					},
+				}); err != nil {
+					t.Errorf("Register(%s) error = %v", name, err)
+				}
+			}
+		})
+	}
+	for range numReaders {
+		wg.Go(func() {
+			for range perGoroutine {
+				if _, err := registry.CreateProvider("base", nil); err != nil {
+					t.Errorf("CreateProvider(base) error = %v", err)
+				}
+				for _, name := range registry.List() {
+					if _, err := registry.Get(name); err != nil {
+						t.Errorf("Get(%s) error = %v", name, err)
+					}
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	if got, want := len(registry.List()), numWriters*perGoroutine+1; got != want {
+		t.Fatalf("registered %d providers, want %d", got, want)
+	}
+}
+
 func TestFeedItemInterface(t *testing.T) {
 	now := time.Now()
 	item := &mockFeedItem{
@@ -441,3 +495,29 @@ func TestDefaultRegistry(t *testing.T) {
 	// Clean up for other tests
 	delete(DefaultRegistry.providers, "test-default")
 }
+
+func TestFeedItemAliasesFeedtypes(t *testing.T) {
+	// Slices only convert implicitly when the element types are identical, so
+	// this fails to compile if FeedItem stops aliasing feedtypes.FeedItem.
+	var items []FeedItem = []feedtypes.FeedItem{&mockFeedItem{title: "aliased"}}
+	if items[0].Title() != "aliased" {
+		t.Fatalf("Title() = %q, want aliased", items[0].Title())
+	}
+}
+
+func TestResolveLimit(t *testing.T) {
+	tests := []struct {
+		requested, configured, defaultLimit, want int
+	}{
+		{requested: 5, configured: 10, defaultLimit: 30, want: 5},
+		{requested: 0, configured: 10, defaultLimit: 30, want: 10},
+		{requested: 0, configured: 0, defaultLimit: 30, want: 30},
+		{requested: -1, configured: -1, defaultLimit: 30, want: 30},
+		{requested: 0, configured: 0, defaultLimit: 0, want: 0},
+	}
+	for _, tt := range tests {
+		if got := ResolveLimit(tt.requested, tt.configured, tt.defaultLimit); got != tt.want {
+			t.Errorf("ResolveLimit(%d, %d, %d) = %d, want %d", tt.requested, tt.configured, tt.defaultLimit, got, tt.want)
+		}
+	}
+}
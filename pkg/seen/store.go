@@ -0,0 +1,125 @@
+// Package seen records which feed first emitted each link, so feeds generated
+// in separate runs can drop stories another feed already carried.
+package seen
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/database"
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+	"github.com/lepinkainen/feed-forge/pkg/filesystem"
+	"github.com/lepinkainen/feed-forge/pkg/urlutils"
+)
+
+// DefaultDBName is the shared database file, in the cache directory, used
+// when NewStore is given no path.
+const DefaultDBName = "seen_urls.db"
+
+// DefaultTTL is how long a link stays claimed by its feed after that feed
+// last emitted it.
+const DefaultTTL = 7 * 24 * time.Hour
+
+const schema = `
+	CREATE TABLE IF NOT EXISTS seen_urls (
+		url TEXT PRIMARY KEY,
+		feed TEXT NOT NULL,
+		seen_at TIMESTAMP NOT NULL
+	);
+`
+
+// Store persists the canonical links each feed has emitted.
+type Store struct {
+	db *database.Database
+}
+
+// NewStore opens or creates the seen-URL database at dbPath, or at
+// DefaultDBName in the cache directory when dbPath is empty.
+func NewStore(dbPath string) (*Store, error) {
+	if dbPath == "" {
+		var err error
+		dbPath, err = filesystem.GetDefaultPath(DefaultDBName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := filesystem.EnsureDirectoryExists(dbPath); err != nil {
+		return nil, fmt.Errorf("create seen store directory: %w", err)
+	}
+
+	db, err := database.NewDatabase(database.Config{Path: dbPath})
+	if err != nil {
+		return nil, fmt.Errorf("open seen store: %w", err)
+	}
+	if err := db.ExecuteSchema(schema); err != nil {
+		return nil, fmt.Errorf("create seen store schema: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Filter drops items whose canonical link another feed emitted within ttl
+// before now. Links feedKey itself emitted, links claimed longer ago than ttl
+// and items without a link are kept.
+// All lookups run in one transaction.
+func (s *Store) Filter(feedKey string, items []feedtypes.FeedItem, ttl time.Duration, now time.Time) ([]feedtypes.FeedItem, error) {
+	cutoff := now.Add(-ttl)
+	kept := make([]feedtypes.FeedItem, 0, len(items))
+	err := s.db.Transaction(func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(`SELECT feed, seen_at FROM seen_urls WHERE url = ?`)
+		if err != nil {
+			return fmt.Errorf("prepare seen url lookup: %w", err)
+		}
+		defer func() { _ = stmt.Close() }()
+
+		for _, item := range items {
+			key := urlutils.CanonicalURL(item.Link())
+			if key == "" {
+				kept = append(kept, item)
+				continue
+			}
+			var owner string
+			var seenAt time.Time
+			err := stmt.QueryRow(key).Scan(&owner, &seenAt)
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+			case err != nil:
+				return fmt.Errorf("look up seen url: %w", err)
+			case owner != feedKey && seenAt.After(cutoff):
+				continue
+			}
+			kept = append(kept, item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return kept, nil
+}
+
+// Record claims the canonical links of items for feedKey as of now, taking
+// over links whose previous claim Filter let through.
+func (s *Store) Record(feedKey string, items []feedtypes.FeedItem, now time.Time) error {
+	return s.db.Transaction(func(tx *sql.Tx) error {
+		for _, item := range items {
+			key := urlutils.CanonicalURL(item.Link())
+			if key == "" {
+				continue
+			}
+			if _, err := tx.Exec(`
+				INSERT INTO seen_urls (url, feed, seen_at) VALUES (?, ?, ?)
+				ON CONFLICT(url) DO UPDATE SET feed = excluded.feed, seen_at = excluded.seen_at`,
+				key, feedKey, now.UTC()); err != nil {
+				return fmt.Errorf("record seen url: %w", err)
+			}
+		}
+		return nil
+	})
+}
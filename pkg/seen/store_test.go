@@ -0,0 +1,61 @@
+package seen
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feed"
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+func links(items []feedtypes.FeedItem) []string {
+	out := make([]string, len(items))
+	for i, item := range items {
+		out[i] = item.Link()
+	}
+	return out
+}
+
+func TestStoreFiltersLinksSeenByAnotherFeed(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), DefaultDBName))
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	const ttl = 24 * time.Hour
+	firstRun := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	hn := []feedtypes.FeedItem{&feed.JSONItem{ItemLink: "https://example.com/story"}}
+	if err := store.Record("hackernews.xml", hn, firstRun); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	reddit := []feedtypes.FeedItem{
+		&feed.JSONItem{ItemLink: "https://www.example.com/story/?utm_source=reddit"},
+		&feed.JSONItem{ItemLink: "https://example.com/other"},
+		&feed.JSONItem{},
+	}
+	nextRun := firstRun.Add(time.Hour)
+	got, err := store.Filter("reddit.xml", reddit, ttl, nextRun)
+	if err != nil {
+		t.Fatalf("Filter() error = %v", err)
+	}
+	if want := []string{"https://example.com/other", ""}; !slices.Equal(links(got), want) {
+		t.Fatalf("Filter(reddit) = %v, want %v", links(got), want)
+	}
+
+	if got, _ := store.Filter("hackernews.xml", hn, ttl, nextRun); len(got) != 1 {
+		t.Fatalf("Filter(hackernews) = %v, want the owning feed to keep its link", links(got))
+	}
+
+	afterTTL := firstRun.Add(ttl + time.Minute)
+	got, err = store.Filter("reddit.xml", reddit, ttl, afterTTL)
+	if err != nil {
+		t.Fatalf("Filter(after TTL) error = %v", err)
+	}
+	if len(got) != len(reddit) {
+		t.Fatalf("Filter(after TTL) = %v, want every link allowed again", links(got))
+	}
+}
@@ -0,0 +1,102 @@
+// Package serve provides HTTP handlers for serving generated feeds.
+package serve
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/lepinkainen/feed-forge/pkg/feed"
+	"github.com/lepinkainen/feed-forge/pkg/providers"
+)
+
+const discoveryTemplate = "feed-discovery.html.tmpl"
+
+// DiscoveryFeed describes one feed advertised on the discovery page.
+type DiscoveryFeed struct {
+	Name  string
+	Title string
+	Href  string
+}
+
+// DiscoveryFeeds lists the feeds of every provider in registry, sorted by
+// provider name. Each feed is assumed to live at <baseURL>/<provider>.xml.
+func DiscoveryFeeds(registry *providers.ProviderRegistry, baseURL string) ([]DiscoveryFeed, error) {
+	names := registry.List()
+	sort.Strings(names)
+
+	feeds := make([]DiscoveryFeed, 0, len(names))
+	for _, name := range names {
+		info, err := registry.Get(name)
+		if err != nil {
+			return nil, err
+		}
+
+		href := name + ".xml"
+		if baseURL != "" {
+			joined, err := url.JoinPath(baseURL, href)
+			if err != nil {
+				return nil, fmt.Errorf("build feed URL for %s: %w", name, err)
+			}
+			href = joined
+		}
+
+		feeds = append(feeds, DiscoveryFeed{
+			Name:  name,
+			Title: discoveryTitle(info, name),
+			Href:  href,
+		})
+	}
+	return feeds, nil
+}
+
+func discoveryTitle(info *providers.ProviderInfo, fallback string) string {
+	if info.Preview != nil && info.Preview.Title != "" {
+		return info.Preview.Title
+	}
+	if info.Preview != nil && info.Preview.ProviderName != "" {
+		return info.Preview.ProviderName
+	}
+	return fallback
+}
+
+// DiscoveryHandler serves an HTML page with Atom autodiscovery links for every
+// registered provider. Relative feed links are used when baseURL is empty.
+func DiscoveryHandler(registry *providers.ProviderRegistry, baseURL string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		page, err := renderDiscoveryPage(registry, baseURL)
+		if err != nil {
+			slog.Error("Failed to render discovery page", "error", err)
+			http.Error(w, "failed to render discovery page", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(page)
+	})
+}
+
+func renderDiscoveryPage(registry *providers.ProviderRegistry, baseURL string) ([]byte, error) {
+	feeds, err := DiscoveryFeeds(registry, baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	tmplContent, err := feed.ReadTemplateContent(discoveryTemplate)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New("feed-discovery").Parse(tmplContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse discovery template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct{ Feeds []DiscoveryFeed }{feeds}); err != nil {
+		return nil, fmt.Errorf("failed to execute discovery template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,59 @@
+package serve
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lepinkainen/feed-forge/pkg/feedmeta"
+	"github.com/lepinkainen/feed-forge/pkg/providers"
+)
+
+func testRegistry(t *testing.T) *providers.ProviderRegistry {
+	t.Helper()
+	registry := providers.NewProviderRegistry()
+	for name, title := range map[string]string{"alpha": "Alpha Feed", "beta": "Beta Feed"} {
+		if err := registry.Register(name, &providers.ProviderInfo{
+			Name:    name,
+			Preview: &providers.PreviewInfo{Config: feedmeta.Config{Title: title}},
+		}); err != nil {
+			t.Fatalf("Register(%s) error = %v", name, err)
+		}
+	}
+	return registry
+}
+
+func TestDiscoveryHandler_AlternateLinkPerProvider(t *testing.T) {
+	handler := DiscoveryHandler(testRegistry(t), "https://feeds.example/rss/")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Fatalf("Content-Type = %q, want text/html", ct)
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		`<link rel="alternate" type="application/atom+xml" title="Alpha Feed" href="https://feeds.example/rss/alpha.xml">`,
+		`<link rel="alternate" type="application/atom+xml" title="Beta Feed" href="https://feeds.example/rss/beta.xml">`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("discovery page missing %s\n%s", want, body)
+		}
+	}
+}
+
+func TestDiscoveryFeeds_RelativeLinksWithoutBaseURL(t *testing.T) {
+	feeds, err := DiscoveryFeeds(testRegistry(t), "")
+	if err != nil {
+		t.Fatalf("DiscoveryFeeds() error = %v", err)
+	}
+	if len(feeds) != 2 || feeds[0].Href != "alpha.xml" || feeds[1].Href != "beta.xml" {
+		t.Fatalf("DiscoveryFeeds() = %#v", feeds)
+	}
+}
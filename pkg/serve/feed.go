@@ -0,0 +1,152 @@
+package serve
+
+import (
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feed"
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+	"github.com/lepinkainen/feed-forge/pkg/metrics"
+)
+
+// Feed formats chosen by NegotiateFormat.
+const (
+	FormatAtom = feed.FormatAtom
+	FormatRSS  = feed.FormatRSS
+	FormatJSON = feed.FormatJSON
+)
+
+// Content types served for each format.
+const (
+	ContentTypeAtom = "application/atom+xml; charset=utf-8"
+	ContentTypeRSS  = "application/rss+xml; charset=utf-8"
+	ContentTypeJSON = "application/feed+json; charset=utf-8"
+)
+
+// formatMediaTypes maps the media types readers ask for to feed formats.
+var formatMediaTypes = map[string]string{
+	"application/atom+xml":  FormatAtom,
+	"application/xml":       FormatAtom,
+	"text/xml":              FormatAtom,
+	"application/rss+xml":   FormatRSS,
+	"application/feed+json": FormatJSON,
+	"application/json":      FormatJSON,
+}
+
+// NegotiateFormat picks the feed format for an Accept header: the supported
+// media type with the highest q-value wins, earlier entries winning ties.
+// Wildcards, unsupported types and an empty header give FormatAtom.
+func NegotiateFormat(accept string) string {
+	best, bestQ := FormatAtom, 0.0
+	for mediaRange := range strings.SplitSeq(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+		format, ok := formatMediaTypes[mediaType]
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if qValue, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(qValue, 64); err != nil {
+				continue
+			}
+		}
+		if q > bestQ {
+			best, bestQ = format, q
+		}
+	}
+	return best
+}
+
+// CacheControl is sent with every served feed: readers may store it but must
+// revalidate with If-None-Match before reusing it.
+const CacheControl = "no-cache"
+
+// etagMatches reports whether an If-None-Match header value matches etag,
+// using the weak comparison RFC 9110 requires for If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for candidate := range strings.SplitSeq(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// FeedHandler serves the feed built from the items returned by load, as Atom
+// (rendered with templateName), RSS or JSON Feed depending on the request's
+// Accept header. Responses carry an ETag from feed.ContentETag, and requests
+// whose If-None-Match matches it get 304 Not Modified without rendering.
+// Requests, 304s, renders, load failures and render time are recorded in
+// metrics.Default under name, the provider name generation also reports under.
+func FeedHandler(name string, load func() ([]feedtypes.FeedItem, error), templateName string, config feed.Config) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		metrics.Requests.Inc(name)
+		start := time.Now()
+		items, err := load()
+		if err != nil {
+			metrics.UpstreamErrors.Inc(name)
+			slog.Error("Failed to load feed items", "feed", name, "error", err)
+			http.Error(w, "failed to load feed items", http.StatusBadGateway)
+			return
+		}
+
+		format := NegotiateFormat(r.Header.Get("Accept"))
+		etag := feed.ContentETag(items, format)
+		w.Header().Add("Vary", "Accept")
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", CacheControl)
+		if inm := r.Header.Get("If-None-Match"); inm != "" && etagMatches(inm, etag) {
+			metrics.CacheHits.Inc(name)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		metrics.CacheMisses.Inc(name)
+
+		var body []byte
+		var contentType string
+		switch format {
+		case FormatJSON:
+			body, err = feed.GenerateJSONFeed(items, config)
+			contentType = ContentTypeJSON
+		case FormatRSS:
+			body, err = feed.GenerateRSSFeed(items, config)
+			contentType = ContentTypeRSS
+		default:
+			var atom string
+			atom, err = feed.GenerateAtomFeedWithEmbeddedTemplate(items, templateName, config, nil)
+			body = []byte(atom)
+			contentType = ContentTypeAtom
+		}
+		if err != nil {
+			slog.Error("Failed to render feed", "feed", name, "error", err)
+			http.Error(w, "failed to render feed", http.StatusInternalServerError)
+			return
+		}
+		metrics.ObserveGeneration(name, time.Since(start))
+
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(body)
+	})
+}
+
+// MetricsPath is where NewMux serves the metrics in metrics.Default.
+const MetricsPath = "/metrics"
+
+// NewMux returns a mux serving each handler in feeds at its path, as built by
+// FeedHandler, and the Prometheus metrics at MetricsPath.
+func NewMux(feeds map[string]http.Handler) *http.ServeMux {
+	mux := http.NewServeMux()
+	for path, handler := range feeds {
+		mux.Handle(path, handler)
+	}
+	mux.Handle(MetricsPath, metrics.Handler(metrics.Default))
+	return mux
+}
@@ -0,0 +1,180 @@
+package serve
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feed"
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+)
+
+func TestNegotiateFormat(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{accept: "", want: FormatAtom},
+		{accept: "*/*", want: FormatAtom},
+		{accept: "text/html, */*;q=0.8", want: FormatAtom},
+		{accept: "application/feed+json", want: FormatJSON},
+		{accept: "application/rss+xml, application/atom+xml;q=0.9", want: FormatRSS},
+		{accept: "application/atom+xml;q=0.5, application/json", want: FormatJSON},
+		{accept: "application/rss+xml;q=0, text/xml", want: FormatAtom},
+		{accept: "application/atom+xml, application/feed+json", want: FormatAtom},
+	}
+	for _, tt := range tests {
+		if got := NegotiateFormat(tt.accept); got != tt.want {
+			t.Errorf("NegotiateFormat(%q) = %q, want %q", tt.accept, got, tt.want)
+		}
+	}
+}
+
+func TestFeedHandler_ContentNegotiation(t *testing.T) {
+	items := []feedtypes.FeedItem{&feed.JSONItem{
+		ItemTitle:        "Served post",
+		ItemLink:         "https://example.com/post",
+		ItemCommentsLink: "https://news.example/item?id=1",
+		ItemCreatedAt:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		ItemContent:      "<p>Body</p>",
+	}}
+	handler := FeedHandler("Hacker News", func() ([]feedtypes.FeedItem, error) { return items, nil }, "hackernews-atom",
+		feed.Config{Title: "Served", Link: "https://news.example/", ID: "https://news.example/"})
+
+	serve := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/hackernews", nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Accept %q: status = %d, body = %s", accept, rec.Code, rec.Body)
+		}
+		if vary := rec.Header().Get("Vary"); vary != "Accept" {
+			t.Errorf("Accept %q: Vary = %q, want Accept", accept, vary)
+		}
+		return rec
+	}
+
+	t.Run("atom by default", func(t *testing.T) {
+		rec := serve("")
+		if ct := rec.Header().Get("Content-Type"); ct != ContentTypeAtom {
+			t.Fatalf("Content-Type = %q, want %q", ct, ContentTypeAtom)
+		}
+		var parsed struct {
+			XMLName xml.Name
+			Entries []struct {
+				Title string `xml:"title"`
+			} `xml:"entry"`
+		}
+		if err := xml.Unmarshal(rec.Body.Bytes(), &parsed); err != nil {
+			t.Fatalf("parse Atom: %v", err)
+		}
+		if parsed.XMLName.Local != "feed" || len(parsed.Entries) != 1 || parsed.Entries[0].Title != "Served post" {
+			t.Fatalf("Atom body = %+v", parsed)
+		}
+	})
+
+	t.Run("rss", func(t *testing.T) {
+		rec := serve("application/rss+xml")
+		if ct := rec.Header().Get("Content-Type"); ct != ContentTypeRSS {
+			t.Fatalf("Content-Type = %q, want %q", ct, ContentTypeRSS)
+		}
+		var parsed struct {
+			XMLName xml.Name
+			Version string `xml:"version,attr"`
+			Items   []struct {
+				Title    string `xml:"title"`
+				Link     string `xml:"link"`
+				Comments string `xml:"comments"`
+			} `xml:"channel>item"`
+		}
+		if err := xml.Unmarshal(rec.Body.Bytes(), &parsed); err != nil {
+			t.Fatalf("parse RSS: %v", err)
+		}
+		if parsed.XMLName.Local != "rss" || parsed.Version != "2.0" || len(parsed.Items) != 1 {
+			t.Fatalf("RSS body = %+v", parsed)
+		}
+		if item := parsed.Items[0]; item.Link != "https://example.com/post" || item.Comments != "https://news.example/item?id=1" {
+			t.Errorf("RSS item = %+v", item)
+		}
+	})
+
+	t.Run("json feed", func(t *testing.T) {
+		rec := serve("application/feed+json")
+		if ct := rec.Header().Get("Content-Type"); ct != ContentTypeJSON {
+			t.Fatalf("Content-Type = %q, want %q", ct, ContentTypeJSON)
+		}
+		var parsed struct {
+			Version string `json:"version"`
+			Title   string `json:"title"`
+			Items   []struct {
+				ID          string `json:"id"`
+				URL         string `json:"url"`
+				ExternalURL string `json:"external_url"`
+				ContentHTML string `json:"content_html"`
+			} `json:"items"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &parsed); err != nil {
+			t.Fatalf("parse JSON Feed: %v", err)
+		}
+		if parsed.Version != feed.JSONFeedVersion || parsed.Title != "Served" || len(parsed.Items) != 1 {
+			t.Fatalf("JSON Feed body = %+v", parsed)
+		}
+		if item := parsed.Items[0]; item.URL != "https://news.example/item?id=1" || item.ExternalURL != "https://example.com/post" || item.ContentHTML != "<p>Body</p>" {
+			t.Errorf("JSON Feed item = %+v", item)
+		}
+	})
+}
+
+func TestFeedHandler_ConditionalGet(t *testing.T) {
+	items := []feedtypes.FeedItem{&feed.JSONItem{
+		ItemTitle:        "Cached post",
+		ItemLink:         "https://example.com/post",
+		ItemCommentsLink: "https://news.example/item?id=2",
+		ItemCreatedAt:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		ItemScore:        10,
+	}}
+	handler := FeedHandler("Hacker News", func() ([]feedtypes.FeedItem, error) { return items, nil }, "hackernews-atom",
+		feed.Config{Title: "Served", Link: "https://news.example/", ID: "https://news.example/"})
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/hackernews", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := get("")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Body.Len() == 0 {
+		t.Fatalf("first fetch: status = %d, ETag = %q, body length = %d", first.Code, etag, first.Body.Len())
+	}
+	if cc := first.Header().Get("Cache-Control"); cc != CacheControl {
+		t.Errorf("Cache-Control = %q, want %q", cc, CacheControl)
+	}
+
+	revalidated := get(`"other", ` + etag)
+	if revalidated.Code != http.StatusNotModified || revalidated.Body.Len() != 0 {
+		t.Fatalf("revalidation: status = %d, body length = %d, want 304 with empty body", revalidated.Code, revalidated.Body.Len())
+	}
+	if got := revalidated.Header().Get("ETag"); got != etag {
+		t.Errorf("304 ETag = %q, want %q", got, etag)
+	}
+
+	if stale := get(`"stale"`); stale.Code != http.StatusOK {
+		t.Errorf("stale If-None-Match: status = %d, want 200", stale.Code)
+	}
+
+	items[0].(*feed.JSONItem).ItemScore = 11
+	if changed := get(etag); changed.Code != http.StatusOK || changed.Header().Get("ETag") == etag {
+		t.Errorf("changed feed: status = %d, ETag = %q, want 200 with new ETag", changed.Code, changed.Header().Get("ETag"))
+	}
+}
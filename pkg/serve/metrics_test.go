@@ -0,0 +1,82 @@
+package serve
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lepinkainen/feed-forge/pkg/feed"
+	"github.com/lepinkainen/feed-forge/pkg/feedtypes"
+	"github.com/lepinkainen/feed-forge/pkg/metrics"
+)
+
+func TestMetricsHandler_ScrapeAfterFeedRequests(t *testing.T) {
+	items := []feedtypes.FeedItem{&feed.JSONItem{
+		ItemTitle:     "Measured post",
+		ItemLink:      "https://example.com/measured",
+		ItemCreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}}
+	mux := NewMux(map[string]http.Handler{
+		"/measured.xml": FeedHandler("Measured", func() ([]feedtypes.FeedItem, error) { return items, nil }, "hackernews-atom",
+			feed.Config{Title: "Measured feed", Link: "https://news.example/", ID: "https://news.example/"}),
+		"/broken.xml": FeedHandler("Broken", func() ([]feedtypes.FeedItem, error) { return nil, errors.New("upstream down") }, "hackernews-atom",
+			feed.Config{Title: "Broken feed"}),
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	get := func(path, ifNoneMatch string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("GET %s error = %v", path, err)
+		}
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+
+	first := get("/measured.xml", "")
+	get("/measured.xml", first.Header.Get("ETag"))
+	get("/broken.xml", "")
+
+	resp := get(MetricsPath, "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("/metrics status = %d, want 200", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != metrics.ContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, metrics.ContentType)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(body)
+
+	for _, want := range []string{
+		"# TYPE feed_forge_requests_total counter",
+		"# TYPE feed_forge_cache_hits_total counter",
+		"# TYPE feed_forge_cache_misses_total counter",
+		"# TYPE feed_forge_upstream_errors_total counter",
+		"# TYPE feed_forge_last_generation_duration_seconds gauge",
+		`feed_forge_requests_total{feed="Measured"} 2`,
+		`feed_forge_cache_hits_total{feed="Measured"} 1`,
+		`feed_forge_cache_misses_total{feed="Measured"} 1`,
+		`feed_forge_upstream_errors_total{feed="Broken"} 1`,
+		`feed_forge_last_generation_duration_seconds{feed="Measured"} `,
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("/metrics missing %q\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+// Package textutils provides helpers for the text of feed items: titles,
+// summaries and the counts shown next to them.
+package textutils
+
+import "strconv"
+
+// AbbreviateCount shortens large counts for display: 999 stays "999", 1000
+// becomes "1k", 1500 "1.5k", 12345 "12.3k" and 1000000 "1M". One decimal is
+// kept and truncated, never rounded up, so a count is never overstated.
+func AbbreviateCount(n int) string {
+	if n < 0 {
+		return "-" + AbbreviateCount(-n)
+	}
+	switch {
+	case n < 1_000:
+		return strconv.Itoa(n)
+	case n < 1_000_000:
+		return abbreviate(n, 1_000, "k")
+	case n < 1_000_000_000:
+		return abbreviate(n, 1_000_000, "M")
+	default:
+		return abbreviate(n, 1_000_000_000, "B")
+	}
+}
+
+func abbreviate(n, unit int, suffix string) string {
+	tenths := n / (unit / 10)
+	s := strconv.Itoa(tenths / 10)
+	if digit := tenths % 10; digit != 0 {
+		s += "." + strconv.Itoa(digit)
+	}
+	return s + suffix
+}
@@ -0,0 +1,28 @@
+package textutils
+
+import "testing"
+
+func TestAbbreviateCount(t *testing.T) {
+	tests := []struct {
+		in   int
+		want string
+	}{
+		{in: 0, want: "0"},
+		{in: 999, want: "999"},
+		{in: 1000, want: "1k"},
+		{in: 1049, want: "1k"},
+		{in: 1500, want: "1.5k"},
+		{in: 12345, want: "12.3k"},
+		{in: 999999, want: "999.9k"},
+		{in: 1000000, want: "1M"},
+		{in: 2750000, want: "2.7M"},
+		{in: 1000000000, want: "1B"},
+		{in: -1500, want: "-1.5k"},
+	}
+
+	for _, tt := range tests {
+		if got := AbbreviateCount(tt.in); got != tt.want {
+			t.Errorf("AbbreviateCount(%d) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package textutils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// emojiRanges covers pictographic emoji and the joiners/modifiers used to
+// build emoji sequences. Arrows, math and other plain symbols are left alone.
+var emojiRanges = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x200d, Hi: 0x200d, Stride: 1}, // zero width joiner
+		{Lo: 0x20e3, Hi: 0x20e3, Stride: 1}, // combining enclosing keycap
+		{Lo: 0x2600, Hi: 0x27bf, Stride: 1}, // miscellaneous symbols, dingbats
+		{Lo: 0x2b50, Hi: 0x2b55, Stride: 1}, // stars and circles
+		{Lo: 0xfe0e, Hi: 0xfe0f, Stride: 1}, // variation selectors
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1f000, Hi: 0x1faff, Stride: 1}, // pictographs, emoticons, flags, skin tones
+		{Lo: 0xe0020, Hi: 0xe007f, Stride: 1}, // tag sequences (subdivision flags)
+	},
+}
+
+// StripEmoji removes emoji from s and collapses the whitespace left behind.
+func StripEmoji(s string) string {
+	stripped := strings.Map(func(r rune) rune {
+		if unicode.Is(emojiRanges, r) {
+			return -1
+		}
+		return r
+	}, s)
+	if stripped == s {
+		return s
+	}
+	return CollapseWhitespace(stripped)
+}
@@ -0,0 +1,29 @@
+package textutils
+
+import "testing"
+
+func TestStripEmoji(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "plain text", in: "Show HN: A tiny  database", want: "Show HN: A tiny  database"},
+		{name: "leading emoji", in: "🚀 Launching today", want: "Launching today"},
+		{name: "inline emoji", in: "Cats 🐱 and dogs 🐶 agree", want: "Cats and dogs agree"},
+		{name: "zwj sequence", in: "Family 👨‍👩‍👧 photo", want: "Family photo"},
+		{name: "skin tone and selector", in: "Thumbs 👍🏽 up ❤️", want: "Thumbs up"},
+		{name: "flag", in: "Suomi 🇫🇮 voittaa", want: "Suomi voittaa"},
+		{name: "arrows preserved", in: "Go → Rust ← C ↑", want: "Go → Rust ← C ↑"},
+		{name: "symbols preserved", in: "Price €5 © 2024 ±1", want: "Price €5 © 2024 ±1"},
+		{name: "only emoji", in: "🔥🔥🔥", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripEmoji(tt.in); got != tt.want {
+				t.Fatalf("StripEmoji(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package textutils
+
+import "unicode"
+
+// rtlScripts are the right-to-left scripts IsRTL counts.
+var rtlScripts = []*unicode.RangeTable{
+	unicode.Arabic,
+	unicode.Hebrew,
+	unicode.Syriac,
+	unicode.Thaana,
+	unicode.Nko,
+	unicode.Samaritan,
+	unicode.Mandaic,
+	unicode.Adlam,
+}
+
+// IsRTL reports whether s is predominantly right-to-left text: more of its
+// letters are in a right-to-left script than in any other. Digits,
+// punctuation and whitespace are ignored, so s without letters is not RTL.
+func IsRTL(s string) bool {
+	rtl, other := 0, 0
+	for _, r := range s {
+		switch {
+		case unicode.IsOneOf(rtlScripts, r):
+			rtl++
+		case unicode.IsLetter(r):
+			other++
+		}
+	}
+	return rtl > other
+}
@@ -0,0 +1,28 @@
+package textutils
+
+import "testing"
+
+func TestIsRTL(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want bool
+	}{
+		{name: "arabic", in: "مرحبا بالعالم", want: true},
+		{name: "hebrew", in: "שלום עולם", want: true},
+		{name: "arabic with latin brand", in: "إطلاق Go 1.26 اليوم رسميا", want: true},
+		{name: "english", in: "Hello, world", want: false},
+		{name: "english quoting hebrew", in: "The word שלום means peace in Hebrew", want: false},
+		{name: "finnish", in: "Hyvää huomenta", want: false},
+		{name: "digits and punctuation only", in: "2024 — 100%!", want: false},
+		{name: "empty", in: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRTL(tt.in); got != tt.want {
+				t.Fatalf("IsRTL(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package textutils
+
+import "strings"
+
+// CollapseWhitespace replaces each run of spaces, tabs and newlines in s with
+// a single space and trims both ends.
+func CollapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,25 @@
+package textutils
+
+import "testing"
+
+func TestCollapseWhitespace(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "single spaces kept", in: "Show HN: A tiny database", want: "Show HN: A tiny database"},
+		{name: "multiple spaces", in: "Show HN:   A    tiny database", want: "Show HN: A tiny database"},
+		{name: "tabs and newlines", in: "Ask HN:\tWhat\n\nare you\r\n working on?", want: "Ask HN: What are you working on?"},
+		{name: "ends trimmed", in: " \n\t Title \t\n", want: "Title"},
+		{name: "only whitespace", in: " \t\n ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CollapseWhitespace(tt.in); got != tt.want {
+				t.Fatalf("CollapseWhitespace(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package urlutils
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// trackingParams are query parameters that never change what a link points to.
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"ref":     true,
+	"ref_src": true,
+}
+
+// CanonicalURL normalises a link for equality checks: the scheme and host are
+// lowercased, a default port and a leading "www." are dropped, tracking
+// parameters (utm_* and friends) and the fragment are removed, the remaining
+// query is sorted, and a trailing slash is trimmed. Unparseable input is
+// returned trimmed.
+func CanonicalURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Host)
+	if port := u.Port(); (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		host = strings.ToLower(u.Hostname())
+	}
+	u.Host = strings.TrimPrefix(host, "www.")
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	query := u.Query()
+	for key := range query {
+		if strings.HasPrefix(key, "utm_") || trackingParams[key] {
+			query.Del(key)
+		}
+	}
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, url.QueryEscape(key)+"="+url.QueryEscape(value))
+		}
+	}
+	u.RawQuery = strings.Join(parts, "&")
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+
+	return u.String()
+}
+
+// StripQuery removes the whole query string from a link, keeping the scheme,
+// host, path and fragment as they were. Links without a query and
+// unparseable input are returned unchanged.
+func StripQuery(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.RawQuery == "" && !u.ForceQuery) {
+		return rawURL
+	}
+	u.RawQuery = ""
+	u.ForceQuery = false
+	return u.String()
+}
+
+// NormalizeTitle reduces a title to lowercase letters and digits separated by
+// single spaces, so punctuation, case and spacing differences compare equal.
+func NormalizeTitle(title string) string {
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,67 @@
+package urlutils
+
+import "testing"
+
+func TestCanonicalURL(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "already canonical", in: "https://example.com/story", want: "https://example.com/story"},
+		{name: "case and www", in: "HTTPS://WWW.Example.com/story", want: "https://example.com/story"},
+		{name: "default port", in: "https://example.com:443/story", want: "https://example.com/story"},
+		{name: "explicit port kept", in: "https://example.com:8443/story", want: "https://example.com:8443/story"},
+		{name: "scheme kept", in: "http://example.com/story", want: "http://example.com/story"},
+		{name: "http default port", in: "http://example.com:80/story", want: "http://example.com/story"},
+		{name: "trailing slash and fragment", in: "https://example.com/story/#comments", want: "https://example.com/story"},
+		{name: "tracking params dropped", in: "https://example.com/story?utm_source=hn&id=7&fbclid=x", want: "https://example.com/story?id=7"},
+		{name: "query sorted", in: "https://example.com/s?b=2&a=1", want: "https://example.com/s?a=1&b=2"},
+		{name: "path case kept", in: "https://example.com/Story", want: "https://example.com/Story"},
+		{name: "not a url", in: "  not a url ", want: "not a url"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanonicalURL(tt.in); got != tt.want {
+				t.Fatalf("CanonicalURL(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripQuery(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "https://example.com/r/golang/comments/abc/post/?utm_source=share&utm_medium=web", want: "https://example.com/r/golang/comments/abc/post/"},
+		{in: "https://example.com/story?id=7#comments", want: "https://example.com/story#comments"},
+		{in: "https://example.com/story?", want: "https://example.com/story"},
+		{in: "https://example.com/Path%2FEncoded", want: "https://example.com/Path%2FEncoded"},
+		{in: "not a url", want: "not a url"},
+	}
+	for _, tt := range tests {
+		if got := StripQuery(tt.in); got != tt.want {
+			t.Errorf("StripQuery(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "Show HN: A Tiny Database!", want: "show hn a tiny database"},
+		{in: "  show hn —  a tiny database ", want: "show hn a tiny database"},
+		{in: "Ääkköset ja 2024", want: "ääkköset ja 2024"},
+		{in: "???", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeTitle(tt.in); got != tt.want {
+			t.Errorf("NormalizeTitle(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -1,4 +1,5 @@
-// Package urlutils provides URL and common helper functions.
+// Package urlutils provides URL validation, canonicalization and resolution
+// helpers. Text helpers live in textutils.
 package urlutils
 
 import (